@@ -1,17 +1,23 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
 
 	. "github.com/g2a-com/cicd/internal/blueprint"
+	"github.com/g2a-com/cicd/internal/cache"
 	"github.com/g2a-com/cicd/internal/flags"
 	"github.com/g2a-com/cicd/internal/object"
+	"github.com/g2a-com/cicd/internal/plan"
+	. "github.com/g2a-com/cicd/internal/scheduler"
 	"github.com/g2a-com/cicd/internal/schema"
 	"github.com/g2a-com/cicd/internal/script"
+	"github.com/g2a-com/cicd/internal/secrets"
 	"github.com/g2a-com/cicd/internal/utils"
 	log "github.com/g2a-com/klio-logger-go/v2"
+	"github.com/hashicorp/go-multierror"
 )
 
 func main() {
@@ -22,17 +28,32 @@ func main() {
 
 	// Parse options
 	opts := options{
+		Concurrency: 1,
+		CacheFile:   "build-cache.json",
 		ResultFile:  "build-result.json",
 		ProjectFile: utils.FindProjectFile(),
 	}
 	flags.ParseArgs(&opts, os.Args)
+	opts.Plan = opts.Plan || opts.PlanFile != ""
 
 	// Prepare logger
-	l := log.StandardLogger()
+	var l log.Logger = log.StandardLogger()
+
+	// Masker is prepared after loading blueprint
+	var masker *secrets.Masker
 
 	// Handle results
 	result := &Result{}
-	defer utils.SaveResult(opts.ResultFile, result)
+	if !opts.Plan {
+		defer func() {
+			reason := recover()
+			result.Finish(reason)
+			utils.SaveResult(opts.ResultFile, result, masker)
+			if reason != nil {
+				panic(reason)
+			}
+		}()
+	}
 
 	// Check if project file exists
 	if !utils.FileExists(opts.ProjectFile) {
@@ -46,7 +67,9 @@ func main() {
 		Services: opts.Services,
 		Preprocessors: []Preprocessor{
 			schema.Validate,
-			schema.Migrate,
+		},
+		DocumentPreprocessors: []DocumentPreprocessor{
+			schema.MigrateDocument,
 		},
 	}
 	err = blueprint.Load(filepath.Join(utils.FindCommandDirectory(), "assets", "executors", "*", "*.yaml"))
@@ -58,10 +81,57 @@ func main() {
 	err = blueprint.Validate()
 	assert(err == nil, err)
 
+	// Mask values of secrets in logs and results
+	masker = secrets.NewMasker(blueprint.ListSecretValues()...)
+	l = masker.Logger(l)
+
+	// Restrict commands which can be run by executors
+	policy := blueprint.GetProject().ExecutorPolicy()
+
+	// Make libraries importable by executors
+	libraries := blueprint.ListLibraries()
+
 	// Change working directory
 	err = os.Chdir(blueprint.GetProject().Directory())
 	assert(err == nil, err)
 
+	// Print plan
+	if opts.Plan {
+		p := &plan.Plan{}
+		for _, service := range blueprint.ListServices() {
+			if len(service.Entries(object.BuildEntryType)) == 0 {
+				continue
+			}
+			entryTypes := []string{object.TagEntryType, object.BuildEntryType}
+			if opts.Push {
+				entryTypes = append(entryTypes, object.PushEntryType)
+			}
+			for _, entryType := range entryTypes {
+				for _, entry := range service.Entries(entryType) {
+					p.Add(&blueprint, service, entryType, entry)
+				}
+			}
+		}
+		err = p.Print(l)
+		assert(err == nil, err)
+		if opts.PlanFile != "" {
+			utils.SaveResult(opts.PlanFile, p, masker)
+		}
+		return
+	}
+
+	// Handle interruptions and time limits
+	timeout := blueprint.GetProject().Timeout()
+	if opts.Timeout > 0 {
+		timeout = opts.Timeout
+	}
+	ctx, cancel := utils.InterruptContext(l, timeout)
+	defer cancel()
+	result.EntryTimeout = blueprint.GetProject().EntryTimeout()
+	if opts.EntryTimeout > 0 {
+		result.EntryTimeout = opts.EntryTimeout
+	}
+
 	// Helper for getting executors
 	getExecutor := func(kind object.Kind, name string) object.Executor {
 		e, ok := blueprint.GetExecutor(kind, name)
@@ -69,43 +139,130 @@ func main() {
 		return e
 	}
 
+	// Load build cache
+	var buildCache *cache.Cache
+	if opts.Cache {
+		buildCache, err = cache.Load(opts.CacheFile)
+		assert(err == nil, err)
+		defer func() {
+			if err := buildCache.Save(); err != nil {
+				l.WithLevel(log.WarnLevel).Printf("Cannot save build cache: %s", err)
+			}
+		}()
+	}
+
+	// Helper for computing fingerprints of services
+	getFingerprint := func(service object.Object) (string, error) {
+		values := []interface{}{}
+		for _, entryType := range []string{object.BuildEntryType, object.PushEntryType} {
+			for _, entry := range service.Entries(entryType) {
+				executor := getExecutor(entry.ExecutorKind(), entry.ExecutorName())
+				values = append(values, []interface{}{
+					entry.ExecutorKind(), entry.ExecutorName(), entry.Spec(&blueprint), executor.Script(),
+				})
+			}
+		}
+		ignore := []string{opts.CacheFile, opts.ResultFile}
+		return cache.Fingerprint(service.Directory(), ignore, values...)
+	}
+
+	// Prepare scheduler
+	scheduler := &Scheduler{
+		Concurrency: opts.Concurrency,
+		Logger:      l,
+		KeepGoing:   opts.KeepGoing,
+		Context:     ctx,
+		OnSkip: func(service object.Object) {
+			l.WithTags(service.Name()).WithLevel(log.WarnLevel).Print("Skipping, because services it depends on failed")
+			result.addFailed(service)
+			result.SkipEntries(service, object.TagEntryType, object.BuildEntryType)
+		},
+	}
+
+	// Failures collected in the keep-going mode
+	var errs *multierror.Error
+
 	// Build
-	for _, service := range blueprint.ListServices() {
-		l := l.WithTags(service.Name())
+	err = scheduler.Run(blueprint.ListServices(), func(service object.Object, l log.Logger) (err error) {
+		l = l.WithTags(service.Name())
+
+		defer func() {
+			if r := recover(); r != nil {
+				result.addFailed(service)
+				panic(r)
+			}
+			if err != nil {
+				result.addFailed(service)
+			}
+		}()
 
 		if len(service.Entries(object.BuildEntryType)) == 0 {
 			l.WithLevel(log.VerboseLevel).Print("No artifacts to build")
-			continue
+			return nil
 		}
 
 		// Generate tags
-		for _, entry := range service.Entries(object.TagEntryType) {
+		err = result.RunEntries(ctx, service, object.TagEntryType, func(ctx context.Context, entry object.Entry) error {
 			s := script.New(getExecutor(entry.ExecutorKind(), entry.ExecutorName()))
 			s.Logger = l
+			s.Masker = masker
+			s.Policy = policy
+			s.Libraries = libraries
 
-			res, err := s.Run(TaggerInput{
+			res, err := s.RunContext(ctx, TaggerInput{
 				Spec: entry.Spec(&blueprint),
 				Dirs: Dirs{
 					Project: blueprint.GetProject().Directory(),
 					Service: service.Directory(),
 				},
 			})
-			assert(err == nil, err)
+			if err != nil {
+				return err
+			}
 
-			result.addTags(service, entry, res)
+			result.addTags(service, entry, script.IDs(res))
+			return nil
+		})
+		if err != nil {
+			result.SkipEntries(service, object.BuildEntryType)
+			return err
 		}
 
 		if len(result.getTags(service)) == 0 {
 			l.WithLevel(log.WarnLevel).Print("No tags to build")
-			continue
+			result.SkipEntries(service, object.BuildEntryType)
+			return nil
+		}
+
+		// Skip services which didn't change since the last build
+		fingerprint := ""
+		if buildCache != nil {
+			fp, err := getFingerprint(service)
+			if err != nil {
+				result.SkipEntries(service, object.BuildEntryType)
+				return err
+			}
+			fingerprint = fp
+
+			cached, ok := buildCache.Get(service.Name())
+			if ok && cached.Fingerprint == fingerprint && equal(cached.Tags, result.getTags(service)) {
+				l.Print("Nothing changed since the last build, using cached artifacts")
+				result.restoreArtifacts(service, cached.Artifacts)
+				result.addCached(service)
+				result.SkipEntries(service, object.BuildEntryType)
+				return nil
+			}
 		}
 
 		// Build artifacts
-		for _, entry := range service.Entries(object.BuildEntryType) {
+		err = result.RunEntries(ctx, service, object.BuildEntryType, func(ctx context.Context, entry object.Entry) error {
 			s := script.New(getExecutor(entry.ExecutorKind(), entry.ExecutorName()))
 			s.Logger = l
+			s.Masker = masker
+			s.Policy = policy
+			s.Libraries = libraries
 
-			res, err := s.Run(BuilderInput{
+			res, err := s.RunContext(ctx, BuilderInput{
 				Spec: entry.Spec(&blueprint),
 				Tags: result.getTags(service),
 				Dirs: Dirs{
@@ -113,37 +270,101 @@ func main() {
 					Service: service.Directory(),
 				},
 			})
-			assert(err == nil, err)
+			if err != nil {
+				return err
+			}
 
 			result.addArtifacts(service, entry, res)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		if buildCache != nil {
+			entry := result.getCacheEntry(service)
+			entry.Fingerprint = fingerprint
+			buildCache.Set(service.Name(), entry)
 		}
-	}
+
+		return nil
+	})
+	assert(err == nil || opts.KeepGoing, err)
+	errs = multierror.Append(errs, err)
 
 	// Push artifacts
 	if opts.Push {
+		services := []object.Object{}
 		for _, service := range blueprint.ListServices() {
-			l := l.WithTags("push", service.Name())
+			if !result.isFailed(service) {
+				services = append(services, service)
+			}
+		}
 
-			for _, entry := range service.Entries(object.PushEntryType) {
+		scheduler.OnSkip = func(service object.Object) {
+			l.WithTags("push", service.Name()).WithLevel(log.WarnLevel).Print("Skipping, because services it depends on failed")
+			result.SkipEntries(service, object.PushEntryType)
+		}
+
+		err = scheduler.Run(services, func(service object.Object, l log.Logger) error {
+			l = l.WithTags("push", service.Name())
+
+			if result.isCached(service) {
+				cached, _ := buildCache.Get(service.Name())
+				if cached.Pushed {
+					l.Print("Artifacts were already pushed")
+					result.restorePushedArtifacts(service, cached.PushedArtifacts)
+					result.SkipEntries(service, object.PushEntryType)
+					return nil
+				}
+			}
+
+			err := result.RunEntries(ctx, service, object.PushEntryType, func(ctx context.Context, entry object.Entry) error {
 				s := script.New(getExecutor(entry.ExecutorKind(), entry.ExecutorName()))
 				s.Logger = l
+				s.Masker = masker
+				s.Policy = policy
+				s.Libraries = libraries
 
-				res, err := s.Run(PusherInput{
-					Spec:      entry.Spec(&blueprint),
-					Tags:      result.getTags(service),
-					Artifacts: result.getArtifacts(service, entry),
+				artifacts := result.getArtifacts(service, entry)
+				res, err := s.RunContext(ctx, PusherInput{
+					Spec:            entry.Spec(&blueprint),
+					Tags:            result.getTags(service),
+					Artifacts:       script.IDs(artifacts),
+					ArtifactDetails: script.Maps(artifacts),
 					Dirs: Dirs{
 						Project: blueprint.GetProject().Directory(),
 						Service: service.Directory(),
 					},
 				})
-				assert(err == nil, err)
+				if err != nil {
+					return err
+				}
 
 				result.addPushedArtifacts(service, entry, res)
+				return nil
+			})
+			if err != nil {
+				return err
 			}
-		}
+
+			if buildCache != nil {
+				if cached, ok := buildCache.Get(service.Name()); ok {
+					entry := result.getCacheEntry(service)
+					entry.Fingerprint = cached.Fingerprint
+					entry.Pushed = true
+					buildCache.Set(service.Name(), entry)
+				}
+			}
+
+			return nil
+		})
+		assert(err == nil || opts.KeepGoing, err)
+		errs = multierror.Append(errs, err)
 	}
 
+	assert(errs.ErrorOrNil() == nil, errs)
+
 	// Print success message
 	switch count := len(blueprint.ListServices()); count {
 	case 0:
@@ -155,6 +376,18 @@ func main() {
 	}
 }
 
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
 func assert(condition bool, err interface{}) {
 	if !condition {
 		panic(err)
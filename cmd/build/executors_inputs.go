@@ -15,7 +15,9 @@ type PusherInput struct {
 	Spec      interface{} `tengo:"spec"`
 	Tags      []string    `tengo:"tags"`
 	Artifacts []string    `tengo:"artifacts"`
-	Dirs      Dirs        `tengo:"dirs"`
+	// Artifacts as maps with the "id" field and metadata
+	ArtifactDetails []map[string]interface{} `tengo:"artifactDetails"`
+	Dirs            Dirs                     `tengo:"dirs"`
 }
 
 type Dirs struct {
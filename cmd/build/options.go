@@ -1,15 +1,27 @@
 package main
 
-import "github.com/g2a-com/cicd/internal/object"
+import (
+	"time"
+
+	"github.com/g2a-com/cicd/internal/object"
+)
 
 type options struct {
 	object.GenericObject
 
-	Push        bool              `flag:"push" alias:"p" help:"Push artifacts to remote registry"`
-	Services    []string          `flag:"services" alias:"s" help:"List of services to build (skip to build all services)"`
-	Params      map[string]string `flag:"param" help:"Parameters to use in configuration files (key=value pairs)"`
-	ProjectFile string            `flag:"project-file" alias:"f" help:"Path to project file"`
-	ResultFile  string            `flag:"result-file" help:"Where to write result file"`
+	Push         bool              `flag:"push" alias:"p" help:"Push artifacts to remote registry"`
+	Concurrency  int               `flag:"concurrency" help:"Maximal number of services built at the same time"`
+	KeepGoing    bool              `flag:"keep-going" help:"Continue building other services when one of them fails"`
+	Cache        bool              `flag:"cache" help:"Skip building services which didn't change since the last build"`
+	CacheFile    string            `flag:"cache-file" help:"Where to keep fingerprints of built services"`
+	Services     []string          `flag:"services" alias:"s" help:"List of services to build (skip to build all services)"`
+	Params       map[string]string `flag:"param" help:"Parameters to use in configuration files (key=value pairs)"`
+	Plan         bool              `flag:"plan" help:"Print resolved entries without running executors"`
+	PlanFile     string            `flag:"plan-file" help:"Where to write plan as JSON (implies --plan)"`
+	Timeout      time.Duration     `flag:"timeout" help:"Time limit for the whole command, e.g. 1h (overrides project configuration)"`
+	EntryTimeout time.Duration     `flag:"entry-timeout" help:"Time limit for entries which don't define their own one, e.g. 10m (overrides project configuration)"`
+	ProjectFile  string            `flag:"project-file" alias:"f" help:"Path to project file"`
+	ResultFile   string            `flag:"result-file" help:"Where to write result file"`
 }
 
 func (o options) Kind() object.Kind {
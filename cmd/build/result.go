@@ -1,22 +1,38 @@
 package main
 
 import (
+	"sort"
+	"sync"
+
+	"github.com/g2a-com/cicd/internal/cache"
 	"github.com/g2a-com/cicd/internal/object"
+	"github.com/g2a-com/cicd/internal/results"
+	"github.com/g2a-com/cicd/internal/script"
 )
 
-type ResultEntry struct {
-	Service string `json:"service"`
-	Entry   int    `json:"entry"`
-	Result  string `json:"result"`
+func newResultEntry(service object.Object, entryIndex int, r script.Result) results.ResultEntry {
+	return results.ResultEntry{Service: service.Name(), Entry: entryIndex, Result: r.ID, Metadata: r.Metadata}
 }
 
+// Result is safe for concurrent use. Entries are kept ordered by service name
+// and entry index, so the result file doesn't depend on the order in which
+// services were processed.
 type Result struct {
-	Tags            []ResultEntry `json:"tags"`
-	Artifacts       []ResultEntry `json:"artifacts"`
-	PushedArtifacts []ResultEntry `json:"pushedArtifacts"`
+	results.Summary
+
+	Tags            []results.ResultEntry `json:"tags"`
+	Artifacts       []results.ResultEntry `json:"artifacts"`
+	PushedArtifacts []results.ResultEntry `json:"pushedArtifacts"`
+	Cached          []string              `json:"cached,omitempty"`
+
+	failed map[string]bool
+	mutex  sync.Mutex
 }
 
 func (r *Result) getTags(service object.Object) (tags []string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
 	for _, r := range r.Tags {
 		if r.Service == service.Name() {
 			tags = append(tags, r.Result)
@@ -26,28 +42,137 @@ func (r *Result) getTags(service object.Object) (tags []string) {
 }
 
 func (r *Result) addTags(service object.Object, entry object.Entry, tags []string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
 	for _, tag := range tags {
-		r.Tags = append(r.Tags, ResultEntry{service.Name(), entry.Index(), tag})
+		r.Tags = append(r.Tags, results.ResultEntry{Service: service.Name(), Entry: entry.Index(), Result: tag})
 	}
+	sortEntries(r.Tags)
 }
 
-func (r *Result) getArtifacts(service object.Object, entry object.Entry) (artifacts []string) {
+// getArtifacts returns artifacts built for the entry together with their
+// metadata.
+func (r *Result) getArtifacts(service object.Object, entry object.Entry) []script.Result {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	artifacts := []script.Result{}
 	for _, r := range r.Artifacts {
 		if r.Service == service.Name() && r.Entry == entry.Index() {
-			artifacts = append(artifacts, r.Result)
+			artifacts = append(artifacts, script.Result{ID: r.Result, Metadata: r.Metadata})
 		}
 	}
 	return artifacts
 }
 
-func (r *Result) addArtifacts(service object.Object, entry object.Entry, artifacts []string) {
+func (r *Result) addArtifacts(service object.Object, entry object.Entry, artifacts []script.Result) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
 	for _, artifact := range artifacts {
-		r.Artifacts = append(r.Artifacts, ResultEntry{service.Name(), entry.Index(), artifact})
+		r.Artifacts = append(r.Artifacts, newResultEntry(service, entry.Index(), artifact))
 	}
+	sortEntries(r.Artifacts)
 }
 
-func (r *Result) addPushedArtifacts(service object.Object, entry object.Entry, artifacts []string) {
+func (r *Result) addPushedArtifacts(service object.Object, entry object.Entry, artifacts []script.Result) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
 	for _, artifact := range artifacts {
-		r.PushedArtifacts = append(r.PushedArtifacts, ResultEntry{service.Name(), entry.Index(), artifact})
+		r.PushedArtifacts = append(r.PushedArtifacts, newResultEntry(service, entry.Index(), artifact))
+	}
+	sortEntries(r.PushedArtifacts)
+}
+
+func (r *Result) addCached(service object.Object) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.Cached = append(r.Cached, service.Name())
+	sort.Strings(r.Cached)
+}
+
+// getCacheEntry returns results of the service in the form stored in the
+// build cache.
+func (r *Result) getCacheEntry(service object.Object) cache.Entry {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	entry := cache.Entry{}
+	for _, e := range r.Tags {
+		if e.Service == service.Name() {
+			entry.Tags = append(entry.Tags, e.Result)
+		}
 	}
+	for _, e := range r.Artifacts {
+		if e.Service == service.Name() {
+			entry.Artifacts = append(entry.Artifacts, cache.Result{Entry: e.Entry, Result: e.Result, Metadata: e.Metadata})
+		}
+	}
+	for _, e := range r.PushedArtifacts {
+		if e.Service == service.Name() {
+			entry.PushedArtifacts = append(entry.PushedArtifacts, cache.Result{Entry: e.Entry, Result: e.Result, Metadata: e.Metadata})
+		}
+	}
+	return entry
+}
+
+func (r *Result) restoreArtifacts(service object.Object, artifacts []cache.Result) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, a := range artifacts {
+		r.Artifacts = append(r.Artifacts, results.ResultEntry{Service: service.Name(), Entry: a.Entry, Result: a.Result, Metadata: a.Metadata})
+	}
+	sortEntries(r.Artifacts)
+}
+
+func (r *Result) restorePushedArtifacts(service object.Object, artifacts []cache.Result) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, a := range artifacts {
+		r.PushedArtifacts = append(r.PushedArtifacts, results.ResultEntry{Service: service.Name(), Entry: a.Entry, Result: a.Result, Metadata: a.Metadata})
+	}
+	sortEntries(r.PushedArtifacts)
+}
+
+func (r *Result) isCached(service object.Object) bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, name := range r.Cached {
+		if name == service.Name() {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Result) addFailed(service object.Object) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.failed == nil {
+		r.failed = map[string]bool{}
+	}
+	r.failed[service.Name()] = true
+}
+
+func (r *Result) isFailed(service object.Object) bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.failed[service.Name()]
+}
+
+func sortEntries(entries []results.ResultEntry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Service != entries[j].Service {
+			return entries[i].Service < entries[j].Service
+		}
+		return entries[i].Entry < entries[j].Entry
+	})
 }
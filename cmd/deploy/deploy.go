@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -9,8 +10,12 @@ import (
 	. "github.com/g2a-com/cicd/internal/blueprint"
 	"github.com/g2a-com/cicd/internal/flags"
 	"github.com/g2a-com/cicd/internal/object"
+	"github.com/g2a-com/cicd/internal/plan"
+	"github.com/g2a-com/cicd/internal/results"
+	. "github.com/g2a-com/cicd/internal/scheduler"
 	"github.com/g2a-com/cicd/internal/schema"
 	"github.com/g2a-com/cicd/internal/script"
+	"github.com/g2a-com/cicd/internal/secrets"
 	"github.com/g2a-com/cicd/internal/utils"
 	log "github.com/g2a-com/klio-logger-go/v2"
 )
@@ -27,19 +32,39 @@ func main() {
 		ProjectFile: utils.FindProjectFile(),
 	}
 	flags.ParseArgs(&opts, os.Args)
+	opts.Plan = opts.Plan || opts.PlanFile != ""
 
 	// Prepare logger
-	l := log.StandardLogger()
+	var l log.Logger = log.StandardLogger()
+
+	// Masker is prepared after loading blueprint
+	var masker *secrets.Masker
 
 	// Handle results
 	result := &Result{}
-	defer utils.SaveResult(opts.ResultFile, result)
+	if !opts.Plan {
+		defer func() {
+			reason := recover()
+			result.Finish(reason)
+			utils.SaveResult(opts.ResultFile, result, masker)
+			if reason != nil {
+				panic(reason)
+			}
+		}()
+	}
 
 	// Check if project file exists
 	if !utils.FileExists(opts.ProjectFile) {
 		panic("cannot find project.yaml")
 	}
 
+	// Read results of build, they have to be loaded before blueprint, because
+	// they are used as placeholders
+	if opts.BuildResult != "" {
+		opts.buildResult, err = results.LoadBuildResult(opts.BuildResult)
+		assert(err == nil, err)
+	}
+
 	// Load blueprint
 	blueprint := Blueprint{
 		Mode:        DeployMode,
@@ -49,7 +74,9 @@ func main() {
 		Services:    opts.Services,
 		Preprocessors: []Preprocessor{
 			schema.Validate,
-			schema.Migrate,
+		},
+		DocumentPreprocessors: []DocumentPreprocessor{
+			schema.MigrateDocument,
 		},
 	}
 	err = blueprint.Load(filepath.Join(utils.FindCommandDirectory(), "assets", "executors", "*", "*.yaml"))
@@ -61,49 +88,153 @@ func main() {
 	err = blueprint.Validate()
 	assert(err == nil, err)
 
+	// Ensure releases reference artifacts of the same build
+	if opts.buildResult != nil {
+		err = opts.buildResult.Validate(blueprint.ListServices())
+		assert(err == nil, err)
+	}
+
+	// Mask values of secrets in logs and results
+	masker = secrets.NewMasker(blueprint.ListSecretValues()...)
+	l = masker.Logger(l)
+
+	// Restrict commands which can be run by executors
+	policy := blueprint.GetProject().ExecutorPolicy()
+
+	// Make libraries importable by executors
+	libraries := blueprint.ListLibraries()
+
 	// Change working directory
 	err = os.Chdir(blueprint.GetProject().Directory())
 	assert(err == nil, err)
 
+	// Print plan
+	if opts.Plan {
+		p := &plan.Plan{}
+		for _, service := range blueprint.ListServices() {
+			for _, entry := range service.Entries(object.DeployEntryType) {
+				p.Add(&blueprint, service, object.DeployEntryType, entry)
+			}
+		}
+		err = p.Print(l)
+		assert(err == nil, err)
+		if opts.PlanFile != "" {
+			utils.SaveResult(opts.PlanFile, p, masker)
+		}
+		return
+	}
+
+	// Handle interruptions and time limits
+	timeout := blueprint.GetProject().Timeout()
+	if opts.Timeout > 0 {
+		timeout = opts.Timeout
+	}
+	ctx, cancel := utils.InterruptContext(l, timeout)
+	defer cancel()
+	result.EntryTimeout = blueprint.GetProject().EntryTimeout()
+	if opts.EntryTimeout > 0 {
+		result.EntryTimeout = opts.EntryTimeout
+	}
+
 	// Deploy
 	l.Printf(`Deploying to environment %q...`, opts.Environment)
 
 	environment, _ := blueprint.GetEnvironment(opts.Environment)
 
-	for _, service := range blueprint.ListServices() {
-		l := l.WithTags(service.Name())
+	dirs := func(service object.Object) Dirs {
+		return Dirs{
+			Project:     blueprint.GetProject().Directory(),
+			Environment: environment.Directory(),
+			Service:     service.Directory(),
+		}
+	}
+
+	// Releases deployed so far, used for rollback
+	deployed := []deployedEntry{}
+
+	scheduler := &Scheduler{
+		Logger:    l,
+		KeepGoing: opts.KeepGoing,
+		Context:   ctx,
+		OnSkip: func(service object.Object) {
+			l.WithTags(service.Name()).WithLevel(log.WarnLevel).Print("Skipping, because services it depends on failed")
+			result.SkipEntries(service, object.DeployEntryType)
+		},
+	}
+
+	err = scheduler.Run(blueprint.ListServices(), func(service object.Object, l log.Logger) error {
+		l = l.WithTags(service.Name())
 
 		if len(service.Entries(object.DeployEntryType)) == 0 {
 			l.WithLevel(log.VerboseLevel).Print("No releases to deploy")
-			continue
+			return nil
 		}
 
 		l.Printf(`Deploying service %q...`, service.Name())
 
-		for _, entry := range service.Entries(object.DeployEntryType) {
+		return result.RunEntries(ctx, service, object.DeployEntryType, func(ctx context.Context, entry object.Entry) error {
 			e, ok := blueprint.GetExecutor(entry.ExecutorKind(), entry.ExecutorName())
 			assert(ok, fmt.Errorf("%s %q does not exist", strings.ToLower(string(entry.ExecutorKind())), entry.ExecutorName()))
 
 			s := script.New(e)
 			s.Logger = l
+			s.Masker = masker
+			s.Policy = policy
+			s.Libraries = libraries
 
-			res, err := s.Run(DeployerInput{
+			artifacts := opts.buildResult.Artifacts(service)
+			res, err := s.RunContext(ctx, DeployerInput{
 				Spec:   entry.Spec(&blueprint),
 				Force:  opts.Force,
 				DryRun: opts.DryRun,
 				Wait:   opts.Wait,
-				Dirs: Dirs{
-					Project:     blueprint.GetProject().Directory(),
-					Environment: environment.Directory(),
-					Service:     service.Directory(),
-				},
-			})
-			assert(err == nil, err)
+				Dirs:   dirs(service),
 
-			result.addReleases(service, entry, res)
+				Artifacts:       script.IDs(artifacts),
+				ArtifactDetails: script.Maps(artifacts),
+			})
+			releases := script.IDs(res)
+			if len(releases) > 0 {
+				deployed = append(deployed, deployedEntry{service, entry, e, releases})
+			}
+			if err != nil {
+				return err
+			}
+
+			result.addReleases(service, entry, releases)
+			return nil
+		})
+	})
+
+	// Rollback (it's not bound to the context, so it's done even if deployment
+	// was interrupted or timed out)
+	if err != nil && opts.Rollback {
+		r := &rollback{
+			Logger:    l,
+			Masker:    masker,
+			Policy:    policy,
+			Libraries: libraries,
+			KeepGoing: opts.KeepGoing,
+			Input: func(d deployedEntry) RollbackInput {
+				artifacts := opts.buildResult.Artifacts(d.service)
+				return RollbackInput{
+					Spec:     d.entry.Spec(&blueprint),
+					Force:    opts.Force,
+					DryRun:   opts.DryRun,
+					Wait:     opts.Wait,
+					Dirs:     dirs(d.service),
+					Releases: d.releases,
+
+					Artifacts:       script.IDs(artifacts),
+					ArtifactDetails: script.Maps(artifacts),
+				}
+			},
 		}
+		r.run(deployed, result)
 	}
 
+	assert(err == nil, err)
+
 	// Print success message
 	switch count := len(blueprint.ListServices()); count {
 	case 0:
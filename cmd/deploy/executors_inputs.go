@@ -6,6 +6,23 @@ type DeployerInput struct {
 	DryRun bool        `tengo:"dryRun"`
 	Wait   int         `tengo:"wait"`
 	Dirs   Dirs        `tengo:"dirs"`
+	// Artifacts pushed for the service, read from the build result
+	Artifacts []string `tengo:"artifacts"`
+	// Artifacts as maps with the "id" field and metadata
+	ArtifactDetails []map[string]interface{} `tengo:"artifactDetails"`
+}
+
+type RollbackInput struct {
+	Spec     interface{} `tengo:"spec"`
+	Force    bool        `tengo:"force"`
+	DryRun   bool        `tengo:"dryRun"`
+	Wait     int         `tengo:"wait"`
+	Dirs     Dirs        `tengo:"dirs"`
+	Releases []string    `tengo:"releases"`
+	// Artifacts pushed for the service, read from the build result
+	Artifacts []string `tengo:"artifacts"`
+	// Artifacts as maps with the "id" field and metadata
+	ArtifactDetails []map[string]interface{} `tengo:"artifactDetails"`
 }
 
 type Dirs struct {
@@ -1,19 +1,33 @@
 package main
 
-import "github.com/g2a-com/cicd/internal/object"
+import (
+	"time"
+
+	"github.com/g2a-com/cicd/internal/object"
+	"github.com/g2a-com/cicd/internal/results"
+)
 
 type options struct {
 	object.GenericObject
 
-	Environment string            `flag:"environment" alias:"e" help:"Name of an environment to deploy to" required:"true"`
-	Tag         string            `flag:"tag" alias:"t" help:"Tag (version) of service to deploy"`
-	Force       bool              `flag:"force" help:"Force release update"`
-	DryRun      bool              `flag:"dry-run" help:"Simulate a deploy"`
-	Wait        int               `flag:"wait" default:"0" help:"Maximum time in seconds to wait for deploy to complete, 0 - don't wait"`
-	Services    []string          `flag:"services" alias:"s" help:"List of services to deploy (overrides environment configuration)"`
-	Params      map[string]string `flag:"param" help:"Parameters to use in configuration files (key=value pairs)"`
-	ProjectFile string            `flag:"project-file" alias:"f" help:"Path to project file"`
-	ResultFile  string            `flag:"result-file" help:"Where to write result file"`
+	Environment  string            `flag:"environment" alias:"e" help:"Name of an environment to deploy to" required:"true"`
+	Tag          string            `flag:"tag" alias:"t" help:"Tag (version) of service to deploy"`
+	BuildResult  string            `flag:"build-result" help:"Path to result file of build command, its tags and artifacts are passed to deployers"`
+	Force        bool              `flag:"force" help:"Force release update"`
+	DryRun       bool              `flag:"dry-run" help:"Simulate a deploy"`
+	Rollback     bool              `flag:"rollback" help:"Roll back already deployed releases when deployment fails (with --keep-going only releases of failed services)"`
+	KeepGoing    bool              `flag:"keep-going" help:"Continue deploying other services when one of them fails"`
+	Wait         int               `flag:"wait" default:"0" help:"Maximum time in seconds to wait for deploy to complete, 0 - don't wait"`
+	Services     []string          `flag:"services" alias:"s" help:"List of services to deploy (overrides environment configuration)"`
+	Params       map[string]string `flag:"param" help:"Parameters to use in configuration files (key=value pairs)"`
+	Plan         bool              `flag:"plan" help:"Print resolved entries without running executors"`
+	PlanFile     string            `flag:"plan-file" help:"Where to write plan as JSON (implies --plan)"`
+	Timeout      time.Duration     `flag:"timeout" help:"Time limit for the whole command, e.g. 1h (overrides project configuration)"`
+	EntryTimeout time.Duration     `flag:"entry-timeout" help:"Time limit for entries which don't define their own one, e.g. 10m (overrides project configuration)"`
+	ProjectFile  string            `flag:"project-file" alias:"f" help:"Path to project file"`
+	ResultFile   string            `flag:"result-file" help:"Where to write result file"`
+
+	buildResult *results.BuildResult
 }
 
 func (o options) Kind() object.Kind {
@@ -21,8 +35,8 @@ func (o options) Kind() object.Kind {
 }
 
 func (o options) PlaceholderValues() map[string]interface{} {
-	return map[string]interface{}{
-		"Params": o.Params,
-		"Tag":    o.Tag,
-	}
+	values := o.buildResult.PlaceholderValues()
+	values["Params"] = o.Params
+	values["Tag"] = o.Tag
+	return values
 }
@@ -2,6 +2,7 @@ package main
 
 import (
 	"github.com/g2a-com/cicd/internal/object"
+	"github.com/g2a-com/cicd/internal/results"
 )
 
 type ResultEntry struct {
@@ -10,8 +11,19 @@ type ResultEntry struct {
 	Result  string `json:"result"`
 }
 
+type RollbackEntry struct {
+	Service  string         `json:"service"`
+	Entry    int            `json:"entry"`
+	Releases []string       `json:"releases"`
+	Status   results.Status `json:"status"`
+	Error    string         `json:"error,omitempty"`
+}
+
 type Result struct {
-	Releases []ResultEntry `json:"releases"`
+	results.Summary
+
+	Releases  []ResultEntry   `json:"releases"`
+	Rollbacks []RollbackEntry `json:"rollbacks,omitempty"`
 }
 
 func (r *Result) addReleases(service object.Object, entry object.Entry, releases []string) {
@@ -19,3 +31,23 @@ func (r *Result) addReleases(service object.Object, entry object.Entry, releases
 		r.Releases = append(r.Releases, ResultEntry{service.Name(), entry.Index(), release})
 	}
 }
+
+// failedServices returns names of services with releases which failed or
+// timed out.
+func (r *Result) failedServices() map[string]bool {
+	failed := map[string]bool{}
+	for _, e := range r.Entries {
+		if e.Status == results.Failed || e.Status == results.TimedOut {
+			failed[e.Service] = true
+		}
+	}
+	return failed
+}
+
+func (r *Result) addRollback(service object.Object, entry object.Entry, releases []string, status results.Status, err error) {
+	msg := ""
+	if err != nil {
+		msg = err.Error()
+	}
+	r.Rollbacks = append(r.Rollbacks, RollbackEntry{service.Name(), entry.Index(), releases, status, msg})
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"github.com/g2a-com/cicd/internal/object"
+	"github.com/g2a-com/cicd/internal/results"
+	"github.com/g2a-com/cicd/internal/script"
+	"github.com/g2a-com/cicd/internal/secrets"
+	log "github.com/g2a-com/klio-logger-go/v2"
+)
+
+// deployedEntry is a release entry which returned releases, so it can be
+// rolled back.
+type deployedEntry struct {
+	service  object.Object
+	entry    object.Entry
+	executor object.Executor
+	releases []string
+}
+
+// rollback rolls back releases when deployment fails.
+//
+// Rollback is implemented by the "rollback" script of a deployer, defined next
+// to its deploy script, rather than by an entry point within the deploy script
+// itself. Tengo cannot call functions defined by a script from Go, and running
+// the deploy script in a special "rollback mode" would deploy releases again
+// when a deployer doesn't handle that mode.
+type rollback struct {
+	Logger    log.Logger
+	Masker    *secrets.Masker
+	Policy    *object.ExecutorPolicy
+	Libraries []object.Library
+	// KeepGoing keeps services which were deployed successfully, only
+	// releases of services which failed are rolled back.
+	KeepGoing bool
+	// Input returns input of the rollback script for the entry.
+	Input func(d deployedEntry) RollbackInput
+}
+
+// run rolls back deployed releases in reverse order and records the outcome
+// of every rollback in the result. Failures of rollback scripts are logged,
+// and don't stop rolling back other releases.
+func (r *rollback) run(deployed []deployedEntry, result *Result) {
+	if r.KeepGoing {
+		failed := result.failedServices()
+		kept := []deployedEntry{}
+		for _, d := range deployed {
+			if failed[d.service.Name()] {
+				kept = append(kept, d)
+			}
+		}
+		deployed = kept
+	}
+
+	r.Logger.WithLevel(log.WarnLevel).Printf("Deployment failed, rolling back %d release(s)...", len(deployed))
+
+	for i := len(deployed) - 1; i >= 0; i-- {
+		d := deployed[i]
+		l := r.Logger.WithTags("rollback", d.service.Name())
+
+		if d.executor.RollbackScript() == "" {
+			l.WithLevel(log.WarnLevel).Printf("Rollback is not supported by %s", d.executor.DisplayName())
+			result.addRollback(d.service, d.entry, d.releases, results.Skipped, nil)
+			continue
+		}
+
+		s := script.NewRollback(d.executor)
+		s.Logger = l
+		s.Masker = r.Masker
+		s.Policy = r.Policy
+		s.Libraries = r.Libraries
+
+		_, err := s.Run(r.Input(d))
+		if err != nil {
+			l.WithLevel(log.ErrorLevel).Print(err)
+			result.addRollback(d.service, d.entry, d.releases, results.Failed, err)
+			continue
+		}
+
+		result.addRollback(d.service, d.entry, d.releases, results.Succeeded, nil)
+	}
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/g2a-com/cicd/internal/object"
+	"github.com/g2a-com/cicd/internal/results"
+	fakelogger "github.com/g2a-com/cicd/internal/utils/fake_logger"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func Test_failed_deployment_is_rolled_back_in_reverse_order(t *testing.T) {
+	deployer := newDeployer(t, `{
+		apiVersion: g2a-cli/v2.0, kind: Deployer, name: deployer,
+		script: "",
+		rollback: 'if input.releases[0] == "broken" { abort(error("cannot roll back")) }',
+	}`)
+	withoutRollback := newDeployer(t, `{ apiVersion: g2a-cli/v2.0, kind: Deployer, name: other, script: "" }`)
+	api := newService(t, `{ apiVersion: g2a-cli/v2.0, kind: Service, name: api, releases: [ { deployer: {} }, { other: {} } ] }`)
+	web := newService(t, `{ apiVersion: g2a-cli/v2.0, kind: Service, name: web, releases: [ { deployer: {} } ] }`)
+	deployed := []deployedEntry{
+		{api, api.Entries(object.DeployEntryType)[0], deployer, []string{"api-1"}},
+		{api, api.Entries(object.DeployEntryType)[1], withoutRollback, []string{"api-2"}},
+		{web, web.Entries(object.DeployEntryType)[0], deployer, []string{"broken"}},
+	}
+	result := &Result{}
+
+	newRollback(false).run(deployed, result)
+
+	require.Equal(t, []RollbackEntry{
+		{Service: "web", Entry: 0, Releases: []string{"broken"}, Status: results.Failed, Error: result.Rollbacks[0].Error},
+		{Service: "api", Entry: 1, Releases: []string{"api-2"}, Status: results.Skipped},
+		{Service: "api", Entry: 0, Releases: []string{"api-1"}, Status: results.Succeeded},
+	}, result.Rollbacks)
+	require.Contains(t, result.Rollbacks[0].Error, "cannot roll back")
+}
+
+func Test_rollback_receives_releases_returned_by_deployer(t *testing.T) {
+	deployer := newDeployer(t, `{
+		apiVersion: g2a-cli/v2.0, kind: Deployer, name: deployer,
+		script: "",
+		rollback: 'if input.releases[0] != "api-1" || input.spec.name != "api" { abort(error("unexpected input")) }',
+	}`)
+	api := newService(t, `{ apiVersion: g2a-cli/v2.0, kind: Service, name: api, releases: [ { deployer: {} } ] }`)
+	result := &Result{}
+
+	newRollback(false).run([]deployedEntry{{api, api.Entries(object.DeployEntryType)[0], deployer, []string{"api-1"}}}, result)
+
+	require.Equal(t, []RollbackEntry{
+		{Service: "api", Entry: 0, Releases: []string{"api-1"}, Status: results.Succeeded},
+	}, result.Rollbacks)
+}
+
+func Test_rollback_with_keep_going_keeps_services_deployed_successfully(t *testing.T) {
+	deployer := newDeployer(t, `{ apiVersion: g2a-cli/v2.0, kind: Deployer, name: deployer, script: "", rollback: "x := 1" }`)
+	api := newService(t, `{ apiVersion: g2a-cli/v2.0, kind: Service, name: api, releases: [ { deployer: {} }, { deployer: {} } ] }`)
+	web := newService(t, `{ apiVersion: g2a-cli/v2.0, kind: Service, name: web, releases: [ { deployer: {} } ] }`)
+	deployed := []deployedEntry{
+		{web, web.Entries(object.DeployEntryType)[0], deployer, []string{"web-1"}},
+		{api, api.Entries(object.DeployEntryType)[0], deployer, []string{"api-1"}},
+	}
+	result := &Result{}
+	result.Entries = []results.Entry{
+		{Service: "web", Type: object.DeployEntryType, Entry: 0, Status: results.Succeeded},
+		{Service: "api", Type: object.DeployEntryType, Entry: 0, Status: results.Succeeded},
+		{Service: "api", Type: object.DeployEntryType, Entry: 1, Status: results.Failed, Error: "failure"},
+	}
+
+	newRollback(true).run(deployed, result)
+
+	require.Equal(t, []RollbackEntry{
+		{Service: "api", Entry: 0, Releases: []string{"api-1"}, Status: results.Succeeded},
+	}, result.Rollbacks)
+}
+
+func Test_failed_services_include_timed_out_releases(t *testing.T) {
+	result := &Result{}
+	result.Entries = []results.Entry{
+		{Service: "a", Status: results.Succeeded},
+		{Service: "b", Status: results.Failed, Error: "failure"},
+		{Service: "c", Status: results.TimedOut},
+		{Service: "d", Status: results.Skipped},
+	}
+
+	require.Equal(t, map[string]bool{"b": true, "c": true}, result.failedServices())
+}
+
+func newRollback(keepGoing bool) *rollback {
+	return &rollback{
+		Logger:    fakelogger.New(),
+		KeepGoing: keepGoing,
+		Input: func(d deployedEntry) RollbackInput {
+			return RollbackInput{
+				Spec:     map[string]interface{}{"name": d.service.Name()},
+				Releases: d.releases,
+			}
+		},
+	}
+}
+
+func newDeployer(t *testing.T, content string) object.Executor {
+	node := &yaml.Node{}
+	require.NoError(t, yaml.Unmarshal([]byte(content), node))
+	executor, err := object.NewExecutor("deployer.yaml", node)
+	require.NoError(t, err)
+	return executor
+}
+
+func newService(t *testing.T, content string) object.Object {
+	node := &yaml.Node{}
+	require.NoError(t, yaml.Unmarshal([]byte(content), node))
+	service, err := object.NewDeployService("service.yaml", node)
+	require.NoError(t, err)
+	return service
+}
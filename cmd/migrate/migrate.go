@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/g2a-com/cicd/internal/flags"
+	"github.com/g2a-com/cicd/internal/migration"
+	"github.com/g2a-com/cicd/internal/utils"
+	log "github.com/g2a-com/klio-logger-go/v2"
+)
+
+func main() {
+	// Exit nicely on panics
+	defer utils.HandlePanics()
+
+	// Parse options
+	opts := options{
+		ProjectFile: utils.FindProjectFile(),
+	}
+	flags.ParseArgs(&opts, os.Args)
+
+	// Prepare logger
+	l := log.StandardLogger()
+
+	// Check if project file exists
+	if !utils.FileExists(opts.ProjectFile) {
+		panic("cannot find project.yaml")
+	}
+
+	changes, err := migration.Plan(opts.ProjectFile)
+	assert(err == nil, err)
+
+	if len(changes) == 0 {
+		l.Print("All files are up to date")
+		return
+	}
+
+	if opts.Check {
+		for _, change := range changes {
+			diff, err := change.Diff()
+			assert(err == nil, err)
+			l.Print(strings.TrimSuffix(diff, "\n"))
+		}
+		panic(fmt.Sprintf("%d file(s) need to be migrated", len(changes)))
+	}
+
+	err = migration.Apply(changes)
+	assert(err == nil, err)
+
+	for _, change := range changes {
+		switch {
+		case change.OldPath == "":
+			l.Printf("Created %s", change.NewPath)
+		case change.OldPath != change.NewPath:
+			l.Printf("Migrated %s and renamed it to %s", change.OldPath, change.NewPath)
+		default:
+			l.Printf("Migrated %s", change.NewPath)
+		}
+	}
+}
+
+func assert(condition bool, err interface{}) {
+	if !condition {
+		panic(err)
+	}
+}
@@ -0,0 +1,6 @@
+package main
+
+type options struct {
+	Check       bool   `flag:"check" help:"Print diff of required changes instead of writing files, fails if any file needs to be migrated"`
+	ProjectFile string `flag:"project-file" alias:"f" help:"Path to project file"`
+}
@@ -0,0 +1,11 @@
+package main
+
+type RunnerInput struct {
+	Spec interface{} `tengo:"spec"`
+	Dirs Dirs        `tengo:"dirs"`
+}
+
+type Dirs struct {
+	Project string `tengo:"project"`
+	Service string `tengo:"service"`
+}
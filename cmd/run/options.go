@@ -0,0 +1,29 @@
+package main
+
+import (
+	"time"
+
+	"github.com/g2a-com/cicd/internal/object"
+)
+
+type options struct {
+	object.GenericObject
+
+	Task         string            `arg:"0" required:"true"`
+	Services     []string          `flag:"services" alias:"s" help:"List of services to run task for (skip to run for all services)"`
+	Params       map[string]string `flag:"param" help:"Parameters to use in configuration files (key=value pairs)"`
+	Timeout      time.Duration     `flag:"timeout" help:"Time limit for the whole command, e.g. 1h (overrides project configuration)"`
+	EntryTimeout time.Duration     `flag:"entry-timeout" help:"Time limit for entries which don't define their own one, e.g. 10m (overrides project configuration)"`
+	ProjectFile  string            `flag:"project-file" alias:"f" help:"Path to project file"`
+	ResultFile   string            `flag:"result-file" help:"Where to write result file"`
+}
+
+func (o options) Kind() object.Kind {
+	return object.OptionsKind
+}
+
+func (o options) PlaceholderValues() map[string]interface{} {
+	return map[string]interface{}{
+		"Params": o.Params,
+	}
+}
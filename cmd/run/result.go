@@ -0,0 +1,29 @@
+package main
+
+import (
+	"github.com/g2a-com/cicd/internal/object"
+	"github.com/g2a-com/cicd/internal/results"
+)
+
+type ResultEntry struct {
+	Service string `json:"service,omitempty"`
+	Entry   int    `json:"entry"`
+	Result  string `json:"result"`
+}
+
+type Result struct {
+	results.Summary
+
+	Task    string        `json:"task"`
+	Results []ResultEntry `json:"results"`
+}
+
+func (r *Result) addResults(service object.Object, entry object.Entry, results []string) {
+	name := ""
+	if service != nil {
+		name = service.Name()
+	}
+	for _, result := range results {
+		r.Results = append(r.Results, ResultEntry{name, entry.Index(), result})
+	}
+}
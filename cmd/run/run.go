@@ -0,0 +1,207 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	. "github.com/g2a-com/cicd/internal/blueprint"
+	"github.com/g2a-com/cicd/internal/flags"
+	"github.com/g2a-com/cicd/internal/object"
+	"github.com/g2a-com/cicd/internal/schema"
+	"github.com/g2a-com/cicd/internal/script"
+	"github.com/g2a-com/cicd/internal/secrets"
+	"github.com/g2a-com/cicd/internal/utils"
+	log "github.com/g2a-com/klio-logger-go/v2"
+)
+
+func main() {
+	var err error
+
+	// Exit nicely on panics
+	defer utils.HandlePanics()
+
+	// Parse options
+	opts := options{
+		ResultFile:  "run-result.json",
+		ProjectFile: utils.FindProjectFile(),
+	}
+	flags.ParseArgs(&opts, os.Args)
+
+	// Prepare logger
+	var l log.Logger = log.StandardLogger()
+
+	// Masker is prepared after loading blueprint
+	var masker *secrets.Masker
+
+	// Handle results
+	result := &Result{Task: opts.Task, Results: []ResultEntry{}}
+	defer func() {
+		reason := recover()
+		result.Finish(reason)
+		utils.SaveResult(opts.ResultFile, result, masker)
+		if reason != nil {
+			panic(reason)
+		}
+	}()
+
+	// Check if project file exists
+	if !utils.FileExists(opts.ProjectFile) {
+		panic("cannot find project.yaml")
+	}
+
+	// Load blueprint
+	blueprint := Blueprint{
+		Mode:     RunMode,
+		Params:   opts.Params,
+		Services: opts.Services,
+		Preprocessors: []Preprocessor{
+			schema.Validate,
+		},
+		DocumentPreprocessors: []DocumentPreprocessor{
+			schema.MigrateDocument,
+		},
+	}
+	err = blueprint.Load(filepath.Join(utils.FindCommandDirectory(), "assets", "executors", "*", "*.yaml"))
+	assert(err == nil, err)
+	err = blueprint.Load(opts.ProjectFile)
+	assert(err == nil, err)
+	err = blueprint.AddDocuments(opts)
+	assert(err == nil, err)
+	err = blueprint.Validate()
+	assert(err == nil, err)
+
+	// Mask values of secrets in logs and results
+	masker = secrets.NewMasker(blueprint.ListSecretValues()...)
+	l = masker.Logger(l)
+
+	// Restrict commands which can be run by executors
+	policy := blueprint.GetProject().ExecutorPolicy()
+
+	// Make libraries importable by executors
+	libraries := blueprint.ListLibraries()
+
+	// Change working directory
+	err = os.Chdir(blueprint.GetProject().Directory())
+	assert(err == nil, err)
+
+	// Handle interruptions and time limits
+	timeout := blueprint.GetProject().Timeout()
+	if opts.Timeout > 0 {
+		timeout = opts.Timeout
+	}
+	ctx, cancel := utils.InterruptContext(l, timeout)
+	defer cancel()
+	result.EntryTimeout = blueprint.GetProject().EntryTimeout()
+	if opts.EntryTimeout > 0 {
+		result.EntryTimeout = opts.EntryTimeout
+	}
+
+	// Helper for getting executors
+	getExecutor := func(kind object.Kind, name string) object.Executor {
+		e, ok := blueprint.GetExecutor(kind, name)
+		assert(ok, fmt.Errorf("%s %q does not exist", kind, name))
+		return e
+	}
+
+	// Helper recording that tasks of services weren't run
+	services := blueprint.ListServices()
+	skipServices := func(services []object.Object) {
+		for _, service := range services {
+			result.SkipEntries(service, opts.Task)
+		}
+	}
+
+	count := 0
+
+	// Run project task
+	if project := blueprint.GetProject(); len(project.Entries(opts.Task)) > 0 {
+		l := l.WithTags(project.Name())
+
+		l.Printf(`Running task %q for project %q...`, opts.Task, project.Name())
+
+		err = result.RunEntries(ctx, project, opts.Task, func(ctx context.Context, entry object.Entry) error {
+			s := script.New(getExecutor(entry.ExecutorKind(), entry.ExecutorName()))
+			s.Logger = l
+			s.Masker = masker
+			s.Policy = policy
+			s.Libraries = libraries
+
+			res, err := s.RunContext(ctx, RunnerInput{
+				Spec: entry.Spec(&blueprint),
+				Dirs: Dirs{
+					Project: project.Directory(),
+				},
+			})
+			if err != nil {
+				return err
+			}
+
+			result.addResults(nil, entry, script.IDs(res))
+			return nil
+		})
+		if err != nil {
+			skipServices(services)
+		}
+		assert(err == nil, err)
+
+		count++
+	}
+
+	// Run services tasks
+	for i, service := range services {
+		l := l.WithTags(service.Name())
+
+		if len(service.Entries(opts.Task)) == 0 {
+			l.WithLevel(log.VerboseLevel).Printf("No %q task to run", opts.Task)
+			continue
+		}
+
+		l.Printf(`Running task %q for service %q...`, opts.Task, service.Name())
+
+		err = result.RunEntries(ctx, service, opts.Task, func(ctx context.Context, entry object.Entry) error {
+			s := script.New(getExecutor(entry.ExecutorKind(), entry.ExecutorName()))
+			s.Logger = l
+			s.Masker = masker
+			s.Policy = policy
+			s.Libraries = libraries
+
+			res, err := s.RunContext(ctx, RunnerInput{
+				Spec: entry.Spec(&blueprint),
+				Dirs: Dirs{
+					Project: blueprint.GetProject().Directory(),
+					Service: service.Directory(),
+				},
+			})
+			if err != nil {
+				return err
+			}
+
+			result.addResults(service, entry, script.IDs(res))
+			return nil
+		})
+		if err != nil {
+			skipServices(services[i+1:])
+		}
+		assert(err == nil, err)
+
+		count++
+	}
+
+	// Print success message
+	switch count {
+	case 0:
+		l.Printf("There was nothing to run for task %q", opts.Task)
+	case 1:
+		l.Printf("Successfully ran 1 definition of task %q", opts.Task)
+	default:
+		l.Printf("Successfully ran %v definitions of task %q", count, opts.Task)
+	}
+}
+
+func assert(condition bool, err interface{}) {
+	if !condition {
+		panic(err)
+	}
+}
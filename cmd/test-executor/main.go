@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/g2a-com/cicd/internal/executortest"
+	"github.com/g2a-com/cicd/internal/flags"
+	"github.com/g2a-com/cicd/internal/utils"
+	log "github.com/g2a-com/klio-logger-go/v2"
+)
+
+func main() {
+	// Exit nicely on panics
+	defer utils.HandlePanics()
+
+	// Parse options
+	opts := options{
+		Files: "*.test.yaml",
+	}
+	flags.ParseArgs(&opts, os.Args)
+
+	// Prepare logger
+	l := log.StandardLogger()
+	el := l.WithLevel(log.ErrorLevel)
+
+	files, err := filepath.Glob(opts.Files)
+	assert(err == nil, err)
+	if len(files) == 0 {
+		panic(fmt.Sprintf("no files match %q", opts.Files))
+	}
+
+	total, failed := 0, 0
+	for _, file := range files {
+		suite, err := executortest.Load(file)
+		if err == nil {
+			var results []executortest.Result
+			results, err = suite.Run()
+			for _, result := range results {
+				total++
+				if result.Passed() {
+					l.Printf("PASS %s: %s", file, result.Name)
+					continue
+				}
+				failed++
+				el.Printf("FAIL %s: %s", file, result.Name)
+				for _, failure := range result.Failures {
+					el.Printf("    %s", failure)
+				}
+				l.Print("    Logged lines:")
+				for _, line := range result.Logs {
+					l.Printf("      %s", line)
+				}
+			}
+		}
+		if err != nil {
+			total++
+			failed++
+			el.Printf("FAIL %s:\n\t%s", file, err)
+		}
+	}
+
+	if failed > 0 {
+		panic(fmt.Sprintf("%d of %d test(s) failed", failed, total))
+	}
+	l.Printf("All %d test(s) passed", total)
+}
+
+func assert(condition bool, err interface{}) {
+	if !condition {
+		panic(err)
+	}
+}
@@ -0,0 +1,5 @@
+package main
+
+type options struct {
+	Files string `arg:"0" help:"Glob matching files with tests"`
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"github.com/g2a-com/cicd/internal/object"
+	"github.com/g2a-com/cicd/internal/results"
+)
+
+type options struct {
+	object.GenericObject
+
+	Environments []string          `flag:"environments" alias:"e" help:"List of environments to validate (skip to validate all environments)"`
+	Tag          string            `flag:"tag" alias:"t" help:"Tag (version) used while resolving placeholders"`
+	BuildResult  string            `flag:"build-result" help:"Path to result file of build command, its tags and artifacts are used while resolving placeholders"`
+	Params       map[string]string `flag:"param" help:"Parameters to use in configuration files (key=value pairs)"`
+	ProjectFile  string            `flag:"project-file" alias:"f" help:"Path to project file"`
+	ResultFile   string            `flag:"result-file" help:"Where to write result file"`
+
+	buildResult *results.BuildResult
+}
+
+func (o options) Kind() object.Kind {
+	return object.OptionsKind
+}
+
+func (o options) PlaceholderValues() map[string]interface{} {
+	values := o.buildResult.PlaceholderValues()
+	values["Params"] = o.Params
+	values["Tag"] = o.Tag
+	return values
+}
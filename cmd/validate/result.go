@@ -0,0 +1,61 @@
+package main
+
+import (
+	"sort"
+
+	"github.com/g2a-com/cicd/internal/schema"
+	"github.com/hashicorp/go-multierror"
+)
+
+type Problem struct {
+	File    string `json:"file,omitempty"`
+	Line    int    `json:"line,omitempty"`
+	Pointer string `json:"pointer,omitempty"`
+	Message string `json:"message"`
+}
+
+type Result struct {
+	Problems []Problem `json:"problems"`
+}
+
+// addErrors adds problems described by the errors, problems which were
+// already reported are skipped.
+func (r *Result) addErrors(err error) {
+	if err == nil {
+		return
+	}
+
+	if merr, ok := err.(*multierror.Error); ok {
+		for _, e := range merr.Errors {
+			r.addErrors(e)
+		}
+		return
+	}
+
+	var problem Problem
+	if verr, ok := err.(*schema.ValidationError); ok {
+		problem = Problem{verr.Filename, verr.Line, verr.Pointer, verr.Message}
+	} else {
+		problem = Problem{Message: err.Error()}
+	}
+
+	for _, p := range r.Problems {
+		if p == problem {
+			return
+		}
+	}
+	r.Problems = append(r.Problems, problem)
+}
+
+func (r *Result) sortProblems() {
+	sort.SliceStable(r.Problems, func(i, j int) bool {
+		a, b := r.Problems[i], r.Problems[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Pointer < b.Pointer
+	})
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	. "github.com/g2a-com/cicd/internal/blueprint"
+	"github.com/g2a-com/cicd/internal/flags"
+	"github.com/g2a-com/cicd/internal/results"
+	"github.com/g2a-com/cicd/internal/schema"
+	"github.com/g2a-com/cicd/internal/utils"
+	log "github.com/g2a-com/klio-logger-go/v2"
+)
+
+func main() {
+	var err error
+
+	// Exit nicely on panics
+	defer utils.HandlePanics()
+
+	// Parse options
+	opts := options{
+		ResultFile:  "validate-result.json",
+		ProjectFile: utils.FindProjectFile(),
+	}
+	flags.ParseArgs(&opts, os.Args)
+
+	// Prepare logger
+	l := log.StandardLogger()
+
+	// Check if project file exists
+	if !utils.FileExists(opts.ProjectFile) {
+		panic("cannot find project.yaml")
+	}
+	opts.ProjectFile, err = filepath.Abs(opts.ProjectFile)
+	assert(err == nil, err)
+
+	// Handle results
+	result := &Result{Problems: []Problem{}}
+	defer func() {
+		err := os.Chdir(filepath.Dir(opts.ProjectFile))
+		assert(err == nil, err)
+		utils.SaveResult(opts.ResultFile, result, nil)
+	}()
+
+	// Read results of build, they are used as placeholders in deploy mode
+	if opts.BuildResult != "" {
+		opts.buildResult, err = results.LoadBuildResult(opts.BuildResult)
+		result.addErrors(err)
+	}
+
+	// Helper loading project in the specified mode, all problems are added to
+	// the result
+	load := func(mode Mode, environment string) *Blueprint {
+		blueprint := &Blueprint{
+			Mode:        mode,
+			Params:      opts.Params,
+			Environment: environment,
+			Tag:         opts.Tag,
+			// Secrets are checked when they're used by build, deploy and run
+			IgnoreUnreadableSecrets: true,
+			Preprocessors: []Preprocessor{
+				schema.Validate,
+			},
+			DocumentPreprocessors: []DocumentPreprocessor{
+				schema.MigrateDocument,
+			},
+		}
+		result.addErrors(blueprint.Load(filepath.Join(utils.FindCommandDirectory(), "assets", "executors", "*", "*.yaml")))
+		result.addErrors(blueprint.Load(opts.ProjectFile))
+		result.addErrors(blueprint.AddDocuments(opts))
+		return blueprint
+	}
+
+	l.Print("Validating project in build mode...")
+	result.addErrors(load(BuildMode, "").Validate())
+
+	l.Print("Validating project in run mode...")
+	result.addErrors(load(RunMode, "").Validate())
+
+	// Environments are known only after loading files in deploy mode, so
+	// the first pass uses a name which doesn't match any environment.
+	environments := opts.Environments
+	if len(environments) == 0 {
+		environments = load(DeployMode, "*").ListEnvironmentNames()
+	}
+	for _, environment := range environments {
+		l.Printf("Validating project in deploy mode for environment %q...", environment)
+		blueprint := load(DeployMode, environment)
+		result.addErrors(blueprint.Validate())
+		if opts.buildResult != nil {
+			result.addErrors(opts.buildResult.Validate(blueprint.ListServices()))
+		}
+	}
+
+	result.sortProblems()
+
+	if len(result.Problems) == 0 {
+		l.Print("No problems found")
+		return
+	}
+
+	el := l.WithLevel(log.ErrorLevel)
+	for _, p := range result.Problems {
+		el.Print(formatProblem(p))
+	}
+	panic(fmt.Sprintf("found %d problem(s)", len(result.Problems)))
+}
+
+func formatProblem(p Problem) string {
+	msg := p.Message
+	if p.Pointer != "" && p.Pointer != "/" {
+		msg = fmt.Sprintf("%s: %s", p.Pointer, msg)
+	}
+	if p.File != "" && p.Line != 0 {
+		msg = fmt.Sprintf("%s:%d: %s", p.File, p.Line, msg)
+	} else if p.File != "" {
+		msg = fmt.Sprintf("%s: %s", p.File, msg)
+	}
+	return msg
+}
+
+func assert(condition bool, err interface{}) {
+	if !condition {
+		panic(err)
+	}
+}
@@ -6,10 +6,12 @@ require (
 	github.com/d5/tengo/v2 v2.10.1
 	github.com/g2a-com/klio-logger-go v0.0.0-20220223110843-c1e016520ec2
 	github.com/g2a-com/klio-logger-go/v2 v2.0.0
+	github.com/go-git/go-git/v5 v5.4.2
 	github.com/go-test/deep v1.0.8
 	github.com/hashicorp/go-multierror v1.1.1
 	github.com/icza/dyno v0.0.0-20210726202311-f1bafe5d9996
 	github.com/mitchellh/mapstructure v1.4.1
+	github.com/pmezard/go-difflib v1.0.0
 	github.com/qri-io/jsonschema v0.2.1
 	github.com/spf13/pflag v1.0.5
 	github.com/stretchr/testify v1.7.0
@@ -18,8 +20,23 @@ require (
 )
 
 require (
+	github.com/Microsoft/go-winio v0.4.16 // indirect
+	github.com/ProtonMail/go-crypto v0.0.0-20210428141323-04723f9f07d7 // indirect
+	github.com/acomagu/bufpipe v1.0.3 // indirect
 	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/emirpasic/gods v1.12.0 // indirect
+	github.com/go-git/gcfg v1.5.0 // indirect
+	github.com/go-git/go-billy/v5 v5.3.1 // indirect
 	github.com/hashicorp/errwrap v1.0.0 // indirect
-	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/imdario/mergo v0.3.12 // indirect
+	github.com/jbenet/go-context v0.0.0-20150711004518-d14ea06fba99 // indirect
+	github.com/kevinburke/ssh_config v0.0.0-20201106050909-4977a11b4351 // indirect
+	github.com/mitchellh/go-homedir v1.1.0 // indirect
 	github.com/qri-io/jsonpointer v0.1.1 // indirect
+	github.com/sergi/go-diff v1.1.0 // indirect
+	github.com/xanzy/ssh-agent v0.3.0 // indirect
+	golang.org/x/crypto v0.0.0-20210421170649-83a5a9bb288b // indirect
+	golang.org/x/net v0.0.0-20210326060303-6b1517762897 // indirect
+	golang.org/x/sys v0.0.0-20210502180810-71e4cd670f79 // indirect
+	gopkg.in/warnings.v0 v0.1.2 // indirect
 )
@@ -12,6 +12,7 @@ import (
 	"strings"
 
 	"github.com/g2a-com/cicd/internal/object"
+	"github.com/g2a-com/cicd/internal/schema"
 	log "github.com/g2a-com/klio-logger-go"
 	"github.com/hashicorp/go-multierror"
 	"gopkg.in/yaml.v3"
@@ -27,15 +28,26 @@ const (
 
 type Preprocessor func([]byte) ([]byte, error)
 
+// DocumentPreprocessor modifies decoded document in place, it's run after
+// preprocessors of the whole file.
+type DocumentPreprocessor func(*yaml.Node) error
+
 type Blueprint struct {
-	Mode           Mode
-	Services       []string
-	Params         map[string]string
-	Environment    string
-	Tag            string
-	Preprocessors  []Preprocessor
-	objects        map[string]object.Object
-	processedFiles map[string]bool
+	Mode          Mode
+	Services      []string
+	Params        map[string]string
+	Environment   string
+	Tag           string
+	Preprocessors []Preprocessor
+	// DocumentPreprocessors are run for every document of the file, nodes
+	// they move keep their lines, so errors point to the source file
+	DocumentPreprocessors []DocumentPreprocessor
+	// IgnoreUnreadableSecrets disables reporting secrets which values cannot
+	// be read, their declarations are still validated. Validate command uses
+	// it, since secrets are usually available only where commands are run.
+	IgnoreUnreadableSecrets bool
+	objects                 map[string]object.Object
+	processedFiles          map[string]bool
 }
 
 func (b *Blueprint) init() error {
@@ -75,10 +87,41 @@ func (b *Blueprint) Validate() (err error) {
 		}
 	}
 
+	for _, e := range validateDependencies(b.GetObjectsByKind(object.ServiceKind)) {
+		err = multierror.Append(err, e)
+	}
+
+	for _, e := range validateLibraries(b.GetObjectsByKind(object.LibraryKind), b.getExecutors()) {
+		err = multierror.Append(err, e)
+	}
+
+	if !b.IgnoreUnreadableSecrets {
+		for _, holder := range b.getSecretsHolders() {
+			if _, e := holder.Secrets(); e != nil {
+				err = multierror.Append(err, e)
+			}
+		}
+	}
+
 	return err
 }
 
+// ListSecretValues returns values of secrets defined by the project and the
+// environment in use
+func (b *Blueprint) ListSecretValues() (values []string) {
+	for _, holder := range b.getSecretsHolders() {
+		secrets, _ := holder.Secrets()
+		for _, value := range secrets {
+			values = append(values, value)
+		}
+	}
+	sort.Strings(values)
+	return values
+}
+
 func (b *Blueprint) Load(glob string) error {
+	var errs error
+
 	err := b.init()
 	if err != nil {
 		return err
@@ -108,7 +151,8 @@ func (b *Blueprint) Load(glob string) error {
 
 			docs, err := b.readFile(p, b.Mode)
 			if err != nil {
-				return fmt.Errorf(`file "%s" contains invalid document: %s`, p, err)
+				errs = multierror.Append(errs, err)
+				continue
 			}
 
 			for _, obj := range docs {
@@ -122,12 +166,12 @@ func (b *Blueprint) Load(glob string) error {
 
 			err = b.AddDocuments(docs...)
 			if err != nil {
-				return err
+				errs = multierror.Append(errs, err)
 			}
 		}
 	}
 
-	return nil
+	return errs
 }
 
 // GetProject gets Project object
@@ -148,12 +192,35 @@ func (b *Blueprint) GetEnvironment(name string) (object.Object, bool) {
 	return obj, obj != nil
 }
 
-// ListServices returns all service objects in the blueprint
+// ListEnvironmentNames returns sorted names of all environments in the
+// blueprint
+func (b *Blueprint) ListEnvironmentNames() []string {
+	return b.getEnvironmentNames()
+}
+
+// ListLibraries returns all library objects in the blueprint
+func (b *Blueprint) ListLibraries() []object.Library {
+	var libraries []object.Library
+	for _, obj := range b.GetObjectsByKind(object.LibraryKind) {
+		if library, ok := obj.(object.Library); ok {
+			libraries = append(libraries, library)
+		}
+	}
+	return libraries
+}
+
+// ListServices returns all service objects in the blueprint, each service is
+// preceded by services it depends on
 func (b *Blueprint) ListServices() []object.Object {
-	names := b.getServiceNames()
-	services := make([]object.Object, 0, len(names))
+	selected := map[string]bool{}
 	for _, name := range b.getServiceNames() {
-		services = append(services, b.GetObject(object.ServiceKind, name))
+		selected[name] = true
+	}
+	services := make([]object.Object, 0, len(selected))
+	for _, service := range sortServices(b.GetObjectsByKind(object.ServiceKind)) {
+		if selected[service.Name()] {
+			services = append(services, service)
+		}
 	}
 	return services
 }
@@ -163,7 +230,11 @@ func (b *Blueprint) AddDocuments(documents ...object.Object) error {
 		key := string(obj.Kind()) + "/" + obj.Name()
 		duplicate, ok := b.objects[key]
 		if ok {
-			return fmt.Errorf("%s is duplicated, it's defined in:\n\t* %s\n\t* %s", obj.DisplayName(), duplicate.Metadata(), obj.Metadata())
+			return &schema.ValidationError{
+				Filename: obj.Metadata().Filename(),
+				Line:     obj.Metadata().Line(),
+				Message:  fmt.Sprintf("%s is duplicated, it's also defined in %s", obj.DisplayName(), duplicate.Metadata()),
+			}
 		}
 		b.objects[key] = obj
 	}
@@ -232,6 +303,28 @@ func (b *Blueprint) getEnvironmentNames() (names []string) {
 	return names
 }
 
+func (b *Blueprint) getExecutors() (executors []object.Object) {
+	for _, kind := range []object.Kind{object.BuilderKind, object.DeployerKind, object.PusherKind, object.RunnerKind, object.TaggerKind} {
+		executors = append(executors, b.GetObjectsByKind(kind)...)
+	}
+	return executors
+}
+
+// getSecretsHolders returns objects which secrets are used: the project and
+// the environment in use. Secrets of other environments may be unavailable.
+func (b *Blueprint) getSecretsHolders() (holders []object.SecretsHolder) {
+	objects := b.GetObjectsByKind(object.ProjectKind)
+	if env := b.GetObject(object.EnvironmentKind, b.Environment); b.Environment != "" && env != nil {
+		objects = append(objects, env)
+	}
+	for _, obj := range objects {
+		if holder, ok := obj.(object.SecretsHolder); ok {
+			holders = append(holders, holder)
+		}
+	}
+	return holders
+}
+
 func (b *Blueprint) readFile(filename string, mode Mode) ([]object.Object, error) {
 	log.Debugf("Loading file: %s", filename)
 
@@ -248,7 +341,7 @@ func (b *Blueprint) readFile(filename string, mode Mode) ([]object.Object, error
 	for _, preprocessor := range b.Preprocessors {
 		buf, err = preprocessor(buf)
 		if err != nil {
-			return nil, fmt.Errorf(`file "%s" contains invalid document: %s`, filename, err)
+			return nil, fileError(filename, err)
 		}
 	}
 
@@ -263,14 +356,21 @@ func (b *Blueprint) readFile(filename string, mode Mode) ([]object.Object, error
 		err := decoder.Decode(&content)
 		if err != nil {
 			if err != io.EOF {
-				return nil, fmt.Errorf(`file "%s" contains invalid document: %s`, filename, err)
+				return nil, fileError(filename, err)
 			}
 			break
 		}
 
+		for _, preprocessor := range b.DocumentPreprocessors {
+			err = preprocessor(&content)
+			if err != nil {
+				return nil, fileError(filename, err)
+			}
+		}
+
 		doc, err := object.NewObject(string(b.Mode), filename, &content)
 		if err != nil {
-			return nil, err
+			return nil, fileError(filename, err)
 		}
 
 		if mode != DeployMode && doc.Kind() == object.EnvironmentKind {
@@ -282,3 +382,27 @@ func (b *Blueprint) readFile(filename string, mode Mode) ([]object.Object, error
 
 	return documents, nil
 }
+
+// fileError assigns filename to validation errors, other errors are wrapped
+// with a message containing filename.
+func fileError(filename string, err error) error {
+	var errs []error
+	if merr, ok := err.(*multierror.Error); ok {
+		errs = merr.Errors
+	} else {
+		errs = []error{err}
+	}
+
+	var result error
+	for _, e := range errs {
+		if verr, ok := e.(*schema.ValidationError); ok {
+			if verr.Filename == "" {
+				verr.Filename = filename
+			}
+			result = multierror.Append(result, verr)
+		} else {
+			result = multierror.Append(result, fmt.Errorf(`file "%s" contains invalid document: %s`, filename, e))
+		}
+	}
+	return result
+}
@@ -0,0 +1,166 @@
+package blueprint
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/g2a-com/cicd/internal/object"
+	"github.com/g2a-com/cicd/internal/schema"
+	"github.com/g2a-com/cicd/internal/script"
+	"github.com/g2a-com/cicd/internal/secrets"
+	fakelogger "github.com/g2a-com/cicd/internal/utils/fake_logger"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_project_secrets_are_resolved_and_masked_in_run_mode(t *testing.T) {
+	t.Setenv("TEST_RUN_TOKEN", "supersecret123")
+	b := loadProject(t, RunMode, `
+apiVersion: g2a-cli/v2.0
+kind: Project
+name: test
+secrets:
+  token:
+    env: TEST_RUN_TOKEN
+tasks:
+  print:
+    - script:
+        sh: 'echo "spec={{ .Project.Secrets.token }} env=$TEST_RUN_TOKEN"'
+`)
+	assert.NoError(t, b.Validate())
+
+	output, err := runTask(t, b, "print", secrets.NewMasker(b.ListSecretValues()...), nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"sh": `echo "spec=supersecret123 env=$TEST_RUN_TOKEN"`}, b.GetProject().Entries("print")[0].Spec(b))
+	assert.Contains(t, output, "spec=*** env=***")
+	assert.NotContains(t, output, "supersecret123")
+}
+
+func Test_validating_project_with_unreadable_secrets(t *testing.T) {
+	content := `
+apiVersion: g2a-cli/v2.0
+kind: Project
+name: test
+secrets:
+  token:
+    env: TEST_MISSING_TOKEN
+`
+	testCases := []struct {
+		name                    string
+		ignoreUnreadableSecrets bool
+		err                     string
+	}{
+		{name: "fails by default", err: `cannot read secret "token", environment variable TEST_MISSING_TOKEN is not set`},
+		{name: "passes when unreadable secrets are ignored", ignoreUnreadableSecrets: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			b := loadProject(t, BuildMode, content)
+			b.IgnoreUnreadableSecrets = tc.ignoreUnreadableSecrets
+
+			err := b.Validate()
+
+			if tc.err != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_builtin_script_runner_can_run_tasks_when_policy_requires_commands(t *testing.T) {
+	b := loadProject(t, RunMode, `
+apiVersion: g2a-cli/v2.0
+kind: Project
+name: test
+tasks:
+  print:
+    - script:
+        sh: 'echo "from sh"'
+    - script:
+        path: ./print.sh
+`)
+	prepareScript(t, filepath.Join(b.GetProject().Directory(), "print.sh"), "#!/bin/sh\necho \"from path\"\n")
+	assert.NoError(t, b.Validate())
+
+	output, err := runTask(t, b, "print", nil, &object.ExecutorPolicy{RequireCommands: true})
+
+	assert.NoError(t, err)
+	assert.Contains(t, output, "from sh")
+	assert.Contains(t, output, "from path")
+}
+
+// runTask runs entries of the project task using runners in the blueprint and
+// returns their output.
+func runTask(t *testing.T, b *Blueprint, task string, masker *secrets.Masker, policy *object.ExecutorPolicy) (string, error) {
+	logger := fakelogger.New()
+	for _, entry := range b.GetProject().Entries(task) {
+		runner, ok := b.GetExecutor(entry.ExecutorKind(), entry.ExecutorName())
+		if !ok {
+			t.Fatalf("missing runner %q", entry.ExecutorName())
+		}
+		s := script.New(runner)
+		s.Logger = logger
+		s.Masker = masker
+		s.Policy = policy
+		_, err := s.Run(map[string]interface{}{
+			"spec": entry.Spec(b),
+			"dirs": map[string]interface{}{"project": b.GetProject().Directory()},
+		})
+		if err != nil {
+			return "", err
+		}
+	}
+
+	output := ""
+	for _, msg := range logger.Messages {
+		for _, arg := range msg.Args {
+			output += fmt.Sprintf("%s", arg)
+		}
+	}
+	return output, nil
+}
+
+func prepareScript(t *testing.T, filename string, content string) {
+	if err := os.WriteFile(filename, []byte(content), 0755); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func loadProject(t *testing.T, mode Mode, content string) *Blueprint {
+	filename := filepath.Join(t.TempDir(), "project.yaml")
+	if err := os.WriteFile(filename, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	b := &Blueprint{
+		Mode:                  mode,
+		Preprocessors:         []Preprocessor{schema.Validate},
+		DocumentPreprocessors: []DocumentPreprocessor{schema.MigrateDocument},
+	}
+	if err := b.Load(filepath.Join("..", "..", "assets", "executors", "*", "*.yaml")); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Load(filename); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.AddDocuments(fakeOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+// fakeOptions provides the Options object, which is normally created from
+// command line flags.
+type fakeOptions struct {
+	object.GenericObject
+}
+
+func (o fakeOptions) Kind() object.Kind {
+	return object.OptionsKind
+}
@@ -0,0 +1,168 @@
+package blueprint
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/g2a-com/cicd/internal/object"
+)
+
+// sortServices orders services topologically, so each service is preceded
+// by services it depends on. Services which don't depend on each other are
+// ordered alphabetically. Services involved in dependency cycles are put at
+// the end of the list.
+func sortServices(services []object.Object) []object.Object {
+	byName := map[string]object.Object{}
+	for _, s := range services {
+		byName[s.Name()] = s
+	}
+
+	inDegree := map[string]int{}
+	dependants := map[string][]string{}
+	for _, s := range services {
+		inDegree[s.Name()] += 0
+		for _, dep := range getDependencies(s) {
+			if _, ok := byName[dep]; ok {
+				inDegree[s.Name()]++
+				dependants[dep] = append(dependants[dep], s.Name())
+			}
+		}
+	}
+
+	ready := []string{}
+	for name, degree := range inDegree {
+		if degree == 0 {
+			ready = append(ready, name)
+		}
+	}
+
+	result := make([]object.Object, 0, len(services))
+	for len(ready) > 0 {
+		sort.Strings(ready)
+		name := ready[0]
+		ready = ready[1:]
+
+		result = append(result, byName[name])
+		delete(inDegree, name)
+
+		for _, dependant := range dependants[name] {
+			inDegree[dependant]--
+			if inDegree[dependant] == 0 {
+				ready = append(ready, dependant)
+			}
+		}
+	}
+
+	remaining := make([]string, 0, len(inDegree))
+	for name := range inDegree {
+		remaining = append(remaining, name)
+	}
+	sort.Strings(remaining)
+	for _, name := range remaining {
+		result = append(result, byName[name])
+	}
+
+	return result
+}
+
+// validateDependencies returns an error for each dependency cycle between
+// services.
+func validateDependencies(services []object.Object) (errs []error) {
+	byName := map[string]object.Object{}
+	for _, s := range services {
+		byName[s.Name()] = s
+	}
+
+	for _, cycle := range findCycles(byName, getDependencies) {
+		errs = append(errs, newCycleError("services have cyclic dependencies", byName, cycle, dependencyPointer))
+	}
+
+	return errs
+}
+
+// findCycles returns cycles in the graph of objects, where edges are returned
+// by the function. Edges leading to objects outside the graph are ignored.
+// Each cycle is a list of names, which starts and ends with the same name.
+func findCycles(objects map[string]object.Object, edges func(object.Object) []string) (cycles [][]string) {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	names := make([]string, 0, len(objects))
+	for name := range objects {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	state := map[string]int{}
+	stack := []string{}
+
+	var visit func(name string)
+	visit = func(name string) {
+		state[name] = visiting
+		stack = append(stack, name)
+
+		for _, dep := range edges(objects[name]) {
+			if _, ok := objects[dep]; !ok {
+				continue
+			}
+			switch state[dep] {
+			case unvisited:
+				visit(dep)
+			case visiting:
+				for i := range stack {
+					if stack[i] == dep {
+						cycles = append(cycles, append(stack[i:len(stack):len(stack)], dep))
+						break
+					}
+				}
+			}
+		}
+
+		stack = stack[:len(stack)-1]
+		state[name] = visited
+	}
+
+	for _, name := range names {
+		if state[name] == unvisited {
+			visit(name)
+		}
+	}
+
+	return cycles
+}
+
+// newCycleError creates an error pointing to the edge (e.g. "/dependsOn/0")
+// leading from the first object of the cycle to the next one.
+func newCycleError(message string, objects map[string]object.Object, cycle []string, pointer func(obj object.Object, next string) string) error {
+	files := make([]string, 0, len(cycle)-1)
+	for _, name := range cycle[:len(cycle)-1] {
+		files = append(files, objects[name].Metadata().String())
+	}
+	return object.NewValidationError(
+		objects[cycle[0]].Metadata(), pointer(objects[cycle[0]], cycle[1]),
+		"%s: %s\n\t  Definition files:\n\t    %s",
+		message, strings.Join(cycle, " -> "), strings.Join(files, "\n\t    "),
+	)
+}
+
+// dependencyPointer returns pointer to the dependency within "dependsOn" of
+// the service.
+func dependencyPointer(obj object.Object, name string) string {
+	for i, dep := range getDependencies(obj) {
+		if dep == name {
+			return fmt.Sprintf("/dependsOn/%d", i)
+		}
+	}
+	return "/dependsOn"
+}
+
+func getDependencies(obj object.Object) []string {
+	if service, ok := obj.(object.Service); ok {
+		return service.DependsOn()
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package blueprint
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/g2a-com/cicd/internal/object"
+	"github.com/g2a-com/cicd/internal/schema"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func Test_services_without_dependencies_are_sorted_alphabetically(t *testing.T) {
+	services := []object.Object{
+		newService("c"),
+		newService("a"),
+		newService("b"),
+	}
+
+	result := sortServices(services)
+
+	assert.Equal(t, []string{"a", "b", "c"}, names(result))
+}
+
+func Test_services_are_preceded_by_their_dependencies(t *testing.T) {
+	services := []object.Object{
+		newService("a", "c"),
+		newService("b"),
+		newService("c", "d"),
+		newService("d"),
+	}
+
+	result := sortServices(services)
+
+	assert.Equal(t, []string{"b", "d", "c", "a"}, names(result))
+}
+
+func Test_sorting_ignores_dependencies_on_services_outside_the_list(t *testing.T) {
+	services := []object.Object{
+		newService("a", "missing"),
+		newService("b"),
+	}
+
+	result := sortServices(services)
+
+	assert.Equal(t, []string{"a", "b"}, names(result))
+}
+
+func Test_validating_services_without_cycles_passes(t *testing.T) {
+	services := []object.Object{
+		newService("a", "b", "c"),
+		newService("b", "c"),
+		newService("c"),
+	}
+
+	errs := validateDependencies(services)
+
+	assert.Empty(t, errs)
+}
+
+func Test_validating_services_with_cycle_fails(t *testing.T) {
+	services := []object.Object{
+		newService("a", "b"),
+		newService("b", "c"),
+		newService("c", "a"),
+		newService("d"),
+	}
+
+	errs := validateDependencies(services)
+
+	assert.Len(t, errs, 1)
+	assert.Equal(t, &schema.ValidationError{
+		Filename: "a.yaml",
+		Line:     1,
+		Pointer:  "/dependsOn/0",
+		Message:  "services have cyclic dependencies: a -> b -> c -> a\n\t  Definition files:\n\t    a.yaml:1\n\t    b.yaml:1\n\t    c.yaml:1",
+	}, errs[0])
+}
+
+func Test_validating_service_depending_on_itself_fails(t *testing.T) {
+	services := []object.Object{
+		newService("a", "a"),
+	}
+
+	errs := validateDependencies(services)
+
+	assert.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Error(), "a -> a")
+}
+
+func Test_cycle_error_points_to_dependency_leading_to_cycle(t *testing.T) {
+	var node yaml.Node
+	_ = yaml.Unmarshal([]byte("kind: Service\nname: a\ndependsOn:\n  - c\n  - b\n"), &node)
+	a, _ := object.NewObject("build", "a.yaml", &node)
+	services := []object.Object{a, newService("b", "a"), newService("c")}
+
+	errs := validateDependencies(services)
+
+	if assert.Len(t, errs, 1) {
+		err := errs[0].(*schema.ValidationError)
+		assert.Equal(t, "/dependsOn/1", err.Pointer)
+		assert.Equal(t, 5, err.Line)
+	}
+}
+
+func newService(name string, dependsOn ...string) object.Object {
+	var node yaml.Node
+	deps, _ := json.Marshal(dependsOn)
+	err := yaml.Unmarshal([]byte(fmt.Sprintf("{ kind: Service, name: %q, dependsOn: %s }", name, deps)), &node)
+	if err != nil {
+		panic(err)
+	}
+	obj, err := object.NewObject("build", name+".yaml", &node)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+func names(objects []object.Object) []string {
+	result := make([]string, len(objects))
+	for i, obj := range objects {
+		result[i] = obj.Name()
+	}
+	return result
+}
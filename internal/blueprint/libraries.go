@@ -0,0 +1,103 @@
+package blueprint
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/g2a-com/cicd/internal/object"
+	"github.com/g2a-com/cicd/internal/schema"
+	"github.com/g2a-com/cicd/internal/script"
+	"github.com/g2a-com/cicd/internal/script/stdlib"
+)
+
+// validateLibraries returns an error for each library named the same as a
+// module of the standard library, for each import of a library which doesn't
+// exist and for each import cycle between libraries.
+func validateLibraries(libraries []object.Object, executors []object.Object) (errs []error) {
+	modules := map[string]bool{}
+	for _, name := range stdlib.ModuleNames() {
+		modules[name] = true
+	}
+
+	byName := map[string]object.Object{}
+	for _, l := range libraries {
+		if modules[l.Name()] {
+			errs = append(errs, &schema.ValidationError{
+				Filename: l.Metadata().Filename(),
+				Line:     l.Metadata().Line(),
+				Pointer:  "/name",
+				Message:  fmt.Sprintf("%s conflicts with a module of the standard library", l.DisplayName()),
+			})
+			continue
+		}
+		byName[l.Name()] = l
+	}
+
+	objects := append(append([]object.Object{}, libraries...), executors...)
+	for _, obj := range objects {
+		for _, s := range getScripts(obj) {
+			for _, name := range script.Imports(s.source) {
+				if modules[name] || byName[name] != nil {
+					continue
+				}
+				message := fmt.Sprintf("missing library %q imported by %s", name, obj.DisplayName())
+				if len(byName) > 0 {
+					message += ", available libraries: " + strings.Join(getNames(byName), ", ")
+				}
+				errs = append(errs, &schema.ValidationError{
+					Filename: obj.Metadata().Filename(),
+					Line:     obj.Metadata().Line(),
+					Pointer:  s.pointer,
+					Message:  message,
+				})
+			}
+		}
+	}
+
+	for _, cycle := range findCycles(byName, getImports) {
+		errs = append(errs, newCycleError("libraries have cyclic imports", byName, cycle, importPointer))
+	}
+
+	return errs
+}
+
+type objectScript struct {
+	pointer string
+	source  string
+}
+
+func getScripts(obj object.Object) (scripts []objectScript) {
+	switch o := obj.(type) {
+	case object.Library:
+		scripts = append(scripts, objectScript{"/script", o.Script()})
+	case object.Executor:
+		scripts = append(scripts, objectScript{"/script", o.Script()})
+		if o.RollbackScript() != "" {
+			scripts = append(scripts, objectScript{"/rollback", o.RollbackScript()})
+		}
+	}
+	return scripts
+}
+
+// importPointer returns pointer to the script importing libraries, imports
+// are parts of the script, so they cannot be pointed directly.
+func importPointer(obj object.Object, name string) string {
+	return "/script"
+}
+
+func getImports(obj object.Object) []string {
+	if library, ok := obj.(object.Library); ok {
+		return script.Imports(library.Script())
+	}
+	return nil
+}
+
+func getNames(objects map[string]object.Object) []string {
+	names := make([]string, 0, len(objects))
+	for name := range objects {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
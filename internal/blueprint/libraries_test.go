@@ -0,0 +1,100 @@
+package blueprint
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/g2a-com/cicd/internal/object"
+	"github.com/g2a-com/cicd/internal/schema"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func Test_validating_libraries_without_cycles_passes(t *testing.T) {
+	libraries := []object.Object{
+		newLibrary("a", `b := import("b"); c := import("c")`),
+		newLibrary("b", `c := import("c"); text := import("text")`),
+		newLibrary("c", `export {}`),
+	}
+	executors := []object.Object{
+		newScriptExecutor("test", `a := import("a"); exec := import("exec")`),
+	}
+
+	errs := validateLibraries(libraries, executors)
+
+	assert.Empty(t, errs)
+}
+
+func Test_validating_libraries_with_cycle_fails(t *testing.T) {
+	libraries := []object.Object{
+		newLibrary("a", `import("b")`),
+		newLibrary("b", `import("c")`),
+		newLibrary("c", `import("a")`),
+		newLibrary("d", `import("a")`),
+	}
+
+	errs := validateLibraries(libraries, nil)
+
+	assert.Len(t, errs, 1)
+	assert.Equal(t, &schema.ValidationError{
+		Filename: "a.yaml",
+		Line:     1,
+		Pointer:  "/script",
+		Message:  "libraries have cyclic imports: a -> b -> c -> a\n\t  Definition files:\n\t    a.yaml:1\n\t    b.yaml:1\n\t    c.yaml:1",
+	}, errs[0])
+}
+
+func Test_validating_import_of_missing_library_fails(t *testing.T) {
+	libraries := []object.Object{
+		newLibrary("a", `export {}`),
+		newLibrary("b", `import("missing-in-library")`),
+	}
+	executors := []object.Object{
+		newScriptExecutor("test", `import("missing-in-executor")`),
+	}
+
+	errs := validateLibraries(libraries, executors)
+
+	if assert.Len(t, errs, 2) {
+		assert.Equal(t, "/script: missing library \"missing-in-library\" imported by library \"b\", available libraries: a, b\n\t  at b.yaml:1", errs[0].Error())
+		assert.Equal(t, "/script: missing library \"missing-in-executor\" imported by builder \"test\", available libraries: a, b\n\t  at test.yaml:1", errs[1].Error())
+	}
+}
+
+func Test_validating_library_named_as_module_fails(t *testing.T) {
+	libraries := []object.Object{
+		newLibrary("exec", `export {}`),
+	}
+
+	errs := validateLibraries(libraries, nil)
+
+	if assert.Len(t, errs, 1) {
+		assert.Contains(t, errs[0].Error(), `library "exec" conflicts with a module of the standard library`)
+	}
+}
+
+func newLibrary(name string, script string) object.Object {
+	var node yaml.Node
+	err := yaml.Unmarshal([]byte(fmt.Sprintf("{ kind: Library, name: %q, script: %q }", name, script)), &node)
+	if err != nil {
+		panic(err)
+	}
+	obj, err := object.NewObject("build", name+".yaml", &node)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+func newScriptExecutor(name string, script string) object.Object {
+	var node yaml.Node
+	err := yaml.Unmarshal([]byte(fmt.Sprintf("{ kind: Builder, name: %q, schema: {}, script: %q }", name, script)), &node)
+	if err != nil {
+		panic(err)
+	}
+	obj, err := object.NewObject("build", name+".yaml", &node)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
@@ -0,0 +1,81 @@
+package cache
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"sync"
+)
+
+// Entry describes the last successful build of a service.
+type Entry struct {
+	Fingerprint     string   `json:"fingerprint"`
+	Tags            []string `json:"tags"`
+	Artifacts       []Result `json:"artifacts"`
+	Pushed          bool     `json:"pushed"`
+	PushedArtifacts []Result `json:"pushedArtifacts"`
+}
+
+// Result is a single result returned by an executor for a service entry.
+type Result struct {
+	Entry    int                    `json:"entry"`
+	Result   string                 `json:"result"`
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// Cache keeps entries for services. It's safe for concurrent use.
+type Cache struct {
+	filename string
+	entries  map[string]Entry
+	mutex    sync.Mutex
+}
+
+// Load reads cache from the file. Missing file results in an empty cache.
+func Load(filename string) (*Cache, error) {
+	c := &Cache{filename: filename, entries: map[string]Entry{}}
+
+	content, err := os.ReadFile(filename)
+	if errors.Is(err, fs.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(content, &c.entries)
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// Get returns entry for the service.
+func (c *Cache) Get(service string) (Entry, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	entry, ok := c.entries[service]
+	return entry, ok
+}
+
+// Set updates entry for the service.
+func (c *Cache) Set(service string, entry Entry) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.entries[service] = entry
+}
+
+// Save writes cache to the file it was loaded from.
+func (c *Cache) Save() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	content, err := json.MarshalIndent(c.entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(c.filename, append(content, '\n'), 0644)
+}
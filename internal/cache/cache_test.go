@@ -0,0 +1,63 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_loading_missing_cache_file_returns_empty_cache(t *testing.T) {
+	c, err := Load(filepath.Join(t.TempDir(), "cache.json"))
+
+	assert.NoError(t, err)
+	_, ok := c.Get("service")
+	assert.False(t, ok)
+}
+
+func Test_loading_invalid_cache_file_fails(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "cache.json")
+	os.WriteFile(filename, []byte("invalid"), 0644)
+
+	_, err := Load(filename)
+
+	assert.Error(t, err)
+}
+
+func Test_saved_entries_can_be_loaded(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "cache.json")
+	entry := Entry{
+		Fingerprint: "abc",
+		Tags:        []string{"latest"},
+		Artifacts:   []Result{{Entry: 0, Result: "image:latest"}},
+	}
+
+	c, _ := Load(filename)
+	c.Set("service", entry)
+	err := c.Save()
+	assert.NoError(t, err)
+
+	c, err = Load(filename)
+	assert.NoError(t, err)
+	result, ok := c.Get("service")
+	assert.True(t, ok)
+	assert.Equal(t, entry, result)
+}
+
+func Test_metadata_of_results_is_saved(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "cache.json")
+	entry := Entry{
+		Fingerprint: "abc",
+		Tags:        []string{"latest"},
+		Artifacts:   []Result{{Entry: 0, Result: "image:latest", Metadata: map[string]interface{}{"digest": "sha256:1"}}},
+	}
+
+	c, _ := Load(filename)
+	c.Set("service", entry)
+	_ = c.Save()
+
+	c, _ = Load(filename)
+	result, _ := c.Get("service")
+	assert.Equal(t, entry, result)
+}
@@ -0,0 +1,96 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// Fingerprint computes a hash of the content of the directory and the values.
+// Files listed in the ignore list, files ignored by git (according to
+// .gitignore files of the directory, its subdirectories and parents within the
+// repository) and ".git" directories are skipped, so outputs written by
+// builders don't change the fingerprint.
+func Fingerprint(dir string, ignore []string, values ...interface{}) (string, error) {
+	hash := sha256.New()
+
+	ignored := map[string]bool{}
+	for _, path := range ignore {
+		path, err := filepath.Abs(path)
+		if err != nil {
+			return "", err
+		}
+		ignored[path] = true
+	}
+
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+
+	rules, err := loadIgnoreRules(dir)
+	if err != nil {
+		return "", err
+	}
+
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if ignored[path] || rules.ignored(path, d.IsDir()) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return rules.load(path)
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		io.WriteString(hash, filepath.ToSlash(rel))
+		hash.Write([]byte{0})
+
+		if d.Type()&fs.ModeSymlink != 0 {
+			target, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			io.WriteString(hash, target)
+		} else if d.Type().IsRegular() {
+			f, err := os.Open(path)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			_, err = io.Copy(hash, f)
+			if err != nil {
+				return err
+			}
+		}
+		hash.Write([]byte{0})
+
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	content, err := json.Marshal(values)
+	if err != nil {
+		return "", err
+	}
+	hash.Write(content)
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -0,0 +1,128 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_fingerprint_is_stable(t *testing.T) {
+	dir := prepareDir(t, map[string]string{"a.txt": "a", "sub/b.txt": "b"})
+
+	fp1, err1 := Fingerprint(dir, nil, "value")
+	fp2, err2 := Fingerprint(dir, nil, "value")
+
+	assert.NoError(t, err1)
+	assert.NoError(t, err2)
+	assert.Equal(t, fp1, fp2)
+}
+
+func Test_fingerprint_changes_when_file_content_changes(t *testing.T) {
+	dir := prepareDir(t, map[string]string{"a.txt": "a"})
+
+	fp1, _ := Fingerprint(dir, nil)
+	os.WriteFile(filepath.Join(dir, "a.txt"), []byte("changed"), 0644)
+	fp2, _ := Fingerprint(dir, nil)
+
+	assert.NotEqual(t, fp1, fp2)
+}
+
+func Test_fingerprint_changes_when_file_is_renamed(t *testing.T) {
+	dir := prepareDir(t, map[string]string{"a.txt": "a"})
+
+	fp1, _ := Fingerprint(dir, nil)
+	os.Rename(filepath.Join(dir, "a.txt"), filepath.Join(dir, "b.txt"))
+	fp2, _ := Fingerprint(dir, nil)
+
+	assert.NotEqual(t, fp1, fp2)
+}
+
+func Test_fingerprint_changes_when_values_change(t *testing.T) {
+	dir := prepareDir(t, map[string]string{"a.txt": "a"})
+
+	fp1, _ := Fingerprint(dir, nil, map[string]interface{}{"image": "a"})
+	fp2, _ := Fingerprint(dir, nil, map[string]interface{}{"image": "b"})
+
+	assert.NotEqual(t, fp1, fp2)
+}
+
+func Test_fingerprint_skips_ignored_files_and_git_directory(t *testing.T) {
+	dir := prepareDir(t, map[string]string{"a.txt": "a", "cache.json": "1", ".git/HEAD": "1"})
+
+	fp1, _ := Fingerprint(dir, []string{filepath.Join(dir, "cache.json")})
+	os.WriteFile(filepath.Join(dir, "cache.json"), []byte("2"), 0644)
+	os.WriteFile(filepath.Join(dir, ".git/HEAD"), []byte("2"), 0644)
+	fp2, _ := Fingerprint(dir, []string{filepath.Join(dir, "cache.json")})
+
+	assert.Equal(t, fp1, fp2)
+}
+
+func Test_fingerprint_skips_outputs_ignored_by_git(t *testing.T) {
+	dir := prepareDir(t, map[string]string{
+		".gitignore":     "# outputs\n/dist/\n*.log\n!keep.log\n",
+		"src/.gitignore": "generated/**/*.go\n",
+		"src/main.go":    "package main",
+		"keep.log":       "1",
+	})
+
+	fp1, _ := Fingerprint(dir, nil)
+	// Builder writes its outputs into the service directory
+	prepareFiles(t, dir, map[string]string{
+		"dist/app":               "binary",
+		"build.log":              "log",
+		"src/generated/a/b.go":   "package b",
+		"src/generated/other.go": "package generated",
+	})
+	fp2, _ := Fingerprint(dir, nil)
+
+	assert.Equal(t, fp1, fp2)
+}
+
+func Test_fingerprint_doesnt_skip_files_reincluded_by_gitignore(t *testing.T) {
+	dir := prepareDir(t, map[string]string{".gitignore": "*.log\n!keep.log\n", "keep.log": "1"})
+
+	fp1, _ := Fingerprint(dir, nil)
+	os.WriteFile(filepath.Join(dir, "keep.log"), []byte("2"), 0644)
+	fp2, _ := Fingerprint(dir, nil)
+
+	assert.NotEqual(t, fp1, fp2)
+}
+
+func Test_fingerprint_uses_gitignore_of_parents_within_repository(t *testing.T) {
+	repo := prepareDir(t, map[string]string{".git/HEAD": "1", ".gitignore": "dist/\n", "service/a.txt": "a"})
+	dir := filepath.Join(repo, "service")
+
+	fp1, _ := Fingerprint(dir, nil)
+	prepareFiles(t, dir, map[string]string{"dist/app": "binary"})
+	fp2, _ := Fingerprint(dir, nil)
+
+	assert.Equal(t, fp1, fp2)
+}
+
+func Test_fingerprint_fails_for_missing_directory(t *testing.T) {
+	_, err := Fingerprint(filepath.Join(t.TempDir(), "missing"), nil)
+
+	assert.Error(t, err)
+}
+
+func prepareDir(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	prepareFiles(t, dir, files)
+	return dir
+}
+
+func prepareFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		err := os.MkdirAll(filepath.Dir(path), 0755)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = os.WriteFile(path, []byte(content), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
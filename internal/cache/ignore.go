@@ -0,0 +1,113 @@
+package cache
+
+import (
+	"bufio"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
+)
+
+// ignoreRules matches paths against patterns read from .gitignore files, so
+// build outputs (which are usually ignored by git) don't affect fingerprints.
+// Patterns are parsed and matched by go-git, following the same rules as git.
+type ignoreRules struct {
+	// root is the root of the repository, or the fingerprinted directory when
+	// it isn't in a repository
+	root     string
+	patterns []gitignore.Pattern
+}
+
+// loadIgnoreRules reads excludes of the git repository containing the
+// directory and .gitignore files of the directory's parents, up to the root
+// of the repository. When the
+// directory isn't in a repository, parents are not used.
+func loadIgnoreRules(dir string) (*ignoreRules, error) {
+	var parents []string
+	root := ""
+	for p := dir; ; p = filepath.Dir(p) {
+		// .gitignore of the directory itself is loaded while walking it
+		if p != dir {
+			parents = append(parents, p)
+		}
+		if _, err := os.Stat(filepath.Join(p, ".git")); err == nil {
+			root = p
+			break
+		}
+		if filepath.Dir(p) == p {
+			break
+		}
+	}
+	if root == "" {
+		return &ignoreRules{root: dir}, nil
+	}
+
+	r := &ignoreRules{root: root}
+	err := r.readFile(filepath.Join(root, ".git", "info", "exclude"), nil)
+	if err != nil {
+		return nil, err
+	}
+	for i := len(parents) - 1; i >= 0; i-- {
+		err := r.load(parents[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// load adds patterns from .gitignore file in the directory, if it exists.
+// Patterns added later take precedence.
+func (r *ignoreRules) load(dir string) error {
+	domain, ok := r.split(dir)
+	if !ok {
+		return nil
+	}
+	return r.readFile(filepath.Join(dir, ".gitignore"), domain)
+}
+
+func (r *ignoreRules) readFile(filename string, domain []string) error {
+	f, err := os.Open(filename)
+	if errors.Is(err, fs.ErrNotExist) || errors.Is(err, syscall.ENOTDIR) {
+		// ".git" may be a file, e.g. in worktrees
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "#") && strings.TrimSpace(line) != "" {
+			r.patterns = append(r.patterns, gitignore.ParsePattern(line, domain))
+		}
+	}
+	return scanner.Err()
+}
+
+// ignored checks whether the path is ignored, the last matching pattern wins.
+func (r *ignoreRules) ignored(p string, isDir bool) bool {
+	segments, ok := r.split(p)
+	if !ok || len(segments) == 0 {
+		return false
+	}
+	return gitignore.NewMatcher(r.patterns).Match(segments, isDir)
+}
+
+// split returns segments of the path relative to the root.
+func (r *ignoreRules) split(p string) ([]string, bool) {
+	rel, err := filepath.Rel(r.root, p)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return nil, false
+	}
+	if rel == "." {
+		return []string{}, true
+	}
+	return strings.Split(filepath.ToSlash(rel), "/"), true
+}
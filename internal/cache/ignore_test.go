@@ -0,0 +1,57 @@
+package cache
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ignore_rules_match_gitignore_patterns(t *testing.T) {
+	testCases := []struct {
+		name     string
+		files    map[string]string
+		path     string
+		isDir    bool
+		expected bool
+	}{
+		{name: "wildcard", files: map[string]string{".gitignore": "*.log\n"}, path: "src/build.log", expected: true},
+		{name: "negation", files: map[string]string{".gitignore": "*.log\n!keep.log\n"}, path: "src/keep.log", expected: false},
+		{name: "negation overridden by later pattern", files: map[string]string{".gitignore": "!keep.log\n*.log\n"}, path: "keep.log", expected: true},
+		{name: "negation in nested gitignore", files: map[string]string{".gitignore": "*.log\n", "src/.gitignore": "!keep.log\n"}, path: "src/keep.log", expected: false},
+		{name: "directory-only pattern matches directory", files: map[string]string{".gitignore": "dist/\n"}, path: "src/dist", isDir: true, expected: true},
+		{name: "directory-only pattern doesn't match file", files: map[string]string{".gitignore": "dist/\n"}, path: "src/dist", expected: false},
+		{name: "anchored pattern matches at its directory", files: map[string]string{".gitignore": "/dist\n"}, path: "dist", isDir: true, expected: true},
+		{name: "anchored pattern doesn't match in subdirectory", files: map[string]string{".gitignore": "/dist\n"}, path: "src/dist", isDir: true, expected: false},
+		{name: "pattern with slash is anchored", files: map[string]string{".gitignore": "src/gen\n"}, path: "lib/src/gen", expected: false},
+		{name: "anchored pattern in nested gitignore", files: map[string]string{"src/.gitignore": "/gen\n"}, path: "src/gen", isDir: true, expected: true},
+		{name: "double asterisk", files: map[string]string{".gitignore": "gen/**/*.go\n"}, path: "gen/a/b/c.go", expected: true},
+		{name: "comment", files: map[string]string{".gitignore": "# dist\n"}, path: "# dist", expected: false},
+		{name: "repository excludes", files: map[string]string{".git/info/exclude": "*.tmp\n"}, path: "a.tmp", expected: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := prepareDir(t, tc.files)
+			prepareFiles(t, dir, map[string]string{".git/HEAD": "1"})
+			rules, err := loadIgnoreRules(dir)
+			assert.NoError(t, err)
+			for _, d := range []string{".", "src", "lib/src"} {
+				assert.NoError(t, rules.load(filepath.Join(dir, d)))
+			}
+
+			assert.Equal(t, tc.expected, rules.ignored(filepath.Join(dir, tc.path), tc.isDir))
+		})
+	}
+}
+
+func Test_ignore_rules_dont_use_parents_outside_repository(t *testing.T) {
+	parent := prepareDir(t, map[string]string{".gitignore": "*.txt\n"})
+	dir := filepath.Join(parent, "service")
+	prepareFiles(t, dir, map[string]string{"a.txt": "a"})
+
+	rules, err := loadIgnoreRules(dir)
+
+	assert.NoError(t, err)
+	assert.False(t, rules.ignored(filepath.Join(dir, "a.txt"), false))
+}
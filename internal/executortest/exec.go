@@ -0,0 +1,106 @@
+package executortest
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"k8s.io/utils/exec"
+	testingexec "k8s.io/utils/exec/testing"
+)
+
+// fakeExec answers commands run by the executor using the list of expected
+// commands. Unexpected commands fail, without producing any output.
+type fakeExec struct {
+	mutex    sync.Mutex
+	expected []Command
+	calls    int
+	failures []string
+}
+
+var _ exec.Interface = &fakeExec{}
+
+func newFakeExec(expected []Command) *fakeExec {
+	return &fakeExec{expected: expected}
+}
+
+func (e *fakeExec) Command(name string, args ...string) exec.Cmd {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	argv := append([]string{name}, args...)
+	cmd := &testingexec.FakeCmd{}
+
+	if e.calls >= len(e.expected) {
+		e.failures = append(e.failures, fmt.Sprintf("unexpected command %s, no more commands were expected", formatList(argv)))
+		cmd.RunScript = []testingexec.FakeAction{unexpectedCommand(argv)}
+		return testingexec.InitFakeCmd(cmd, name, args...)
+	}
+
+	expected := e.expected[e.calls]
+	e.calls++
+	if !equal(expected.Command, argv) {
+		e.failures = append(e.failures, fmt.Sprintf("unexpected command %s, expected %s", formatList(argv), formatList(expected.Command)))
+		cmd.RunScript = []testingexec.FakeAction{unexpectedCommand(argv)}
+		return testingexec.InitFakeCmd(cmd, name, args...)
+	}
+
+	cmd.RunScript = []testingexec.FakeAction{
+		func() ([]byte, []byte, error) {
+			if expected.Stdin != nil {
+				e.checkStdin(argv, *expected.Stdin, cmd.Stdin)
+			}
+			var err error
+			if expected.ExitCode != 0 {
+				err = exec.CodeExitError{
+					Code: expected.ExitCode,
+					Err:  fmt.Errorf("exit status %d", expected.ExitCode),
+				}
+			}
+			return []byte(expected.Stdout), []byte(expected.Stderr), err
+		},
+	}
+	return testingexec.InitFakeCmd(cmd, name, args...)
+}
+
+func (e *fakeExec) CommandContext(ctx context.Context, name string, args ...string) exec.Cmd {
+	return e.Command(name, args...)
+}
+
+func (e *fakeExec) LookPath(file string) (string, error) {
+	return file, nil
+}
+
+func (e *fakeExec) checkStdin(argv []string, expected string, stdin io.Reader) {
+	var actual string
+	if stdin != nil {
+		data, _ := io.ReadAll(stdin)
+		actual = string(data)
+	}
+	if actual != expected {
+		e.mutex.Lock()
+		defer e.mutex.Unlock()
+		e.failures = append(e.failures, fmt.Sprintf("command %s received stdin %q, expected %q", formatList(argv), actual, expected))
+	}
+}
+
+// check returns failures of the test, including expected commands which
+// weren't run.
+func (e *fakeExec) check() []string {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	failures := append([]string{}, e.failures...)
+	for _, cmd := range e.expected[e.calls:] {
+		failures = append(failures, fmt.Sprintf("expected command %s was not run", formatList(cmd.Command)))
+	}
+	return failures
+}
+
+func unexpectedCommand(argv []string) testingexec.FakeAction {
+	return func() ([]byte, []byte, error) {
+		return nil, nil, fmt.Errorf("unexpected command %s", strings.Join(argv, " "))
+	}
+}
@@ -0,0 +1,303 @@
+package executortest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/g2a-com/cicd/internal/blueprint"
+	"github.com/g2a-com/cicd/internal/object"
+	"github.com/g2a-com/cicd/internal/schema"
+	"github.com/g2a-com/cicd/internal/script"
+	fakelogger "github.com/g2a-com/cicd/internal/utils/fake_logger"
+	"gopkg.in/yaml.v3"
+)
+
+// Suite describes tests of a single executor.
+type Suite struct {
+	// Executor is a path to the file defining the executor, relative to the
+	// suite's file.
+	Executor string `yaml:"executor"`
+	// Libraries are globs matching files with libraries used by the executor,
+	// relative to the suite's file.
+	Libraries []string `yaml:"libraries"`
+	Tests     []Test   `yaml:"tests"`
+
+	filename string
+}
+
+// Test runs the executor once, using faked commands.
+type Test struct {
+	Name string `yaml:"name"`
+	// Rollback runs rollback implementation of the executor instead of the
+	// regular one.
+	Rollback bool                   `yaml:"rollback"`
+	Input    map[string]interface{} `yaml:"input"`
+	// Commands are expected to be run by the executor in the same order.
+	Commands []Command `yaml:"commands"`
+	// Results are expected to be added by the executor, they are not checked
+	// when omitted. Strings are compared with identifiers of results, while
+	// maps are compared with identifiers together with metadata.
+	Results *[]interface{} `yaml:"results"`
+	// Logs are expected to be logged by the executor in the same order, but
+	// other lines may be logged in between.
+	Logs []string `yaml:"logs"`
+	// Error is expected to be a part of the error returned by the executor.
+	// When omitted, the executor is expected to succeed.
+	Error string `yaml:"error"`
+}
+
+// Command is a faked command.
+type Command struct {
+	// Command contains name and arguments of the expected command.
+	Command []string `yaml:"command"`
+	// Stdin is expected to be passed to the command, it's not checked when
+	// omitted.
+	Stdin    *string `yaml:"stdin"`
+	Stdout   string  `yaml:"stdout"`
+	Stderr   string  `yaml:"stderr"`
+	ExitCode int     `yaml:"exitCode"`
+}
+
+// Result describes outcome of a single test.
+type Result struct {
+	Name     string
+	Failures []string
+	// Logs contains lines logged by the executor.
+	Logs []string
+}
+
+func (r Result) Passed() bool {
+	return len(r.Failures) == 0
+}
+
+// Load reads the suite from the file.
+func Load(filename string) (*Suite, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	suite := &Suite{filename: filename}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	err = decoder.Decode(suite)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read %s: %w", filename, err)
+	}
+	if suite.Executor == "" {
+		return nil, fmt.Errorf("cannot read %s: executor is not specified", filename)
+	}
+
+	return suite, nil
+}
+
+// Run runs all tests of the suite. Error is returned only when the executor
+// cannot be loaded.
+func (s *Suite) Run() ([]Result, error) {
+	executor, libraries, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]Result, 0, len(s.Tests))
+	for i, test := range s.Tests {
+		result := test.run(executor, libraries)
+		if result.Name == "" {
+			result.Name = fmt.Sprintf("test #%d", i+1)
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// load reads and validates the executor and libraries, the same way as they
+// are read by other commands.
+func (s *Suite) load() (object.Executor, []object.Library, error) {
+	dir := filepath.Dir(s.filename)
+	executorFile, err := filepath.Abs(resolve(dir, s.Executor))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	b := &blueprint.Blueprint{
+		Mode: blueprint.BuildMode,
+		Preprocessors: []blueprint.Preprocessor{
+			schema.Validate,
+		},
+		DocumentPreprocessors: []blueprint.DocumentPreprocessor{
+			schema.MigrateDocument,
+		},
+	}
+	if _, err := os.Stat(executorFile); err != nil {
+		return nil, nil, err
+	}
+	err = b.Load(executorFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, glob := range s.Libraries {
+		err = b.Load(resolve(dir, glob))
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	err = b.Validate()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var executors []object.Executor
+	for _, kind := range []object.Kind{object.BuilderKind, object.DeployerKind, object.PusherKind, object.RunnerKind, object.TaggerKind} {
+		for _, obj := range b.GetObjectsByKind(kind) {
+			if e, ok := obj.(object.Executor); ok && obj.Metadata().Filename() == executorFile {
+				executors = append(executors, e)
+			}
+		}
+	}
+	if len(executors) != 1 {
+		return nil, nil, fmt.Errorf("expected exactly one executor in %s, found %d", executorFile, len(executors))
+	}
+
+	return executors[0], b.ListLibraries(), nil
+}
+
+func (t Test) run(executor object.Executor, libraries []object.Library) Result {
+	result := Result{Name: t.Name}
+	log := fakelogger.New()
+	fake := newFakeExec(t.Commands)
+
+	s := script.New(executor)
+	if t.Rollback {
+		s = script.NewRollback(executor)
+	}
+	s.Logger = log
+	s.Libraries = libraries
+	s.Exec = fake
+
+	results, err := s.Run(t.Input)
+	result.Logs = loggedLines(log)
+
+	result.Failures = append(result.Failures, fake.check()...)
+	if t.Error == "" && err != nil {
+		result.Failures = append(result.Failures, fmt.Sprintf("unexpected error: %s", err))
+	}
+	if t.Error != "" && err == nil {
+		result.Failures = append(result.Failures, fmt.Sprintf("expected error containing %q, but executor succeeded", t.Error))
+	}
+	if t.Error != "" && err != nil && !strings.Contains(err.Error(), t.Error) {
+		result.Failures = append(result.Failures, fmt.Sprintf("expected error containing %q, got: %s", t.Error, err))
+	}
+	if t.Results != nil && !matchResults(*t.Results, results) {
+		result.Failures = append(result.Failures, fmt.Sprintf("expected results %s, got %s", formatJSON(*t.Results), formatJSON(resultValues(results))))
+	}
+	if line, ok := findMissingLine(t.Logs, result.Logs); !ok {
+		result.Failures = append(result.Failures, fmt.Sprintf("expected line %q to be logged", line))
+	}
+
+	return result
+}
+
+// resolve returns path relative to the directory, unless it's absolute.
+func resolve(dir string, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(dir, path)
+}
+
+// loggedLines converts messages recorded by the logger to lines of text.
+func loggedLines(log *fakelogger.FakeLogger) (lines []string) {
+	for _, msg := range log.Messages {
+		var text string
+		switch msg.Method {
+		case "Print":
+			text = fmt.Sprint(msg.Args...)
+		case "Printf":
+			text = fmt.Sprintf(msg.Args[0].(string), msg.Args[1:]...)
+		case "Write":
+			text = string(msg.Args[0].([]byte))
+		}
+		text = strings.TrimSuffix(text, "\n")
+		lines = append(lines, strings.Split(text, "\n")...)
+	}
+	return lines
+}
+
+// findMissingLine checks whether expected lines were logged in the same
+// order, it returns the first line which wasn't found.
+func findMissingLine(expected []string, logged []string) (string, bool) {
+	i := 0
+	for _, line := range logged {
+		if i < len(expected) && line == expected[i] {
+			i++
+		}
+	}
+	if i < len(expected) {
+		return expected[i], false
+	}
+	return "", true
+}
+
+func matchResults(expected []interface{}, actual []script.Result) bool {
+	if len(expected) != len(actual) {
+		return false
+	}
+	for i := range expected {
+		if id, ok := expected[i].(string); ok {
+			if actual[i].ID != id {
+				return false
+			}
+			continue
+		}
+		if formatJSON(expected[i]) != formatJSON(actual[i].Map()) {
+			return false
+		}
+	}
+	return true
+}
+
+// resultValues returns results in the form used by test files, results
+// without metadata are represented by their identifiers.
+func resultValues(results []script.Result) []interface{} {
+	values := make([]interface{}, len(results))
+	for i, r := range results {
+		if len(r.Metadata) == 0 {
+			values[i] = r.ID
+		} else {
+			values[i] = r.Map()
+		}
+	}
+	return values
+}
+
+func formatJSON(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+func equal(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func formatList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = fmt.Sprintf("%q", v)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
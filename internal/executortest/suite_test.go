@@ -0,0 +1,83 @@
+package executortest
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_passing_test_has_no_failures(t *testing.T) {
+	results := runSuite(t, "testdata/deployer.test.yaml")
+
+	assert.Equal(t, "passing", results[0].Name)
+	assert.Empty(t, results[0].Failures)
+	assert.Contains(t, results[0].Logs, "Deploying app-api")
+}
+
+func Test_failing_test_reports_all_failures(t *testing.T) {
+	results := runSuite(t, "testdata/deployer.test.yaml")
+
+	assert.False(t, results[1].Passed())
+	assert.Equal(t, []string{
+		`unexpected command ["helm", "upgrade", "app-api"], expected ["helm", "install", "app-api"]`,
+		`expected command ["helm", "status", "app-api"] was not run`,
+		"unexpected error: Error occurred while running deployer \"test\":\n\tRuntime Error: unexpected command helm upgrade app-api\n\tat (main):7:1",
+		`expected results ["other-release"], got []`,
+		`expected line "missing line" to be logged`,
+	}, results[1].Failures)
+}
+
+func Test_rollback_test_runs_rollback_script(t *testing.T) {
+	results := runSuite(t, "testdata/deployer.test.yaml")
+
+	assert.Equal(t, "test #3", results[2].Name)
+	assert.Empty(t, results[2].Failures)
+}
+
+func Test_unexpected_stdin_is_reported(t *testing.T) {
+	suite, _ := Load("testdata/deployer.test.yaml")
+	other := "other"
+	suite.Tests[0].Commands[0].Stdin = &other
+
+	results, err := suite.Run()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{`command ["helm", "upgrade", "app-api"] received stdin "values", expected "other"`}, results[0].Failures)
+}
+
+func Test_loading_suite_with_missing_executor_fails(t *testing.T) {
+	suite, _ := Load("testdata/deployer.test.yaml")
+	suite.Executor = "missing.yaml"
+
+	_, err := suite.Run()
+
+	assert.Error(t, err)
+}
+
+func Test_loading_suite_with_unknown_fields_fails(t *testing.T) {
+	_, err := Load("testdata/invalid.test.yaml")
+
+	assert.Error(t, err)
+}
+
+func Test_bundled_executors_pass_their_tests(t *testing.T) {
+	files, _ := filepath.Glob("testdata/executors/*.test.yaml")
+	for _, file := range files {
+		for _, result := range runSuite(t, file) {
+			assert.Empty(t, result.Failures, "%s: %s", file, result.Name)
+		}
+	}
+}
+
+func runSuite(t *testing.T, filename string) []Result {
+	suite, err := Load(filename)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	results, err := suite.Run()
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	return results
+}
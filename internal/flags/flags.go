@@ -4,6 +4,9 @@ import (
 	"fmt"
 	"os"
 	"reflect"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/spf13/pflag"
 )
@@ -32,6 +35,11 @@ func ParseArgs(data interface{}, args []string) {
 			continue
 		}
 
+		if fv.Type() == reflect.TypeOf(time.Duration(0)) {
+			flagset.DurationVarP(fv.Addr().Interface().(*time.Duration), flag, alias, time.Duration(fv.Int()), help)
+			continue
+		}
+
 		switch fv.Kind() {
 		case reflect.String:
 			flagset.StringVarP(fv.Addr().Interface().(*string), flag, alias, fv.String(), help)
@@ -50,11 +58,37 @@ func ParseArgs(data interface{}, args []string) {
 
 	flagset.Parse(args)
 
+	// Positional arguments (the first one is the command itself)
+	positional := flagset.Args()
+	if len(positional) > 0 {
+		positional = positional[1:]
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		fv := v.Field(i)
+		ft := t.Field(i)
+
+		arg := ft.Tag.Get("arg")
+
+		if arg == "" {
+			continue
+		}
+
+		index, err := strconv.Atoi(arg)
+		if err != nil || fv.Kind() != reflect.String {
+			panic(fmt.Sprintf("unsupported positional argument %s", ft.Name))
+		}
+		if index < len(positional) {
+			fv.SetString(positional[index])
+		}
+	}
+
 	for i := 0; i < t.NumField(); i++ {
 		fv := v.Field(i)
 		ft := t.Field(i)
 
 		flag := ft.Tag.Get("flag")
+		arg := ft.Tag.Get("arg")
 		required := ft.Tag.Get("required")
 
 		if flag != "" && required == "true" {
@@ -63,6 +97,11 @@ func ParseArgs(data interface{}, args []string) {
 
 			}
 		}
+		if arg != "" && required == "true" {
+			if fv.IsZero() {
+				exitWithError(flagset, fmt.Errorf("missing required argument: %s", strings.ToLower(ft.Name)))
+			}
+		}
 	}
 }
 
@@ -0,0 +1,315 @@
+package migration
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/g2a-com/cicd/internal/schema"
+	"github.com/pmezard/go-difflib/difflib"
+	"gopkg.in/yaml.v3"
+)
+
+// Change describes modification of a single file. Empty OldPath means that
+// the file is created, file is renamed if OldPath differs from NewPath.
+type Change struct {
+	OldPath    string
+	NewPath    string
+	OldContent []byte
+	NewContent []byte
+}
+
+// Diff returns unified diff of the change.
+func (c Change) Diff() (string, error) {
+	fromFile, toFile := c.OldPath, c.NewPath
+	if fromFile == "" {
+		fromFile = "/dev/null"
+	}
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(c.OldContent)),
+		B:        difflib.SplitLines(string(c.NewContent)),
+		FromFile: fromFile,
+		ToFile:   toFile,
+		Context:  3,
+	})
+}
+
+// splitTargets maps kinds of documents which have to be moved out of the
+// project file to names of the files expected by the v2.0 layout.
+var splitTargets = map[string]string{
+	"Service":     "service.yaml",
+	"Environment": "environment.yaml",
+}
+
+// Plan lists changes required to migrate the project file and all files
+// referenced by it to the latest version. Files which are already up to date
+// are skipped.
+func Plan(projectFile string) ([]Change, error) {
+	var changes []Change
+
+	globs := []string{projectFile}
+	processed := map[string]bool{}
+	planned := map[string]bool{}
+
+	for i := 0; i < len(globs); i++ {
+		paths, err := filepath.Glob(globs[i])
+		if err != nil {
+			return nil, err
+		}
+
+		for _, p := range paths {
+			if processed[p] {
+				continue
+			}
+			processed[p] = true
+
+			buf, err := os.ReadFile(p)
+			if err != nil {
+				return nil, err
+			}
+
+			_, err = schema.Validate(buf)
+			if err != nil {
+				return nil, fmt.Errorf(`file "%s" contains invalid document: %s`, p, err)
+			}
+
+			legacy, err := isLegacy(buf)
+			if err != nil {
+				return nil, fmt.Errorf(`file "%s" contains invalid document: %s`, p, err)
+			}
+
+			content := buf
+			if legacy {
+				content, err = schema.Migrate(buf)
+				if err != nil {
+					return nil, fmt.Errorf(`cannot migrate file "%s": %s`, p, err)
+				}
+			}
+
+			docs, err := decodeDocuments(content)
+			if err != nil {
+				return nil, fmt.Errorf(`file "%s" contains invalid document: %s`, p, err)
+			}
+
+			project := findDocument(docs, "Project")
+			if project != nil {
+				for _, entry := range getFiles(project) {
+					globs = append(globs, path.Join(filepath.Dir(p), entry))
+				}
+			}
+
+			if !legacy {
+				continue
+			}
+
+			change := Change{OldPath: p, NewPath: p, OldContent: buf, NewContent: content}
+
+			if project != nil {
+				// Project file should contain only a project, other documents are
+				// moved to the files used by the v2.0 layout.
+				var split []Change
+				docs, split, err = splitProjectFile(p, project, docs, planned)
+				if err != nil {
+					return nil, err
+				}
+
+				// Legacy name of the project file is replaced with the default one.
+				if filepath.Base(p) == "g2a.yaml" {
+					change.NewPath = filepath.Join(filepath.Dir(p), "project.yaml")
+					if err := checkTarget(change.NewPath, planned); err != nil {
+						return nil, err
+					}
+				}
+
+				change.NewContent, err = encodeDocuments(docs)
+				if err != nil {
+					return nil, err
+				}
+
+				changes = append(changes, split...)
+			}
+
+			planned[change.NewPath] = true
+			changes = append(changes, change)
+		}
+	}
+
+	return changes, nil
+}
+
+// Apply writes changes to the disk.
+func Apply(changes []Change) error {
+	for _, c := range changes {
+		mode := os.FileMode(0644)
+		if c.OldPath != "" {
+			if info, err := os.Stat(c.OldPath); err == nil {
+				mode = info.Mode()
+			}
+		}
+		err := os.WriteFile(c.NewPath, c.NewContent, mode)
+		if err != nil {
+			return err
+		}
+	}
+	for _, c := range changes {
+		if c.OldPath != "" && c.OldPath != c.NewPath {
+			err := os.Remove(c.OldPath)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// splitProjectFile removes services and environments from documents of the
+// project file and returns changes creating files for them.
+func splitProjectFile(filename string, project *yaml.Node, docs []*yaml.Node, planned map[string]bool) ([]*yaml.Node, []Change, error) {
+	var kept []*yaml.Node
+	moved := map[string][]*yaml.Node{}
+
+	for _, doc := range docs {
+		target, ok := splitTargets[getKind(doc)]
+		if ok {
+			moved[target] = append(moved[target], doc)
+		} else {
+			kept = append(kept, doc)
+		}
+	}
+
+	var changes []Change
+	for _, target := range []string{"service.yaml", "environment.yaml"} {
+		if len(moved[target]) == 0 {
+			continue
+		}
+
+		p := filepath.Join(filepath.Dir(filename), target)
+		if err := checkTarget(p, planned); err != nil {
+			return nil, nil, fmt.Errorf("cannot move documents out of the project file: %s", err)
+		}
+		planned[p] = true
+
+		content, err := encodeDocuments(moved[target])
+		if err != nil {
+			return nil, nil, err
+		}
+		changes = append(changes, Change{NewPath: p, NewContent: content})
+
+		addFile(project, target)
+	}
+
+	return kept, changes, nil
+}
+
+func checkTarget(p string, planned map[string]bool) error {
+	if _, err := os.Stat(p); err == nil || planned[p] {
+		return fmt.Errorf(`file "%s" already exists`, p)
+	}
+	return nil
+}
+
+func isLegacy(input []byte) (bool, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(input))
+	for {
+		var doc struct {
+			APIVersion string `yaml:"apiVersion"`
+		}
+		err := decoder.Decode(&doc)
+		if err == io.EOF {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		if doc.APIVersion != schema.LatestVersion {
+			return true, nil
+		}
+	}
+}
+
+func decodeDocuments(input []byte) (docs []*yaml.Node, err error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(input))
+	for {
+		doc := &yaml.Node{}
+		err := decoder.Decode(doc)
+		if err == io.EOF {
+			return docs, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, doc)
+	}
+}
+
+func encodeDocuments(docs []*yaml.Node) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	encoder := schema.NewEncoder(buf)
+	for _, doc := range docs {
+		err := encoder.Encode(doc)
+		if err != nil {
+			return nil, err
+		}
+	}
+	err := encoder.Close()
+	return buf.Bytes(), err
+}
+
+func findDocument(docs []*yaml.Node, kind string) *yaml.Node {
+	for _, doc := range docs {
+		if getKind(doc) == kind {
+			return doc
+		}
+	}
+	return nil
+}
+
+func getKind(doc *yaml.Node) string {
+	if value := getMapValue(doc, "kind"); value != nil {
+		return value.Value
+	}
+	return ""
+}
+
+func getFiles(project *yaml.Node) (files []string) {
+	if value := getMapValue(project, "files"); value != nil {
+		for _, node := range value.Content {
+			files = append(files, node.Value)
+		}
+	}
+	return files
+}
+
+// addFile adds entry to the "files" property of the project, unless it's
+// already matched by one of existing entries.
+func addFile(project *yaml.Node, name string) {
+	value := getMapValue(project, "files")
+	if value == nil {
+		return
+	}
+	for _, node := range value.Content {
+		if ok, _ := path.Match(strings.TrimPrefix(node.Value, "./"), name); ok {
+			return
+		}
+	}
+	value.Content = append(value.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: name})
+}
+
+func getMapValue(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
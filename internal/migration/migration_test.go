@@ -0,0 +1,139 @@
+package migration
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeTestFiles(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for name, content := range files {
+		err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func Test_planning_migration_of_up_to_date_project_returns_no_changes(t *testing.T) {
+	dir := writeTestFiles(t, map[string]string{
+		"project.yaml": "{ apiVersion: g2a-cli/v2.0, kind: Project, name: test, files: [service.yaml] }",
+		"service.yaml": "{ apiVersion: g2a-cli/v2.0, kind: Service, name: test }",
+	})
+
+	changes, err := Plan(filepath.Join(dir, "project.yaml"))
+
+	assert.NoError(t, err)
+	assert.Empty(t, changes)
+}
+
+func Test_planning_migration_follows_files_referenced_by_project(t *testing.T) {
+	dir := writeTestFiles(t, map[string]string{
+		"project.yaml": "{ apiVersion: g2a-cli/v1beta4, kind: Project, name: test, services: [.], environments: [] }",
+		"service.yaml": "{ apiVersion: g2a-cli/v1beta4, kind: Service, name: test }",
+	})
+
+	changes, err := Plan(filepath.Join(dir, "project.yaml"))
+
+	assert.NoError(t, err)
+	if assert.Len(t, changes, 2) {
+		assert.Equal(t, filepath.Join(dir, "project.yaml"), changes[0].NewPath)
+		assert.Equal(t, filepath.Join(dir, "service.yaml"), changes[1].NewPath)
+		assert.Contains(t, string(changes[1].NewContent), "apiVersion: g2a-cli/v2.0")
+	}
+}
+
+func Test_planning_migration_renames_legacy_project_file(t *testing.T) {
+	dir := writeTestFiles(t, map[string]string{
+		"g2a.yaml": "{ apiVersion: g2a-cli/v1beta4, kind: Project, name: test, services: [], environments: [] }",
+	})
+
+	changes, err := Plan(filepath.Join(dir, "g2a.yaml"))
+
+	assert.NoError(t, err)
+	if assert.Len(t, changes, 1) {
+		assert.Equal(t, filepath.Join(dir, "g2a.yaml"), changes[0].OldPath)
+		assert.Equal(t, filepath.Join(dir, "project.yaml"), changes[0].NewPath)
+	}
+}
+
+func Test_planning_migration_moves_services_out_of_project_file(t *testing.T) {
+	dir := writeTestFiles(t, map[string]string{
+		"project.yaml": "{ apiVersion: g2a-cli/v1beta4, kind: Project, name: test, services: [], environments: [] }\n---\n" +
+			"{ apiVersion: g2a-cli/v1beta4, kind: Service, name: test }",
+	})
+
+	changes, err := Plan(filepath.Join(dir, "project.yaml"))
+
+	assert.NoError(t, err)
+	if assert.Len(t, changes, 2) {
+		assert.Equal(t, "", changes[0].OldPath)
+		assert.Equal(t, filepath.Join(dir, "service.yaml"), changes[0].NewPath)
+		assert.Contains(t, string(changes[0].NewContent), "kind: Service")
+		assert.NotContains(t, string(changes[1].NewContent), "kind: Service")
+		assert.Contains(t, string(changes[1].NewContent), "files: [service.yaml]")
+	}
+}
+
+func Test_planning_migration_fails_when_split_would_overwrite_file(t *testing.T) {
+	dir := writeTestFiles(t, map[string]string{
+		"project.yaml": "{ apiVersion: g2a-cli/v1beta4, kind: Project, name: test, services: [], environments: [] }\n---\n" +
+			"{ apiVersion: g2a-cli/v1beta4, kind: Service, name: test }",
+		"service.yaml": "{ apiVersion: g2a-cli/v2.0, kind: Service, name: other }",
+	})
+
+	_, err := Plan(filepath.Join(dir, "project.yaml"))
+
+	assert.Error(t, err)
+}
+
+func Test_applying_changes_writes_and_renames_files(t *testing.T) {
+	dir := writeTestFiles(t, map[string]string{
+		"g2a.yaml": "old",
+	})
+
+	err := Apply([]Change{
+		{OldPath: filepath.Join(dir, "g2a.yaml"), NewPath: filepath.Join(dir, "project.yaml"), OldContent: []byte("old"), NewContent: []byte("new")},
+		{NewPath: filepath.Join(dir, "service.yaml"), NewContent: []byte("created")},
+	})
+
+	assert.NoError(t, err)
+	assert.NoFileExists(t, filepath.Join(dir, "g2a.yaml"))
+	content, _ := os.ReadFile(filepath.Join(dir, "project.yaml"))
+	assert.Equal(t, "new", string(content))
+	content, _ = os.ReadFile(filepath.Join(dir, "service.yaml"))
+	assert.Equal(t, "created", string(content))
+}
+
+func Test_diff_of_created_file_uses_dev_null(t *testing.T) {
+	diff, err := Change{NewPath: "service.yaml", NewContent: []byte("foo\n")}.Diff()
+
+	assert.NoError(t, err)
+	assert.Contains(t, diff, "--- /dev/null\n+++ service.yaml\n")
+	assert.Contains(t, diff, "+foo\n")
+}
+
+func Test_planning_migration_formats_split_and_migrated_files_the_same_way(t *testing.T) {
+	dir := writeTestFiles(t, map[string]string{
+		"project.yaml": "apiVersion: g2a-cli/v1beta4\nkind: Project\nname: test\nservices: []\nenvironments:\n  - other\n---\napiVersion: g2a-cli/v1beta4\nkind: Environment\nname: dev\nvariables:\n  A: a\n",
+	})
+	other := writeTestFiles(t, map[string]string{
+		"environment.yaml": "apiVersion: g2a-cli/v1beta4\nkind: Environment\nname: prod\nvariables:\n  A: a\n",
+	})
+	assert.NoError(t, os.Rename(other, filepath.Join(dir, "other")))
+
+	changes, err := Plan(filepath.Join(dir, "project.yaml"))
+
+	assert.NoError(t, err)
+	if assert.Len(t, changes, 3) {
+		split := strings.Replace(string(changes[0].NewContent), "name: dev", "name: prod", 1)
+		assert.Equal(t, filepath.Join(dir, "environment.yaml"), changes[0].NewPath)
+		assert.Equal(t, filepath.Join(dir, "other", "environment.yaml"), changes[2].NewPath)
+		assert.Equal(t, string(changes[2].NewContent), split)
+	}
+}
@@ -1,12 +1,10 @@
 package object
 
 import (
-	"context"
 	"fmt"
-	"strings"
+	"time"
 
 	"github.com/g2a-com/cicd/internal/placeholders"
-	"github.com/hashicorp/go-multierror"
 	"gopkg.in/yaml.v3"
 )
 
@@ -22,7 +20,7 @@ type buildService struct {
 	}
 }
 
-var _ Object = buildService{}
+var _ Service = buildService{}
 
 func NewBuildService(filename string, data *yaml.Node) (Object, error) {
 	service := buildService{}
@@ -56,9 +54,11 @@ type buildServiceEntry struct {
 	executorKind Kind
 	service      Object
 	Data         struct {
-		Index int
-		Type  string
-		Spec  interface{}
+		Index   int
+		Type    string
+		Spec    interface{}
+		Timeout string
+		Push    bool
 	} `mapstructure:",squash"`
 }
 
@@ -74,41 +74,22 @@ func (e *buildServiceEntry) ExecutorName() string {
 	return e.Data.Type
 }
 
-func (e *buildServiceEntry) Validate(objects ObjectCollection) error {
-	spec, err := e.spec(objects)
-	if err != nil {
-		return err
-	}
-
-	obj := objects.GetObject(e.ExecutorKind(), e.ExecutorName())
+func (e *buildServiceEntry) Timeout() time.Duration {
+	return parseTimeout(e.Data.Timeout)
+}
 
-	if obj == nil {
-		return fmt.Errorf(
-			"missing %s %q used by service %q defined in the file:\n\t  %s",
-			strings.ToLower(string(e.ExecutorKind())), e.ExecutorName(), e.service.Name(), e.service.Metadata(),
-		)
-	}
+func (e *buildServiceEntry) Validate(objects ObjectCollection) error {
+	return validateEntry(objects, e.service, e.pointer(), e, e.spec)
+}
 
-	executor, ok := obj.(Executor)
-	if !ok {
-		panic("not an executor")
+func (e *buildServiceEntry) pointer() string {
+	if e.executorKind == TaggerKind {
+		return fmt.Sprintf("/tags/%d", e.Data.Index)
 	}
-
-	schema := executor.Schema()
-	result := schema.Validate(context.Background(), spec)
-
-	if len(*result.Errs) > 0 {
-		var err error
-		for _, msg := range *result.Errs {
-			err = multierror.Append(err, fmt.Errorf(
-				"%s contains invalid configuration for %s:\n\t  %s\n\t  Definition files:\n\t    %s\n\t    %s",
-				e.service.DisplayName(), executor.DisplayName(), msg, e.service.Metadata(), executor.Metadata(),
-			))
-		}
-		return err
+	if e.executorKind == PusherKind && e.Data.Push {
+		return fmt.Sprintf("/artifacts/%d/push", e.Data.Index)
 	}
-
-	return nil
+	return fmt.Sprintf("/artifacts/%d", e.Data.Index)
 }
 
 func (e *buildServiceEntry) Spec(objects ObjectCollection) interface{} {
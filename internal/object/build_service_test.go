@@ -132,6 +132,40 @@ func Test_validating_build_service_using_unknown_pusher_fails(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func Test_validation_error_for_pusher_defined_in_push_property_points_to_the_pusher(t *testing.T) {
+	collection := fakeCollection{
+		fakeObject{kind: ProjectKind},
+		fakeObject{kind: OptionsKind},
+		fakeObject{kind: BuilderKind, name: "builder", schema: "{}"},
+		fakeObject{kind: PusherKind, name: "pusher", schema: `{ "required": ["foo"] }`},
+	}
+	input := prepareTestInput("apiVersion: g2a-cli/v2.0\nkind: Service\nname: test\nartifacts:\n  - builder: {}\n    push:\n      pusher:\n        bar: 1\n")
+
+	service, _ := NewBuildService("dir/file.yaml", input)
+	err := service.Validate(collection)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "/artifacts/0/push/pusher: ")
+	assert.Contains(t, err.Error(), "at dir/file.yaml:7")
+}
+
+func Test_validation_error_for_pusher_sharing_definition_with_builder_points_to_the_artifact(t *testing.T) {
+	collection := fakeCollection{
+		fakeObject{kind: ProjectKind},
+		fakeObject{kind: OptionsKind},
+		fakeObject{kind: BuilderKind, name: "type", schema: "{}"},
+		fakeObject{kind: PusherKind, name: "type", schema: `{ "required": ["foo"] }`},
+	}
+	input := prepareTestInput("apiVersion: g2a-cli/v2.0\nkind: Service\nname: test\nartifacts:\n  - type:\n      bar: 1\n")
+
+	service, _ := NewBuildService("dir/file.yaml", input)
+	err := service.Validate(collection)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "/artifacts/0/type: ")
+	assert.Contains(t, err.Error(), "at dir/file.yaml:5")
+}
+
 func Test_validating_build_service_using_known_pusher_passes(t *testing.T) {
 	collection := fakeCollection{
 		fakeObject{kind: ProjectKind},
@@ -1,12 +1,10 @@
 package object
 
 import (
-	"context"
 	"fmt"
-	"strings"
+	"time"
 
 	"github.com/g2a-com/cicd/internal/placeholders"
-	"github.com/hashicorp/go-multierror"
 	"gopkg.in/yaml.v3"
 )
 
@@ -16,9 +14,17 @@ type deployService struct {
 	Deploy struct {
 		Releases []*deployServiceEntry
 	}
+	// Build entries aren't run during deployment, only their indexes are kept
+	// to validate results of the build
+	Build struct {
+		Tags      []struct{ Index int }
+		Artifacts struct {
+			ToPush []struct{ Index int }
+		}
+	}
 }
 
-var _ Object = deployService{}
+var _ Service = deployService{}
 
 func NewDeployService(filename string, data *yaml.Node) (Object, error) {
 	service := deployService{}
@@ -36,13 +42,31 @@ func NewDeployService(filename string, data *yaml.Node) (Object, error) {
 	return service, err
 }
 
+// BuildEntryIndexes returns indexes of tag or push entries of the service.
+func (s deployService) BuildEntryIndexes(entryType string) []int {
+	var entries []struct{ Index int }
+	switch entryType {
+	case TagEntryType:
+		entries = s.Build.Tags
+	case PushEntryType:
+		entries = s.Build.Artifacts.ToPush
+	}
+
+	indexes := make([]int, len(entries))
+	for i, e := range entries {
+		indexes[i] = e.Index
+	}
+	return indexes
+}
+
 type deployServiceEntry struct {
 	executorKind Kind
 	service      Object
 	Data         struct {
-		Index int
-		Type  string
-		Spec  interface{}
+		Index   int
+		Type    string
+		Spec    interface{}
+		Timeout string
 	} `mapstructure:",squash"`
 }
 
@@ -58,41 +82,16 @@ func (e *deployServiceEntry) ExecutorName() string {
 	return e.Data.Type
 }
 
-func (e *deployServiceEntry) Validate(objects ObjectCollection) error {
-	spec, err := e.spec(objects)
-	if err != nil {
-		return err
-	}
-
-	obj := objects.GetObject(e.ExecutorKind(), e.ExecutorName())
-
-	if obj == nil {
-		return fmt.Errorf(
-			"missing %s %q used by service %q defined in the file:\n\t  %s",
-			strings.ToLower(string(e.ExecutorKind())), e.ExecutorName(), e.service.Name(), e.service.Metadata(),
-		)
-	}
-
-	executor, ok := obj.(Executor)
-	if !ok {
-		panic("not an executor")
-	}
-
-	schema := executor.Schema()
-	result := schema.Validate(context.Background(), spec)
+func (e *deployServiceEntry) Timeout() time.Duration {
+	return parseTimeout(e.Data.Timeout)
+}
 
-	if len(*result.Errs) > 0 {
-		var err error
-		for _, msg := range *result.Errs {
-			err = multierror.Append(err, fmt.Errorf(
-				"%s contains invalid configuration for %s:\n\t  %s\n\t  Definition files:\n\t    %s\n\t    %s",
-				e.service.DisplayName(), executor.DisplayName(), msg, e.service.Metadata(), executor.Metadata(),
-			))
-		}
-		return err
-	}
+func (e *deployServiceEntry) Validate(objects ObjectCollection) error {
+	return validateEntry(objects, e.service, e.pointer(), e, e.spec)
+}
 
-	return nil
+func (e *deployServiceEntry) pointer() string {
+	return fmt.Sprintf("/releases/%d", e.Data.Index)
 }
 
 func (e *deployServiceEntry) Spec(objects ObjectCollection) interface{} {
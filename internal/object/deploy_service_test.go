@@ -23,6 +23,22 @@ func Test_unmarshalling_empty_deploy_service(t *testing.T) {
 	assert.Equal(t, `service "test"`, result.DisplayName())
 }
 
+func Test_deploy_service_returns_indexes_of_build_entries(t *testing.T) {
+	input := prepareTestInput(`{
+		apiVersion: g2a-cli/v2.0, kind: Service, name: test,
+		tags: [ { first: {} }, { second: {} } ],
+		artifacts: [ { docker: {} }, { docker: {}, push: false }, { docker: {} } ],
+	}`)
+
+	service, err := NewDeployService("dir/file.yaml", input)
+
+	assert.NoError(t, err)
+	s := service.(deployService)
+	assert.Equal(t, []int{0, 1}, s.BuildEntryIndexes(TagEntryType))
+	assert.Equal(t, []int{0, 2}, s.BuildEntryIndexes(PushEntryType))
+	assert.Equal(t, []int{}, s.BuildEntryIndexes(DeployEntryType))
+}
+
 func Test_validating_empty_deploy_service_passes(t *testing.T) {
 	collection := fakeCollection{
 		fakeObject{kind: ProjectKind},
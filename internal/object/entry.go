@@ -1,5 +1,15 @@
 package object
 
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/g2a-com/cicd/internal/schema"
+	"github.com/hashicorp/go-multierror"
+)
+
 const (
 	TagEntryType    = "tag"
 	BuildEntryType  = "build"
@@ -11,6 +21,60 @@ type Entry interface {
 	Index() int
 	ExecutorKind() Kind
 	ExecutorName() string
+	// Timeout returns time limit for running the entry, 0 means that entry
+	// doesn't define it.
+	Timeout() time.Duration
 	Spec(ObjectCollection) interface{}
 	Validate(ObjectCollection) error
 }
+
+// validateEntry checks whether executor used by the entry exists and whether
+// spec of the entry matches its schema. Returned errors point to the entry
+// (identified by the JSON pointer) within the owner's definition file.
+func validateEntry(objects ObjectCollection, owner Object, pointer string, entry Entry, spec func(ObjectCollection) (interface{}, error)) error {
+	value, err := spec(objects)
+	if err != nil {
+		return NewValidationError(owner.Metadata(), pointer, "%s", err)
+	}
+
+	obj := objects.GetObject(entry.ExecutorKind(), entry.ExecutorName())
+	if obj == nil {
+		return NewValidationError(
+			owner.Metadata(), pointer, "missing %s %q used by %s",
+			strings.ToLower(string(entry.ExecutorKind())), entry.ExecutorName(), owner.DisplayName(),
+		)
+	}
+
+	executor, ok := obj.(Executor)
+	if !ok {
+		panic("not an executor")
+	}
+
+	result := executor.Schema().Validate(context.Background(), value)
+	for _, e := range *result.Errs {
+		p := fmt.Sprintf("%s/%s", pointer, escapePointerToken(entry.ExecutorName()))
+		if e.PropertyPath != "/" {
+			p += e.PropertyPath
+		}
+		err = multierror.Append(err, NewValidationError(
+			owner.Metadata(), p, "%s contains invalid configuration for %s (defined in %s): %s",
+			owner.DisplayName(), executor.DisplayName(), executor.Metadata(), schema.KeyErrorMessage(e),
+		))
+	}
+
+	return err
+}
+
+// parseTimeout parses durations used in configuration files. Their format is
+// validated by schemas, so invalid values are treated as missing.
+func parseTimeout(value string) time.Duration {
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0
+	}
+	return d
+}
+
+func escapePointerToken(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
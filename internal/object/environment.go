@@ -9,24 +9,34 @@ import (
 
 type environment struct {
 	GenericObject
+	secrets
 
 	DeployServices []string
 	Variables      map[string]string
+	SecretRefs     map[string]secretRef `mapstructure:"secrets"`
 }
 
 var _ Object = environment{}
+var _ SecretsHolder = environment{}
 
 func NewEnvironment(filename string, data *yaml.Node) (Object, error) {
 	e := environment{}
 	e.GenericObject.metadata = NewMetadata(filename, data)
 	err := decode(data, &e)
+	if err == nil {
+		e.secrets = readSecrets(e.Metadata(), e.SecretRefs)
+	}
 	return e, err
 }
 
 func (e environment) Validate(c ObjectCollection) (err error) {
-	for _, name := range e.DeployServices {
+	if secretsErr := e.secrets.validate(); secretsErr != nil {
+		err = multierror.Append(err, secretsErr)
+	}
+
+	for i, name := range e.DeployServices {
 		if c.GetObject(ServiceKind, name) == nil {
-			err = multierror.Append(err, fmt.Errorf("missing service %q deployed to environment %q defined in the file:\n\t  %s", name, e.Name(), e.Metadata().Filename()))
+			err = multierror.Append(err, NewValidationError(e.Metadata(), fmt.Sprintf("/deployServices/%d", i), "missing service %q deployed to environment %q", name, e.Name()))
 		}
 	}
 	return
@@ -34,8 +44,9 @@ func (e environment) Validate(c ObjectCollection) (err error) {
 
 func (e environment) PlaceholderValues() map[string]interface{} {
 	return map[string]interface{}{
-		"Environment.Name": e.Name(),
-		"Environment.Dir":  e.Directory(),
-		"Environment.Vars": e.Variables,
+		"Environment.Name":    e.Name(),
+		"Environment.Dir":     e.Directory(),
+		"Environment.Vars":    e.Variables,
+		"Environment.Secrets": e.secrets.values,
 	}
 }
@@ -1,6 +1,8 @@
 package object
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -70,3 +72,59 @@ func Test_validating_environment_with_deploy_services_containing_unknown_service
 
 	assert.Error(t, err)
 }
+
+func Test_environment_secrets_are_read_from_env_variables_and_files(t *testing.T) {
+	dir := t.TempDir()
+	_ = os.WriteFile(filepath.Join(dir, "token"), []byte("file secret\n"), 0644)
+	t.Setenv("TEST_SECRET", "env secret")
+	input := prepareTestInput(`{
+		apiVersion: g2a-cli/v2.0,
+		kind: Environment,
+		name: test,
+		secrets: {
+			fromEnv: { env: TEST_SECRET },
+			fromFile: { file: token },
+		},
+	}`)
+
+	environment, _ := NewEnvironment(filepath.Join(dir, "file.yaml"), input)
+	secrets, err := environment.(SecretsHolder).Secrets()
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"fromEnv": "env secret", "fromFile": "file secret"}, secrets)
+	assert.Equal(t, secrets, environment.PlaceholderValues()["Environment.Secrets"])
+}
+
+func Test_reading_environment_secrets_fails_when_sources_are_missing(t *testing.T) {
+	input := prepareTestInput(`{
+		apiVersion: g2a-cli/v2.0,
+		kind: Environment,
+		name: test,
+		secrets: {
+			fromEnv: { env: TEST_MISSING_SECRET },
+			fromFile: { file: missing },
+		},
+	}`)
+
+	environment, err := NewEnvironment(filepath.Join(t.TempDir(), "file.yaml"), input)
+	_, secretsErr := environment.(SecretsHolder).Secrets()
+
+	assert.NoError(t, err)
+	assert.Error(t, secretsErr)
+	assert.Contains(t, secretsErr.Error(), `/secrets/fromEnv: cannot read secret "fromEnv", environment variable TEST_MISSING_SECRET is not set`)
+	assert.Contains(t, secretsErr.Error(), `/secrets/fromFile: cannot read secret "fromFile"`)
+}
+
+func Test_validating_environment_doesnt_require_secret_values(t *testing.T) {
+	input := prepareTestInput(`{
+		apiVersion: g2a-cli/v2.0,
+		kind: Environment,
+		name: test,
+		secrets: { fromEnv: { env: TEST_MISSING_SECRET } },
+	}`)
+
+	environment, _ := NewEnvironment("dir/file.yaml", input)
+	err := environment.Validate(fakeCollection{environment})
+
+	assert.NoError(t, err)
+}
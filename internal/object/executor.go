@@ -9,15 +9,27 @@ type Executor interface {
 	Object
 	Schema() *jsonschema.Schema
 	Script() string
+	RollbackScript() string
+	AllowOutsideProject() bool
+	// Commands returns commands declared by the executor, declared is false
+	// if the executor doesn't declare them.
+	Commands() (commands []string, declared bool)
+	// Command returns external program implementing the executor followed by
+	// its arguments. It's empty for executors implemented by scripts.
+	Command() []string
 }
 
 type executor struct {
 	GenericObject
 
 	Data struct {
-		Script string
+		Schema              jsonschema.Schema
+		Script              string
+		Rollback            string
+		AllowOutsideProject bool
+		Commands            *[]string
+		Command             []string
 	} `mapstructure:",squash"`
-	schema jsonschema.Schema
 }
 
 var _ Executor = executor{}
@@ -30,9 +42,28 @@ func NewExecutor(filename string, data *yaml.Node) (Executor, error) {
 }
 
 func (e executor) Schema() *jsonschema.Schema {
-	return &e.schema
+	return &e.Data.Schema
 }
 
 func (e executor) Script() string {
 	return e.Data.Script
 }
+
+func (e executor) RollbackScript() string {
+	return e.Data.Rollback
+}
+
+func (e executor) AllowOutsideProject() bool {
+	return e.Data.AllowOutsideProject
+}
+
+func (e executor) Commands() ([]string, bool) {
+	if e.Data.Commands == nil {
+		return nil, false
+	}
+	return *e.Data.Commands, true
+}
+
+func (e executor) Command() []string {
+	return e.Data.Command
+}
@@ -0,0 +1,60 @@
+package object
+
+// ExecutorPolicy restricts commands which executors can run using the "exec"
+// module.
+type ExecutorPolicy struct {
+	// RequireCommands disallows running commands by executors which don't
+	// declare them.
+	RequireCommands bool
+	// AllowedCommands lists commands which can be run by executors, nil
+	// allows all commands.
+	AllowedCommands *[]string
+	// ApprovedExecutors lists executors which aren't restricted by the
+	// policy.
+	ApprovedExecutors []ExecutorRef
+}
+
+// ExecutorRef identifies an executor.
+type ExecutorRef struct {
+	Kind Kind
+	Name string
+}
+
+// CommandsFor returns commands which the executor is allowed to run,
+// restricted is false if it can run any command. Policy may be nil, in which
+// case only commands declared by the executor are taken into account.
+func (p *ExecutorPolicy) CommandsFor(e Executor) (commands []string, restricted bool) {
+	declared, ok := e.Commands()
+	if p == nil || p.isApproved(e) {
+		return declared, ok
+	}
+
+	switch {
+	case !ok && p.RequireCommands:
+		return []string{}, true
+	case p.AllowedCommands == nil:
+		return declared, ok
+	case !ok:
+		return *p.AllowedCommands, true
+	}
+
+	commands = []string{}
+	for _, cmd := range declared {
+		for _, allowed := range *p.AllowedCommands {
+			if cmd == allowed {
+				commands = append(commands, cmd)
+				break
+			}
+		}
+	}
+	return commands, true
+}
+
+func (p *ExecutorPolicy) isApproved(e Executor) bool {
+	for _, ref := range p.ApprovedExecutors {
+		if ref.Kind == e.Kind() && ref.Name == e.Name() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,67 @@
+package object
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_executor_without_policy_can_run_commands_it_declares(t *testing.T) {
+	var policy *ExecutorPolicy
+
+	commands, restricted := policy.CommandsFor(fakeObject{commands: &[]string{"docker"}})
+
+	assert.True(t, restricted)
+	assert.Equal(t, []string{"docker"}, commands)
+}
+
+func Test_executor_without_policy_and_declared_commands_can_run_any_command(t *testing.T) {
+	var policy *ExecutorPolicy
+
+	_, restricted := policy.CommandsFor(fakeObject{})
+
+	assert.False(t, restricted)
+}
+
+func Test_executor_without_declared_commands_cannot_run_commands_when_policy_requires_them(t *testing.T) {
+	policy := &ExecutorPolicy{RequireCommands: true}
+
+	commands, restricted := policy.CommandsFor(fakeObject{})
+
+	assert.True(t, restricted)
+	assert.Empty(t, commands)
+}
+
+func Test_executor_without_declared_commands_can_run_commands_allowed_by_policy(t *testing.T) {
+	policy := &ExecutorPolicy{AllowedCommands: &[]string{"git"}}
+
+	commands, restricted := policy.CommandsFor(fakeObject{})
+
+	assert.True(t, restricted)
+	assert.Equal(t, []string{"git"}, commands)
+}
+
+func Test_executor_can_run_declared_commands_which_are_allowed_by_policy(t *testing.T) {
+	policy := &ExecutorPolicy{AllowedCommands: &[]string{"git", "docker"}}
+
+	commands, restricted := policy.CommandsFor(fakeObject{commands: &[]string{"docker", "curl"}})
+
+	assert.True(t, restricted)
+	assert.Equal(t, []string{"docker"}, commands)
+}
+
+func Test_approved_executor_is_not_restricted_by_policy(t *testing.T) {
+	policy := &ExecutorPolicy{
+		RequireCommands:   true,
+		AllowedCommands:   &[]string{},
+		ApprovedExecutors: []ExecutorRef{{Kind: DeployerKind, Name: "helm3"}},
+	}
+
+	_, restricted := policy.CommandsFor(fakeObject{kind: DeployerKind, name: "helm3"})
+	commands, _ := policy.CommandsFor(fakeObject{kind: DeployerKind, name: "helm3", commands: &[]string{"helm"}})
+	_, otherRestricted := policy.CommandsFor(fakeObject{kind: BuilderKind, name: "helm3"})
+
+	assert.False(t, restricted)
+	assert.Equal(t, []string{"helm"}, commands)
+	assert.True(t, otherRestricted)
+}
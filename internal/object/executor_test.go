@@ -38,3 +38,81 @@ func Test_validating_empty_executor_passes(t *testing.T) {
 
 	assert.NoError(t, err)
 }
+
+func Test_unmarshalling_deployer_with_rollback_script(t *testing.T) {
+	input := prepareTestInput(`{
+		apiVersion: g2a-cli/v2.0,
+		kind: Deployer,
+		name: test,
+		script: "deploy",
+		rollback: "rollback",
+	}`)
+
+	result, err := NewExecutor("dir/file.yaml", input)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "deploy", result.Script())
+	assert.Equal(t, "rollback", result.RollbackScript())
+}
+
+func Test_unmarshalling_executor_allowed_to_access_files_outside_of_project(t *testing.T) {
+	input := prepareTestInput(`{
+		apiVersion: g2a-cli/v2.0,
+		kind: Builder,
+		name: test,
+		script: "",
+		allowOutsideProject: true,
+	}`)
+
+	result, err := NewExecutor("dir/file.yaml", input)
+
+	assert.NoError(t, err)
+	assert.True(t, result.AllowOutsideProject())
+}
+
+func Test_unmarshalling_executor_declaring_commands(t *testing.T) {
+	input := prepareTestInput(`{
+		apiVersion: g2a-cli/v2.0,
+		kind: Builder,
+		name: test,
+		script: "",
+		commands: [docker, git],
+	}`)
+
+	result, err := NewExecutor("dir/file.yaml", input)
+	commands, declared := result.Commands()
+
+	assert.NoError(t, err)
+	assert.True(t, declared)
+	assert.Equal(t, []string{"docker", "git"}, commands)
+}
+
+func Test_unmarshalling_executor_without_commands(t *testing.T) {
+	input := prepareTestInput(`{
+		apiVersion: g2a-cli/v2.0,
+		kind: Builder,
+		name: test,
+		script: "",
+	}`)
+
+	result, err := NewExecutor("dir/file.yaml", input)
+	_, declared := result.Commands()
+
+	assert.NoError(t, err)
+	assert.False(t, declared)
+}
+
+func Test_unmarshalling_executor_with_command(t *testing.T) {
+	input := prepareTestInput(`{
+		apiVersion: g2a-cli/v2.0,
+		kind: Deployer,
+		name: test,
+		command: [./deployer.py, --verbose],
+	}`)
+
+	result, err := NewExecutor("dir/file.yaml", input)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "", result.Script())
+	assert.Equal(t, []string{"./deployer.py", "--verbose"}, result.Command())
+}
@@ -16,12 +16,17 @@ type fakeObject struct {
 	displayName       string
 	schema            string
 	script            string
+	rollbackScript    string
+	allowOutside      bool
+	commands          *[]string
+	command           []string
 	entryTypes        []string
 	entries           []Entry
 	placeholderValues map[string]interface{}
 }
 
 var _ Object = fakeObject{}
+var _ Executor = fakeObject{}
 
 func (o fakeObject) Name() string {
 	return o.name
@@ -58,6 +63,25 @@ func (o fakeObject) Script() string {
 	return o.script
 }
 
+func (o fakeObject) RollbackScript() string {
+	return o.rollbackScript
+}
+
+func (o fakeObject) AllowOutsideProject() bool {
+	return o.allowOutside
+}
+
+func (o fakeObject) Commands() ([]string, bool) {
+	if o.commands == nil {
+		return nil, false
+	}
+	return *o.commands, true
+}
+
+func (o fakeObject) Command() []string {
+	return o.command
+}
+
 func (o fakeObject) EntryTypes() []string {
 	return o.entryTypes
 }
@@ -19,20 +19,32 @@ func toInternal(obj interface{}) interface{} {
 		return toInternalService(obj)
 	case "Environment":
 		return toInternalEnvironment(obj)
-	case "Builder", "Deployer", "Pusher", "Tagger":
+	case "Builder", "Deployer", "Pusher", "Runner", "Tagger":
 		return toInternalExecutor(obj)
+	case "Library":
+		return toInternalLibrary(obj)
 	default:
 		panic(fmt.Errorf("unsupported kind: %s", kind))
 	}
 }
 
 func toInternalProject(obj interface{}) interface{} {
-	return map[string]interface{}{
+	result := map[string]interface{}{
 		"kind":      "Project",
 		"files":     getSlice(obj, "files"),
 		"name":      getString(obj, "name"),
 		"variables": getMap(obj, "variables"),
+		"secrets":   getMap(obj, "secrets"),
+		"run": map[string]interface{}{
+			"tasks": toInternalTasks(obj),
+		},
 	}
+	for _, key := range []string{"timeout", "entryTimeout", "executorPolicy"} {
+		if value := get(obj, key); value != nil {
+			result[key] = value
+		}
+	}
+	return result
 }
 
 func toInternalService(obj interface{}) interface{} {
@@ -44,18 +56,24 @@ func toInternalService(obj interface{}) interface{} {
 	for i, v := range getSlice(obj, "artifacts") {
 		e := toInternalEntry(i, or(get(v, "push"), v))
 		if getString(e, "type") != "" {
+			// Pusher defined in "push" property is located differently in
+			// the file than the one sharing definition with the builder
+			if get(v, "push") != nil {
+				e.(map[string]interface{})["push"] = true
+			}
+			// Timeout of the artifact applies to pushing too, unless push
+			// entry has its own one
+			if timeout := get(v, "timeout"); timeout != nil && get(e, "timeout") == nil {
+				e.(map[string]interface{})["timeout"] = timeout
+			}
 			toPush = append(toPush, e)
 		}
 	}
 
-	tasks := map[string]interface{}{}
-	for k, v := range getMap(obj, "tasks") {
-		tasks[k] = mapSlice(getSlice(v), toInternalEntry)
-	}
-
 	return map[string]interface{}{
-		"kind": "Service",
-		"name": getString(obj, "name"),
+		"kind":      "Service",
+		"name":      getString(obj, "name"),
+		"dependsOn": getSlice(obj, "dependsOn"),
 		"build": map[string]interface{}{
 			"artifacts": map[string]interface{}{
 				"toBuild": toBuild,
@@ -67,7 +85,7 @@ func toInternalService(obj interface{}) interface{} {
 			"releases": releases,
 		},
 		"run": map[string]interface{}{
-			"tasks": tasks,
+			"tasks": toInternalTasks(obj),
 		},
 	}
 }
@@ -78,6 +96,7 @@ func toInternalEnvironment(obj interface{}) interface{} {
 		"name":           getString(obj, "name"),
 		"deployServices": getSlice(obj, "deployServices"),
 		"variables":      getMap(obj, "variables"),
+		"secrets":        getMap(obj, "secrets"),
 	}
 }
 
@@ -86,12 +105,41 @@ func toInternalExecutor(obj interface{}) interface{} {
 	if err != nil {
 		panic(err)
 	}
-	return map[string]interface{}{
+	result := map[string]interface{}{
 		"kind":   getString(obj, "kind"),
 		"name":   getString(obj, "name"),
 		"script": getString(obj, "script"),
 		"schema": string(schema),
 	}
+	if getString(obj, "kind") == "Deployer" {
+		result["rollback"] = getString(obj, "rollback")
+	}
+	if allow := get(obj, "allowOutsideProject"); allow != nil {
+		result["allowOutsideProject"] = allow
+	}
+	if commands := get(obj, "commands"); commands != nil {
+		result["commands"] = commands
+	}
+	if command := get(obj, "command"); command != nil {
+		result["command"] = command
+	}
+	return result
+}
+
+func toInternalLibrary(obj interface{}) interface{} {
+	return map[string]interface{}{
+		"kind":   "Library",
+		"name":   getString(obj, "name"),
+		"script": getString(obj, "script"),
+	}
+}
+
+func toInternalTasks(obj interface{}) map[string]interface{} {
+	tasks := map[string]interface{}{}
+	for k, v := range getMap(obj, "tasks") {
+		tasks[k] = mapSlice(getSlice(v), toInternalEntry)
+	}
+	return tasks
 }
 
 func toInternalEntry(i int, obj interface{}) interface{} {
@@ -102,8 +150,12 @@ func toInternalEntry(i int, obj interface{}) interface{} {
 		return map[string]interface{}{"index": int64(i), "type": obj, "spec": nil}
 	}
 	for k, v := range getMap(obj) {
-		if k != "push" {
-			return map[string]interface{}{"index": int64(i), "type": k, "spec": v}
+		if k != "push" && k != "timeout" {
+			entry := map[string]interface{}{"index": int64(i), "type": k, "spec": v}
+			if timeout := get(obj, "timeout"); timeout != nil {
+				entry["timeout"] = timeout
+			}
+			return entry
 		}
 	}
 	return nil
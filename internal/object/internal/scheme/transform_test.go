@@ -19,6 +19,9 @@ func TestToInternal(t *testing.T) {
 				"apiVersion": "g2a-cli/v2.0",
 				"kind":       "Service",
 				"name":       "test",
+				"dependsOn": []interface{}{
+					"other",
+				},
 				"tags": []interface{}{
 					"gitTag",
 					map[string]interface{}{
@@ -48,6 +51,16 @@ func TestToInternal(t *testing.T) {
 						"docker": map[string]interface{}{"image": "example.com/test/image"},
 						"push":   map[string]interface{}{"script": "script.sh"},
 					},
+					map[string]interface{}{
+						"docker":  map[string]interface{}{"image": "example.com/test/image"},
+						"push":    "script",
+						"timeout": "10m",
+					},
+					map[string]interface{}{
+						"docker":  map[string]interface{}{"image": "example.com/test/image"},
+						"push":    map[string]interface{}{"script": "script.sh", "timeout": "1m"},
+						"timeout": "10m",
+					},
 				},
 				"releases": []interface{}{
 					"npm",
@@ -57,6 +70,10 @@ func TestToInternal(t *testing.T) {
 					map[string]interface{}{
 						"helm": map[string]interface{}{"chartPath": "bitnami/redis"},
 					},
+					map[string]interface{}{
+						"helm":    map[string]interface{}{"chartPath": "bitnami/redis"},
+						"timeout": "5m",
+					},
 				},
 				"tasks": map[string]interface{}{
 					"prepare": []interface{}{
@@ -82,6 +99,9 @@ func TestToInternal(t *testing.T) {
 			expected: map[string]interface{}{
 				"kind": "Service",
 				"name": "test",
+				"dependsOn": []interface{}{
+					"other",
+				},
 				"build": map[string]interface{}{
 					"tags": []interface{}{
 						map[string]interface{}{
@@ -132,6 +152,18 @@ func TestToInternal(t *testing.T) {
 								"spec":  map[string]interface{}{"image": "example.com/test/image"},
 								"type":  "docker",
 							},
+							map[string]interface{}{
+								"index":   int64(6),
+								"spec":    map[string]interface{}{"image": "example.com/test/image"},
+								"type":    "docker",
+								"timeout": "10m",
+							},
+							map[string]interface{}{
+								"index":   int64(7),
+								"spec":    map[string]interface{}{"image": "example.com/test/image"},
+								"type":    "docker",
+								"timeout": "10m",
+							},
 						},
 						"toPush": []interface{}{
 							map[string]interface{}{
@@ -153,11 +185,27 @@ func TestToInternal(t *testing.T) {
 								"index": int64(4),
 								"spec":  nil,
 								"type":  "script",
+								"push":  true,
 							},
 							map[string]interface{}{
 								"index": int64(5),
 								"spec":  "script.sh",
 								"type":  "script",
+								"push":  true,
+							},
+							map[string]interface{}{
+								"index":   int64(6),
+								"spec":    nil,
+								"type":    "script",
+								"push":    true,
+								"timeout": "10m",
+							},
+							map[string]interface{}{
+								"index":   int64(7),
+								"spec":    "script.sh",
+								"type":    "script",
+								"push":    true,
+								"timeout": "1m",
 							},
 						},
 					},
@@ -179,6 +227,12 @@ func TestToInternal(t *testing.T) {
 							"spec":  map[string]interface{}{"chartPath": "bitnami/redis"},
 							"type":  "helm",
 						},
+						map[string]interface{}{
+							"index":   int64(3),
+							"spec":    map[string]interface{}{"chartPath": "bitnami/redis"},
+							"type":    "helm",
+							"timeout": "5m",
+						},
 					},
 				},
 				"run": map[string]interface{}{
@@ -230,8 +284,9 @@ func TestToInternal(t *testing.T) {
 				"name":       "test",
 			},
 			expected: map[string]interface{}{
-				"kind": "Service",
-				"name": "test",
+				"kind":      "Service",
+				"name":      "test",
+				"dependsOn": []interface{}{},
 				"build": map[string]interface{}{
 					"artifacts": map[string]interface{}{
 						"toBuild": []interface{}{},
@@ -259,6 +314,23 @@ func TestToInternal(t *testing.T) {
 				"variables": map[string]interface{}{
 					"name": "value",
 				},
+				"secrets": map[string]interface{}{
+					"token": map[string]interface{}{"env": "TOKEN"},
+				},
+				"timeout":      "1h",
+				"entryTimeout": "15m",
+				"executorPolicy": map[string]interface{}{
+					"requireCommands": true,
+				},
+				"tasks": map[string]interface{}{
+					"prepare": []interface{}{
+						map[string]interface{}{
+							"script": map[string]interface{}{
+								"path": "./prepare.sh",
+							},
+						},
+					},
+				},
 				"extra": true,
 			},
 			expected: map[string]interface{}{
@@ -270,6 +342,27 @@ func TestToInternal(t *testing.T) {
 				"variables": map[string]interface{}{
 					"name": "value",
 				},
+				"secrets": map[string]interface{}{
+					"token": map[string]interface{}{"env": "TOKEN"},
+				},
+				"timeout":      "1h",
+				"entryTimeout": "15m",
+				"executorPolicy": map[string]interface{}{
+					"requireCommands": true,
+				},
+				"run": map[string]interface{}{
+					"tasks": map[string]interface{}{
+						"prepare": []interface{}{
+							map[string]interface{}{
+								"index": int64(0),
+								"spec": map[string]interface{}{
+									"path": "./prepare.sh",
+								},
+								"type": "script",
+							},
+						},
+					},
+				},
 			},
 		},
 		{
@@ -284,6 +377,10 @@ func TestToInternal(t *testing.T) {
 				"name":      "test",
 				"files":     []interface{}{},
 				"variables": map[string]interface{}{},
+				"secrets":   map[string]interface{}{},
+				"run": map[string]interface{}{
+					"tasks": map[string]interface{}{},
+				},
 			},
 		},
 		{
@@ -300,6 +397,9 @@ func TestToInternal(t *testing.T) {
 					"varA": "value",
 					"varB": "value",
 				},
+				"secrets": map[string]interface{}{
+					"token": map[string]interface{}{"file": "token.txt"},
+				},
 			},
 			expected: map[string]interface{}{
 				"kind": "Environment",
@@ -312,6 +412,9 @@ func TestToInternal(t *testing.T) {
 					"varA": "value",
 					"varB": "value",
 				},
+				"secrets": map[string]interface{}{
+					"token": map[string]interface{}{"file": "token.txt"},
+				},
 			},
 		},
 		{
@@ -326,15 +429,35 @@ func TestToInternal(t *testing.T) {
 				"name":           "test",
 				"deployServices": []interface{}{},
 				"variables":      map[string]interface{}{},
+				"secrets":        map[string]interface{}{},
 			},
 		},
 		{
 			name: "v2.0/Builder/full",
+			input: map[string]interface{}{
+				"apiVersion":          "g2a-cli/v2.0",
+				"kind":                "Builder",
+				"name":                "test",
+				"schema":              map[string]interface{}{},
+				"script":              "",
+				"allowOutsideProject": true,
+				"commands":            []interface{}{"docker"},
+			},
+			expected: map[string]interface{}{
+				"kind":                "Builder",
+				"name":                "test",
+				"schema":              "{}",
+				"script":              "",
+				"allowOutsideProject": true,
+				"commands":            []interface{}{"docker"},
+			},
+		},
+		{
+			name: "v2.0/Builder/min",
 			input: map[string]interface{}{
 				"apiVersion": "g2a-cli/v2.0",
 				"kind":       "Builder",
 				"name":       "test",
-				"schema":     map[string]interface{}{},
 				"script":     "",
 			},
 			expected: map[string]interface{}{
@@ -345,18 +468,19 @@ func TestToInternal(t *testing.T) {
 			},
 		},
 		{
-			name: "v2.0/Builder/min",
+			name: "v2.0/Builder/command",
 			input: map[string]interface{}{
 				"apiVersion": "g2a-cli/v2.0",
 				"kind":       "Builder",
 				"name":       "test",
-				"script":     "",
+				"command":    []interface{}{"./builder.py", "--verbose"},
 			},
 			expected: map[string]interface{}{
-				"kind":   "Builder",
-				"name":   "test",
-				"schema": "{}",
-				"script": "",
+				"kind":    "Builder",
+				"name":    "test",
+				"schema":  "{}",
+				"script":  "",
+				"command": []interface{}{"./builder.py", "--verbose"},
 			},
 		},
 		{
@@ -367,12 +491,14 @@ func TestToInternal(t *testing.T) {
 				"name":       "test",
 				"schema":     map[string]interface{}{},
 				"script":     "",
+				"rollback":   "",
 			},
 			expected: map[string]interface{}{
-				"kind":   "Deployer",
-				"name":   "test",
-				"schema": "{}",
-				"script": "",
+				"kind":     "Deployer",
+				"name":     "test",
+				"schema":   "{}",
+				"script":   "",
+				"rollback": "",
 			},
 		},
 		{
@@ -384,10 +510,25 @@ func TestToInternal(t *testing.T) {
 				"script":     "",
 			},
 			expected: map[string]interface{}{
-				"kind":   "Deployer",
+				"kind":     "Deployer",
+				"name":     "test",
+				"schema":   "{}",
+				"script":   "",
+				"rollback": "",
+			},
+		},
+		{
+			name: "v2.0/Library",
+			input: map[string]interface{}{
+				"apiVersion": "g2a-cli/v2.0",
+				"kind":       "Library",
+				"name":       "test",
+				"script":     "export {}",
+			},
+			expected: map[string]interface{}{
+				"kind":   "Library",
 				"name":   "test",
-				"schema": "{}",
-				"script": "",
+				"script": "export {}",
 			},
 		},
 		{
@@ -421,6 +562,37 @@ func TestToInternal(t *testing.T) {
 				"script": "",
 			},
 		},
+		{
+			name: "v2.0/Runner/full",
+			input: map[string]interface{}{
+				"apiVersion": "g2a-cli/v2.0",
+				"kind":       "Runner",
+				"name":       "test",
+				"schema":     map[string]interface{}{},
+				"script":     "",
+			},
+			expected: map[string]interface{}{
+				"kind":   "Runner",
+				"name":   "test",
+				"schema": "{}",
+				"script": "",
+			},
+		},
+		{
+			name: "v2.0/Runner/min",
+			input: map[string]interface{}{
+				"apiVersion": "g2a-cli/v2.0",
+				"kind":       "Runner",
+				"name":       "test",
+				"script":     "",
+			},
+			expected: map[string]interface{}{
+				"kind":   "Runner",
+				"name":   "test",
+				"schema": "{}",
+				"script": "",
+			},
+		},
 		{
 			name: "v2.0/Tagger/full",
 			input: map[string]interface{}{
@@ -6,8 +6,10 @@ const (
 	BuilderKind     Kind = "Builder"
 	DeployerKind    Kind = "Deployer"
 	EnvironmentKind Kind = "Environment"
+	LibraryKind     Kind = "Library"
 	ProjectKind     Kind = "Project"
 	PusherKind      Kind = "Pusher"
+	RunnerKind      Kind = "Runner"
 	ServiceKind     Kind = "Service"
 	TaggerKind      Kind = "Tagger"
 	OptionsKind     Kind = "Options"
@@ -0,0 +1,33 @@
+package object
+
+import (
+	"gopkg.in/yaml.v3"
+)
+
+// Library is a script shared between executors, which can import it using
+// its name.
+type Library interface {
+	Object
+	Script() string
+}
+
+type library struct {
+	GenericObject
+
+	Data struct {
+		Script string
+	} `mapstructure:",squash"`
+}
+
+var _ Library = library{}
+
+func NewLibrary(filename string, data *yaml.Node) (Library, error) {
+	l := library{}
+	l.GenericObject.metadata = NewMetadata(filename, data)
+	err := decode(data, &l)
+	return l, err
+}
+
+func (l library) Script() string {
+	return l.Data.Script
+}
@@ -0,0 +1,39 @@
+package object
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_unmarshalling_library(t *testing.T) {
+	input := prepareTestInput(`{
+		apiVersion: g2a-cli/v2.0,
+		kind: Library,
+		name: test,
+		script: "export {}",
+	}`)
+
+	result, err := NewLibrary("dir/file.yaml", input)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "dir/file.yaml", result.Metadata().Filename())
+	assert.Equal(t, LibraryKind, result.Kind())
+	assert.Equal(t, "test", result.Name())
+	assert.Equal(t, `library "test"`, result.DisplayName())
+	assert.Equal(t, "export {}", result.Script())
+}
+
+func Test_creating_library_using_generic_constructor(t *testing.T) {
+	input := prepareTestInput(`{
+		apiVersion: g2a-cli/v2.0,
+		kind: Library,
+		name: test,
+		script: "export {}",
+	}`)
+
+	result, err := NewObject("build", "dir/file.yaml", input)
+
+	assert.NoError(t, err)
+	assert.Implements(t, (*Library)(nil), result)
+}
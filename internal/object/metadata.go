@@ -3,6 +3,7 @@ package object
 import (
 	"fmt"
 
+	"github.com/g2a-com/cicd/internal/schema"
 	"gopkg.in/yaml.v3"
 )
 
@@ -13,12 +14,13 @@ type Metadata interface {
 }
 
 type metadata struct {
-	filename string `yaml:"-"`
-	line     int    `yaml:"-"`
+	filename string     `yaml:"-"`
+	line     int        `yaml:"-"`
+	node     *yaml.Node `yaml:"-"`
 }
 
 func NewMetadata(filename string, data *yaml.Node) Metadata {
-	return metadata{filename, data.Line}
+	return metadata{filename, data.Line, data}
 }
 
 func (m metadata) String() string {
@@ -36,3 +38,18 @@ func (m metadata) Filename() string {
 func (m metadata) Line() int {
 	return m.line
 }
+
+// NewValidationError creates an error pointing to the value within the
+// document described by the metadata.
+func NewValidationError(m Metadata, pointer string, format string, args ...interface{}) error {
+	line := m.Line()
+	if md, ok := m.(metadata); ok && md.node != nil {
+		line = schema.FindLine(md.node, pointer)
+	}
+	return &schema.ValidationError{
+		Filename: m.Filename(),
+		Line:     line,
+		Pointer:  pointer,
+		Message:  fmt.Sprintf(format, args...),
+	}
+}
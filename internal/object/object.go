@@ -83,6 +83,9 @@ func NewObject(mode string, filename string, data *yaml.Node) (Object, error) {
 
 	switch obj.Kind() {
 	case ProjectKind:
+		if mode == "run" {
+			return NewRunProject(filename, data)
+		}
 		return NewProject(filename, data)
 	case ServiceKind:
 		switch mode {
@@ -90,13 +93,17 @@ func NewObject(mode string, filename string, data *yaml.Node) (Object, error) {
 			return NewBuildService(filename, data)
 		case "deploy":
 			return NewDeployService(filename, data)
+		case "run":
+			return NewRunService(filename, data)
 		default:
 			return nil, fmt.Errorf("unknown mode %s", mode)
 		}
 	case EnvironmentKind:
 		return NewEnvironment(filename, data)
-	case BuilderKind, DeployerKind, PusherKind, TaggerKind:
+	case BuilderKind, DeployerKind, PusherKind, RunnerKind, TaggerKind:
 		return NewExecutor(filename, data)
+	case LibraryKind:
+		return NewLibrary(filename, data)
 	default:
 		return nil, fmt.Errorf("unknown kind %q", obj.Kind())
 	}
@@ -1,7 +1,7 @@
 package object
 
 import (
-	"fmt"
+	"time"
 
 	"github.com/hashicorp/go-multierror"
 	"gopkg.in/yaml.v3"
@@ -11,30 +11,51 @@ type Project interface {
 	Object
 
 	Files() []string
+	// Timeout returns time limit for the whole command, 0 means no limit.
+	Timeout() time.Duration
+	// EntryTimeout returns time limit for entries which don't define their
+	// own one, 0 means no limit.
+	EntryTimeout() time.Duration
+	// ExecutorPolicy returns policy restricting commands run by executors, or
+	// nil if the project doesn't define it.
+	ExecutorPolicy() *ExecutorPolicy
 }
 
 type project struct {
 	GenericObject
+	secrets
 
 	Data struct {
-		Files     []string
-		Variables map[string]string
+		Files          []string
+		Variables      map[string]string
+		SecretRefs     map[string]secretRef `mapstructure:"secrets"`
+		Timeout        string
+		EntryTimeout   string
+		ExecutorPolicy *ExecutorPolicy
 	} `mapstructure:",squash"`
 }
 
 var _ Project = project{}
+var _ SecretsHolder = project{}
 
 func NewProject(filename string, data *yaml.Node) (Project, error) {
 	p := project{}
 	p.GenericObject.metadata = NewMetadata(filename, data)
 	err := decode(data, &p)
+	if err == nil {
+		p.secrets = readSecrets(p.Metadata(), p.Data.SecretRefs)
+	}
 	return p, err
 }
 
 func (p project) Validate(objects ObjectCollection) (err error) {
+	if e := p.secrets.validate(); e != nil {
+		err = multierror.Append(err, e)
+	}
+
 	for _, project := range objects.GetObjectsByKind(ProjectKind) {
 		if project.Metadata() != p.Metadata() {
-			err = multierror.Append(err, fmt.Errorf("project is duplicated, it's defined in:\n\t* %s\n\t* %s", project.Metadata(), p.Metadata()))
+			err = multierror.Append(err, NewValidationError(p.Metadata(), "", "project is duplicated, it's also defined in %s", project.Metadata()))
 		}
 	}
 
@@ -43,12 +64,25 @@ func (p project) Validate(objects ObjectCollection) (err error) {
 
 func (p project) PlaceholderValues() map[string]interface{} {
 	return map[string]interface{}{
-		"Project.Name": p.Name(),
-		"Project.Dir":  p.Directory(),
-		"Project.Vars": p.Data.Variables,
+		"Project.Name":    p.Name(),
+		"Project.Dir":     p.Directory(),
+		"Project.Vars":    p.Data.Variables,
+		"Project.Secrets": p.secrets.values,
 	}
 }
 
 func (p project) Files() []string {
 	return p.Data.Files
 }
+
+func (p project) Timeout() time.Duration {
+	return parseTimeout(p.Data.Timeout)
+}
+
+func (p project) EntryTimeout() time.Duration {
+	return parseTimeout(p.Data.EntryTimeout)
+}
+
+func (p project) ExecutorPolicy() *ExecutorPolicy {
+	return p.Data.ExecutorPolicy
+}
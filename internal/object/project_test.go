@@ -23,6 +23,28 @@ func Test_unmarshalling_empty_project(t *testing.T) {
 	assert.Equal(t, `project "test"`, result.DisplayName())
 }
 
+func Test_unmarshalling_project_with_executor_policy(t *testing.T) {
+	input := prepareTestInput(`{
+		apiVersion: g2a-cli/v2.0,
+		kind: Project,
+		name: test,
+		executorPolicy: {
+			requireCommands: true,
+			allowedCommands: [docker],
+			approvedExecutors: [{ kind: Deployer, name: helm3 }],
+		},
+	}`)
+
+	result, err := NewProject("dir/file.yaml", input)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &ExecutorPolicy{
+		RequireCommands:   true,
+		AllowedCommands:   &[]string{"docker"},
+		ApprovedExecutors: []ExecutorRef{{Kind: DeployerKind, Name: "helm3"}},
+	}, result.ExecutorPolicy())
+}
+
 func Test_validating_empty_project_passes(t *testing.T) {
 	collection := fakeCollection{}
 	input := prepareTestInput(`{
@@ -52,3 +74,20 @@ func Test_validating_duplicated_project_fails(t *testing.T) {
 
 	assert.Error(t, err)
 }
+
+func Test_project_secrets_are_available_as_placeholders(t *testing.T) {
+	t.Setenv("TEST_SECRET", "secret")
+	input := prepareTestInput(`{
+		apiVersion: g2a-cli/v2.0,
+		kind: Project,
+		name: test,
+		secrets: { token: { env: TEST_SECRET } },
+	}`)
+
+	project, _ := NewProject("dir/file.yaml", input)
+	secrets, err := project.(SecretsHolder).Secrets()
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"token": "secret"}, secrets)
+	assert.Equal(t, secrets, project.PlaceholderValues()["Project.Secrets"])
+}
@@ -0,0 +1,68 @@
+package object
+
+import (
+	"sort"
+
+	"github.com/hashicorp/go-multierror"
+	"gopkg.in/yaml.v3"
+)
+
+// runProject is a project which exposes its tasks as entries, it's used only
+// in the run mode.
+type runProject struct {
+	project
+	entries map[string][]Entry
+}
+
+var _ Project = runProject{}
+
+func NewRunProject(filename string, data *yaml.Node) (Project, error) {
+	p := runProject{}
+	p.GenericObject.metadata = NewMetadata(filename, data)
+	err := decode(data, &p.project)
+	if err != nil {
+		return p, err
+	}
+	p.secrets = readSecrets(p.Metadata(), p.Data.SecretRefs)
+
+	var tasks struct {
+		Run struct {
+			Tasks map[string][]*runEntry
+		}
+	}
+	err = decode(data, &tasks)
+
+	p.entries = newRunEntries(p.project, tasks.Run.Tasks)
+
+	return p, err
+}
+
+func (p runProject) Validate(objects ObjectCollection) (err error) {
+	err = p.project.Validate(objects)
+
+	for _, entryType := range p.EntryTypes() {
+		for _, entry := range p.entries[entryType] {
+			e := entry.Validate(objects)
+			if e != nil {
+				err = multierror.Append(err, e)
+			}
+		}
+	}
+
+	return
+}
+
+func (p runProject) EntryTypes() []string {
+	result := make([]string, 0, len(p.entries))
+	for key := range p.entries {
+		result = append(result, key)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func (p runProject) Entries(entryType string) []Entry {
+	result := make([]Entry, len(p.entries[entryType]))
+	copy(result, p.entries[entryType])
+	return result
+}
@@ -0,0 +1,94 @@
+package object
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_unmarshalling_empty_run_project(t *testing.T) {
+	input := prepareTestInput(`{
+		apiVersion: g2a-cli/v2.0,
+		kind: Project,
+		name: test,
+	}`)
+
+	result, err := NewRunProject("dir/file.yaml", input)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "dir/file.yaml", result.Metadata().Filename())
+	assert.Equal(t, ProjectKind, result.Kind())
+	assert.Equal(t, "test", result.Name())
+	assert.Equal(t, "dir", result.Directory())
+	assert.Equal(t, `project "test"`, result.DisplayName())
+	assert.Empty(t, result.EntryTypes())
+}
+
+func Test_unmarshalling_run_project_preserves_files(t *testing.T) {
+	input := prepareTestInput(`{
+		apiVersion: g2a-cli/v2.0, kind: Project, name: test,
+		files: [ "services/*/service.yaml" ],
+	}`)
+
+	result, err := NewRunProject("dir/file.yaml", input)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"services/*/service.yaml"}, result.Files())
+}
+
+func Test_validating_run_project_using_unknown_runner_fails(t *testing.T) {
+	input := prepareTestInput(`{
+		apiVersion: g2a-cli/v2.0, kind: Project, name: test,
+		tasks: { prepare: [ { unknown: {} } ] },
+	}`)
+
+	project, _ := NewRunProject("dir/file.yaml", input)
+	collection := fakeCollection{
+		project,
+		fakeObject{kind: OptionsKind},
+		fakeObject{kind: RunnerKind, name: "known", schema: "{}"},
+	}
+	err := project.Validate(collection)
+
+	assert.Error(t, err)
+}
+
+func Test_validating_run_project_using_known_runner_passes(t *testing.T) {
+	input := prepareTestInput(`{
+		apiVersion: g2a-cli/v2.0, kind: Project, name: test,
+		tasks: { prepare: [ { known: {} } ] },
+	}`)
+
+	project, _ := NewRunProject("dir/file.yaml", input)
+	collection := fakeCollection{
+		project,
+		fakeObject{kind: OptionsKind},
+		fakeObject{kind: RunnerKind, name: "known", schema: "{}"},
+	}
+	err := project.Validate(collection)
+
+	assert.NoError(t, err)
+}
+
+func Test_getting_run_project_entry_spec_fills_placeholders_using_values_from_project_and_options(t *testing.T) {
+	input := prepareTestInput(`{
+		apiVersion: g2a-cli/v2.0, kind: Project, name: test,
+		tasks: { prepare: [ { name: "{{.Project.Name}} {{.Options.Egg}}" } ] },
+	}`)
+
+	project, _ := NewRunProject("dir/file.yaml", input)
+	collection := fakeCollection{
+		project,
+		fakeObject{
+			kind:              OptionsKind,
+			placeholderValues: map[string]interface{}{"Options.Egg": "1"},
+		},
+	}
+	entries := project.Entries("prepare")
+	result := entries[0].Spec(collection)
+
+	assert.Len(t, entries, 1)
+	assert.Equal(t, RunnerKind, entries[0].ExecutorKind())
+	assert.Equal(t, "name", entries[0].ExecutorName())
+	assert.Equal(t, "test 1", result)
+}
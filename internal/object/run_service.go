@@ -0,0 +1,112 @@
+package object
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/g2a-com/cicd/internal/placeholders"
+	"gopkg.in/yaml.v3"
+)
+
+type runService struct {
+	GenericService
+
+	Run struct {
+		Tasks map[string][]*runEntry
+	}
+}
+
+var _ Service = runService{}
+
+func NewRunService(filename string, data *yaml.Node) (Object, error) {
+	service := runService{}
+	service.GenericObject.metadata = NewMetadata(filename, data)
+	err := decode(data, &service)
+
+	service.entries = newRunEntries(service, service.Run.Tasks)
+
+	return service, err
+}
+
+// newRunEntries converts tasks into entries. Entry type is the same as the
+// name of a task.
+func newRunEntries(owner Object, tasks map[string][]*runEntry) map[string][]Entry {
+	entries := map[string][]Entry{}
+	for task, list := range tasks {
+		entries[task] = make([]Entry, len(list))
+		for i, entry := range list {
+			entry.owner = owner
+			entry.task = task
+			entry.executorKind = RunnerKind
+			entries[task][i] = entry
+		}
+	}
+	return entries
+}
+
+type runEntry struct {
+	executorKind Kind
+	owner        Object
+	task         string
+	Data         struct {
+		Index   int
+		Type    string
+		Spec    interface{}
+		Timeout string
+	} `mapstructure:",squash"`
+}
+
+func (e *runEntry) Index() int {
+	return e.Data.Index
+}
+
+func (e *runEntry) ExecutorKind() Kind {
+	return e.executorKind
+}
+
+func (e *runEntry) ExecutorName() string {
+	return e.Data.Type
+}
+
+func (e *runEntry) Timeout() time.Duration {
+	return parseTimeout(e.Data.Timeout)
+}
+
+func (e *runEntry) Validate(objects ObjectCollection) error {
+	return validateEntry(objects, e.owner, e.pointer(), e, e.spec)
+}
+
+func (e *runEntry) pointer() string {
+	return fmt.Sprintf("/tasks/%s/%d", escapePointerToken(e.task), e.Data.Index)
+}
+
+func (e *runEntry) Spec(objects ObjectCollection) interface{} {
+	spec, err := e.spec(objects)
+	if err != nil {
+		// Errors should have been handled during validation phase.
+		panic(err)
+	}
+	return spec
+}
+
+func (e *runEntry) spec(b ObjectCollection) (interface{}, error) {
+	project := b.GetUniqueObject(ProjectKind)
+	if project == nil {
+		return nil, fmt.Errorf("cannot find project")
+	}
+	options := b.GetUniqueObject(OptionsKind)
+	if options == nil {
+		return nil, fmt.Errorf("cannot find options")
+	}
+
+	values, err := placeholders.MergeValues(
+		project.PlaceholderValues(),
+		options.PlaceholderValues(),
+		e.owner.PlaceholderValues(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return placeholders.ReplaceWithValues(e.Data.Spec, values)
+}
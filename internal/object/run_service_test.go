@@ -0,0 +1,152 @@
+package object
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_unmarshalling_empty_run_service(t *testing.T) {
+	input := prepareTestInput(`{
+		apiVersion: g2a-cli/v2.0,
+		kind: Service,
+		name: test,
+	}`)
+
+	result, err := NewRunService("dir/file.yaml", input)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "dir/file.yaml", result.Metadata().Filename())
+	assert.Equal(t, ServiceKind, result.Kind())
+	assert.Equal(t, "test", result.Name())
+	assert.Equal(t, "dir", result.Directory())
+	assert.Equal(t, `service "test"`, result.DisplayName())
+}
+
+func Test_validating_empty_run_service_passes(t *testing.T) {
+	collection := fakeCollection{
+		fakeObject{kind: ProjectKind},
+		fakeObject{kind: OptionsKind},
+	}
+	input := prepareTestInput(`{
+		apiVersion: g2a-cli/v2.0,
+		kind: Service,
+		name: test,
+	}`)
+
+	service, _ := NewRunService("dir/file.yaml", input)
+	err := service.Validate(collection)
+
+	assert.NoError(t, err)
+}
+
+func Test_validating_run_service_using_unknown_runner_fails(t *testing.T) {
+	collection := fakeCollection{
+		fakeObject{kind: ProjectKind},
+		fakeObject{kind: OptionsKind},
+		fakeObject{kind: RunnerKind, name: "known", schema: "{}"},
+	}
+	input := prepareTestInput(`{
+		apiVersion: g2a-cli/v2.0, kind: Service, name: test,
+		tasks: { test: [ { unknown: {} } ] },
+	}`)
+
+	service, _ := NewRunService("dir/file.yaml", input)
+	err := service.Validate(collection)
+
+	assert.Error(t, err)
+}
+
+func Test_validating_run_service_using_known_runner_passes(t *testing.T) {
+	collection := fakeCollection{
+		fakeObject{kind: ProjectKind},
+		fakeObject{kind: OptionsKind},
+		fakeObject{kind: RunnerKind, name: "known", schema: "{}"},
+	}
+	input := prepareTestInput(`{
+		apiVersion: g2a-cli/v2.0, kind: Service, name: test,
+		tasks: { test: [ { known: {} } ] },
+	}`)
+
+	service, _ := NewRunService("dir/file.yaml", input)
+	err := service.Validate(collection)
+
+	assert.NoError(t, err)
+}
+
+func Test_validating_run_service_with_task_entry_not_matching_runner_schema_fails(t *testing.T) {
+	collection := fakeCollection{
+		fakeObject{kind: ProjectKind},
+		fakeObject{kind: OptionsKind},
+		fakeObject{kind: RunnerKind, name: "type", schema: `{ "required": ["foo"] }`},
+	}
+	input := prepareTestInput(`{
+		apiVersion: g2a-cli/v2.0, kind: Service, name: test,
+		tasks: { test: [ { type: {} } ] },
+	}`)
+
+	service, _ := NewRunService("dir/file.yaml", input)
+	err := service.Validate(collection)
+
+	assert.Error(t, err)
+}
+
+func Test_getting_entry_types_list_from_run_service_returns_sorted_task_names(t *testing.T) {
+	input := prepareTestInput(`{
+		apiVersion: g2a-cli/v2.0, kind: Service, name: test,
+		tasks: { test: [ a ], lint: [ b ], custom: [ c ] },
+	}`)
+
+	service, _ := NewRunService("dir/file.yaml", input)
+	result := service.EntryTypes()
+
+	assert.Equal(t, []string{"custom", "lint", "test"}, result)
+}
+
+func Test_getting_run_entries_returns_only_entries_defined_for_the_task(t *testing.T) {
+	collection := fakeCollection{
+		fakeObject{kind: ProjectKind},
+		fakeObject{kind: OptionsKind},
+	}
+	input := prepareTestInput(`{
+		apiVersion: g2a-cli/v2.0, kind: Service, name: test,
+		releases: [{ release: spec }],
+		tasks: { test: [ { runner1: spec1 }, { runner2: spec2 } ], lint: [ { runner3: spec3 } ] },
+	}`)
+
+	service, _ := NewRunService("dir/file.yaml", input)
+	result := service.Entries("test")
+
+	assert.Len(t, result, 2)
+	assert.Equal(t, 0, result[0].Index())
+	assert.Equal(t, RunnerKind, result[0].ExecutorKind())
+	assert.Equal(t, "runner1", result[0].ExecutorName())
+	assert.Equal(t, "spec1", result[0].Spec(collection))
+	assert.Equal(t, 1, result[1].Index())
+	assert.Equal(t, RunnerKind, result[1].ExecutorKind())
+	assert.Equal(t, "runner2", result[1].ExecutorName())
+	assert.Equal(t, "spec2", result[1].Spec(collection))
+}
+
+func Test_getting_run_entry_spec_fills_placeholders_using_values_from_service_project_and_options(t *testing.T) {
+	collection := fakeCollection{
+		fakeObject{
+			kind:              ProjectKind,
+			placeholderValues: map[string]interface{}{"Projects.Foo": "1"},
+		},
+		fakeObject{
+			kind:              OptionsKind,
+			placeholderValues: map[string]interface{}{"Options.Egg": "2"},
+		},
+	}
+	input := prepareTestInput(`{
+		apiVersion: g2a-cli/v2.0, kind: Service, name: test,
+		tasks: { test: [ { name: "{{.Service.Name}} {{.Projects.Foo}} {{.Options.Egg}}" } ] },
+	}`)
+
+	service, _ := NewRunService("dir/file.yaml", input)
+	entries := service.Entries("test")
+	result := entries[0].Spec(collection)
+
+	assert.Equal(t, "test 1 2", result)
+}
@@ -0,0 +1,77 @@
+package object
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+// SecretsHolder is implemented by objects defining secret variables.
+type SecretsHolder interface {
+	// Secrets returns values of the secrets, and error if some of them cannot
+	// be read.
+	Secrets() (map[string]string, error)
+}
+
+// secretRef points to the source of the secret value.
+type secretRef struct {
+	Env  string
+	File string
+}
+
+// secrets contains values of secrets read when object was created. Errors
+// aren't returned immediately, since only some objects (e.g. the environment
+// used for deployment) need them.
+type secrets struct {
+	values map[string]string
+	// err contains errors of secrets which values cannot be read
+	err error
+	// invalid contains errors of secrets which are declared incorrectly
+	invalid error
+}
+
+func readSecrets(m Metadata, refs map[string]secretRef) (s secrets) {
+	s.values = map[string]string{}
+	for name, ref := range refs {
+		pointer := "/secrets/" + escapePointerToken(name)
+
+		switch {
+		case ref.Env != "":
+			value, ok := os.LookupEnv(ref.Env)
+			if !ok {
+				s.err = multierror.Append(s.err, NewValidationError(m, pointer, "cannot read secret %q, environment variable %s is not set", name, ref.Env))
+				continue
+			}
+			s.values[name] = value
+
+		case ref.File != "":
+			path := ref.File
+			if !filepath.IsAbs(path) {
+				path = filepath.Join(filepath.Dir(m.Filename()), path)
+			}
+			data, err := ioutil.ReadFile(path)
+			if err != nil {
+				s.err = multierror.Append(s.err, NewValidationError(m, pointer, "cannot read secret %q, %s", name, err))
+				continue
+			}
+			s.values[name] = strings.TrimSuffix(strings.TrimSuffix(string(data), "\n"), "\r")
+
+		default:
+			s.invalid = multierror.Append(s.invalid, NewValidationError(m, pointer, "secret %q has no source, use \"env\" or \"file\"", name))
+		}
+	}
+	return
+}
+
+func (s secrets) Secrets() (map[string]string, error) {
+	return s.values, s.err
+}
+
+// validate returns errors of secrets which are declared incorrectly, values
+// of secrets don't need to be available.
+func (s secrets) validate() error {
+	return s.invalid
+}
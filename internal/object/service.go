@@ -1,19 +1,38 @@
 package object
 
 import (
+	"fmt"
 	"sort"
 
 	"github.com/hashicorp/go-multierror"
 )
 
+type Service interface {
+	Object
+
+	DependsOn() []string
+}
+
 type GenericService struct {
 	GenericObject
+	Data struct {
+		DependsOn []string
+	} `mapstructure:",squash"`
 	entries map[string][]Entry
 }
 
-var _ Object = GenericService{}
+var _ Service = GenericService{}
 
 func (s GenericService) Validate(c ObjectCollection) (err error) {
+	for i, name := range s.DependsOn() {
+		if c.GetObject(ServiceKind, name) == nil {
+			err = multierror.Append(err, NewValidationError(
+				s.Metadata(), fmt.Sprintf("/dependsOn/%d", i),
+				"service %q depends on missing service %q", s.Name(), name,
+			))
+		}
+	}
+
 	for _, entryType := range s.EntryTypes() {
 		for _, entry := range s.entries[entryType] {
 			e := entry.Validate(c)
@@ -26,6 +45,12 @@ func (s GenericService) Validate(c ObjectCollection) (err error) {
 	return
 }
 
+func (s GenericService) DependsOn() []string {
+	result := make([]string, len(s.Data.DependsOn))
+	copy(result, s.Data.DependsOn)
+	return result
+}
+
 func (s GenericService) EntryTypes() []string {
 	result := make([]string, 0, len(s.entries))
 	for key := range s.entries {
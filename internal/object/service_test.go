@@ -0,0 +1,68 @@
+package object
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_unmarshalling_service_with_dependencies(t *testing.T) {
+	input := prepareTestInput(`{
+		apiVersion: g2a-cli/v2.0, kind: Service, name: test,
+		dependsOn: [ a, b ],
+	}`)
+
+	result, err := NewBuildService("dir/file.yaml", input)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, result.(Service).DependsOn())
+}
+
+func Test_validating_service_depending_on_existing_service_passes(t *testing.T) {
+	collection := fakeCollection{
+		fakeObject{kind: ProjectKind},
+		fakeObject{kind: OptionsKind},
+		fakeObject{kind: ServiceKind, name: "other"},
+	}
+	input := prepareTestInput(`{
+		apiVersion: g2a-cli/v2.0, kind: Service, name: test,
+		dependsOn: [ other ],
+	}`)
+
+	service, _ := NewBuildService("dir/file.yaml", input)
+	err := service.Validate(collection)
+
+	assert.NoError(t, err)
+}
+
+func Test_validating_service_depending_on_missing_service_fails(t *testing.T) {
+	collection := fakeCollection{
+		fakeObject{kind: ProjectKind},
+		fakeObject{kind: OptionsKind},
+	}
+	input := prepareTestInput(`{
+		apiVersion: g2a-cli/v2.0, kind: Service, name: test,
+		dependsOn: [ missing ],
+	}`)
+
+	service, _ := NewDeployService("dir/file.yaml", input)
+	err := service.Validate(collection)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), `service "test" depends on missing service "missing"`)
+}
+
+func Test_validation_error_for_missing_dependency_points_to_the_entry(t *testing.T) {
+	collection := fakeCollection{
+		fakeObject{kind: ProjectKind},
+		fakeObject{kind: OptionsKind},
+	}
+	input := prepareTestInput("apiVersion: g2a-cli/v2.0\nkind: Service\nname: test\ndependsOn:\n  - missing\n")
+
+	service, _ := NewBuildService("dir/file.yaml", input)
+	err := service.Validate(collection)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "/dependsOn/0: ")
+	assert.Contains(t, err.Error(), "at dir/file.yaml:5")
+}
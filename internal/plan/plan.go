@@ -0,0 +1,69 @@
+package plan
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/g2a-com/cicd/internal/object"
+	log "github.com/g2a-com/klio-logger-go/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// Entry is a single step of the plan with fully resolved spec.
+type Entry struct {
+	Service      string      `json:"service"`
+	Type         string      `json:"type"`
+	Entry        int         `json:"entry"`
+	ExecutorKind object.Kind `json:"executorKind"`
+	ExecutorName string      `json:"executorName"`
+	Spec         interface{} `json:"spec"`
+}
+
+// Plan lists entries in the order in which they would be executed.
+type Plan struct {
+	Entries []Entry `json:"entries"`
+}
+
+// Add resolves spec of the entry and appends it to the plan.
+func (p *Plan) Add(objects object.ObjectCollection, service object.Object, entryType string, entry object.Entry) {
+	p.Entries = append(p.Entries, Entry{
+		Service:      service.Name(),
+		Type:         entryType,
+		Entry:        entry.Index(),
+		ExecutorKind: entry.ExecutorKind(),
+		ExecutorName: entry.ExecutorName(),
+		Spec:         entry.Spec(objects),
+	})
+}
+
+// Print writes human-readable representation of the plan to the logger.
+func (p *Plan) Print(l log.Logger) error {
+	if len(p.Entries) == 0 {
+		l.Print("There is nothing to do")
+		return nil
+	}
+
+	for _, e := range p.Entries {
+		l := l.WithTags(e.Service)
+
+		l.Printf("%s entry %d: %s %q", e.Type, e.Entry, strings.ToLower(string(e.ExecutorKind)), e.ExecutorName)
+
+		if e.Spec == nil {
+			continue
+		}
+
+		spec := &bytes.Buffer{}
+		encoder := yaml.NewEncoder(spec)
+		encoder.SetIndent(2)
+		err := encoder.Encode(e.Spec)
+		if err != nil {
+			return fmt.Errorf("cannot print spec of %s entry %d of service %q: %s", e.Type, e.Entry, e.Service, err)
+		}
+		for _, line := range strings.Split(strings.TrimRight(spec.String(), "\n"), "\n") {
+			l.Print("  " + line)
+		}
+	}
+
+	return nil
+}
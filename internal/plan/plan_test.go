@@ -0,0 +1,82 @@
+package plan
+
+import (
+	"testing"
+	"time"
+
+	"github.com/g2a-com/cicd/internal/object"
+	fakelogger "github.com/g2a-com/cicd/internal/utils/fake_logger"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+type fakeEntry struct {
+	index int
+	kind  object.Kind
+	name  string
+	spec  interface{}
+}
+
+func (e fakeEntry) Index() int                               { return e.index }
+func (e fakeEntry) ExecutorKind() object.Kind                { return e.kind }
+func (e fakeEntry) ExecutorName() string                     { return e.name }
+func (e fakeEntry) Timeout() time.Duration                   { return 0 }
+func (e fakeEntry) Spec(object.ObjectCollection) interface{} { return e.spec }
+func (e fakeEntry) Validate(object.ObjectCollection) error   { return nil }
+
+func Test_adding_entry_resolves_its_spec(t *testing.T) {
+	p := &Plan{}
+
+	p.Add(nil, newService("api"), object.BuildEntryType, fakeEntry{1, object.BuilderKind, "docker", "spec"})
+
+	assert.Equal(t, []Entry{{
+		Service:      "api",
+		Type:         "build",
+		Entry:        1,
+		ExecutorKind: object.BuilderKind,
+		ExecutorName: "docker",
+		Spec:         "spec",
+	}}, p.Entries)
+}
+
+func Test_printing_plan_logs_entries_with_specs(t *testing.T) {
+	l := fakelogger.New()
+	p := &Plan{Entries: []Entry{
+		{Service: "api", Type: "build", Entry: 0, ExecutorKind: object.BuilderKind, ExecutorName: "docker", Spec: map[string]interface{}{"image": "test"}},
+		{Service: "api", Type: "tag", Entry: 1, ExecutorKind: object.TaggerKind, ExecutorName: "gitSha"},
+	}}
+
+	err := p.Print(l)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []fakelogger.Message{
+		{Tags: []string{"api"}, Method: "Printf", Args: []interface{}{"%s entry %d: %s %q", "build", 0, "builder", "docker"}},
+		{Tags: []string{"api"}, Method: "Print", Args: []interface{}{"  image: test"}},
+		{Tags: []string{"api"}, Method: "Printf", Args: []interface{}{"%s entry %d: %s %q", "tag", 1, "tagger", "gitSha"}},
+	}, l.Messages)
+}
+
+func Test_printing_empty_plan_says_there_is_nothing_to_do(t *testing.T) {
+	l := fakelogger.New()
+	p := &Plan{}
+
+	err := p.Print(l)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []fakelogger.Message{
+		{Method: "Print", Args: []interface{}{"There is nothing to do"}},
+	}, l.Messages)
+}
+
+func newService(name string) object.Object {
+	var node yaml.Node
+	err := yaml.Unmarshal([]byte(`{ kind: Service, name: `+name+` }`), &node)
+	if err != nil {
+		panic(err)
+	}
+	service, err := object.NewObject("build", "file.yaml", &node)
+	if err != nil {
+		panic(err)
+	}
+	return service
+}
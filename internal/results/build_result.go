@@ -0,0 +1,147 @@
+package results
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/g2a-com/cicd/internal/object"
+	"github.com/g2a-com/cicd/internal/script"
+)
+
+// BuildResult contains tags and pushed artifacts read from the result file
+// written by build command.
+type BuildResult struct {
+	Tags            []ResultEntry `json:"tags"`
+	PushedArtifacts []ResultEntry `json:"pushedArtifacts"`
+}
+
+// buildEntriesService is implemented by services loaded for deployment, they
+// know indexes of their build entries without running them.
+type buildEntriesService interface {
+	BuildEntryIndexes(entryType string) []int
+}
+
+// LoadBuildResult reads the result file written by build command.
+func LoadBuildResult(filename string) (*BuildResult, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &BuildResult{}
+	err = json.Unmarshal(data, result)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read build result %s: %w", filename, err)
+	}
+	return result, nil
+}
+
+// Artifacts returns artifacts pushed for the service together with their
+// metadata.
+func (r *BuildResult) Artifacts(service object.Object) []script.Result {
+	artifacts := []script.Result{}
+	if r == nil {
+		return artifacts
+	}
+
+	for _, e := range r.PushedArtifacts {
+		if e.Service == service.Name() {
+			artifacts = append(artifacts, script.Result{ID: e.Result, Metadata: e.Metadata})
+		}
+	}
+	return artifacts
+}
+
+// Validate checks whether the build result matches services which are going
+// to be deployed: services with releases and build entries must have tags or
+// artifacts in the result, and entries referenced by the result must exist.
+func (r *BuildResult) Validate(services []object.Object) error {
+	problems := []string{}
+	for _, service := range services {
+		if len(service.Entries(object.DeployEntryType)) == 0 {
+			continue
+		}
+
+		var tagEntries, pushEntries []int
+		if s, ok := service.(buildEntriesService); ok {
+			tagEntries = s.BuildEntryIndexes(object.TagEntryType)
+			pushEntries = s.BuildEntryIndexes(object.PushEntryType)
+		}
+
+		foundTags, tagProblems := checkEntries(r.Tags, service, "tag", tagEntries)
+		foundArtifacts, pushProblems := checkEntries(r.PushedArtifacts, service, "push", pushEntries)
+		if !foundTags && !foundArtifacts && len(tagEntries)+len(pushEntries) > 0 {
+			problems = append(problems, fmt.Sprintf("tags or artifacts of service %q are missing", service.Name()))
+		}
+		problems = append(problems, tagProblems...)
+		problems = append(problems, pushProblems...)
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("build result doesn't match services:\n\t%s", strings.Join(problems, "\n\t"))
+	}
+	return nil
+}
+
+// checkEntries returns whether there are any result entries of the service,
+// and problems with entries which reference missing entries of the service.
+func checkEntries(entries []ResultEntry, service object.Object, entryType string, indexes []int) (found bool, problems []string) {
+	exists := map[int]bool{}
+	for _, i := range indexes {
+		exists[i] = true
+	}
+
+	reported := map[int]bool{}
+	for _, e := range entries {
+		if e.Service != service.Name() {
+			continue
+		}
+		found = true
+		if !exists[e.Entry] && !reported[e.Entry] {
+			reported[e.Entry] = true
+			problems = append(problems, fmt.Sprintf("%s entry %d of service %q doesn't exist", entryType, e.Entry, service.Name()))
+		}
+	}
+	return found, problems
+}
+
+// PlaceholderValues returns tags and pushed artifacts grouped by service, e.g.
+// {{ .Artifacts.docker.0 }}. Placeholder names cannot contain hyphens, so
+// they are replaced with underscores in service names (see groupByService).
+func (r *BuildResult) PlaceholderValues() map[string]interface{} {
+	if r == nil {
+		return map[string]interface{}{}
+	}
+	return map[string]interface{}{
+		"Artifacts": groupByService(r.PushedArtifacts),
+		"Tags":      groupByService(r.Tags),
+	}
+}
+
+// groupByService returns results of every service keyed by their position
+// ("0", "1", ...) in the order of entries.
+func groupByService(entries []ResultEntry) map[string]interface{} {
+	sorted := make([]ResultEntry, len(entries))
+	copy(sorted, entries)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Entry < sorted[j].Entry
+	})
+
+	grouped := map[string]interface{}{}
+	for _, e := range sorted {
+		// Placeholder names may contain only letters, digits and "_", so
+		// "my-app" is available as {{ .Artifacts.my_app.0 }}
+		name := strings.ReplaceAll(e.Service, "-", "_")
+		values, ok := grouped[name].(map[string]interface{})
+		if !ok {
+			values = map[string]interface{}{}
+			grouped[name] = values
+		}
+		values[strconv.Itoa(len(values))] = e.Result
+	}
+	return grouped
+}
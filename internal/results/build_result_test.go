@@ -0,0 +1,223 @@
+package results
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/g2a-com/cicd/internal/object"
+	"github.com/g2a-com/cicd/internal/script"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func Test_loading_build_result(t *testing.T) {
+	testCases := []struct {
+		name     string
+		content  string
+		expected *BuildResult
+		err      string
+	}{
+		{
+			name:    "tags and artifacts",
+			content: `{"status": "succeeded", "tags": [{"service": "api", "entry": 0, "result": "v1"}], "pushedArtifacts": [{"service": "api", "entry": 1, "result": "app:v1", "metadata": {"digest": "sha256:1"}}]}`,
+			expected: &BuildResult{
+				Tags:            []ResultEntry{{Service: "api", Entry: 0, Result: "v1"}},
+				PushedArtifacts: []ResultEntry{{Service: "api", Entry: 1, Result: "app:v1", Metadata: map[string]interface{}{"digest": "sha256:1"}}},
+			},
+		},
+		{
+			name:     "empty result",
+			content:  `{}`,
+			expected: &BuildResult{},
+		},
+		{
+			name:    "invalid JSON",
+			content: `{"tags": {}}`,
+			err:     "cannot read build result",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			filename := filepath.Join(t.TempDir(), "build-result.json")
+			require.NoError(t, os.WriteFile(filename, []byte(tc.content), 0644))
+
+			result, err := LoadBuildResult(filename)
+
+			if tc.err != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expected, result)
+			}
+		})
+	}
+}
+
+func Test_loading_missing_build_result_fails(t *testing.T) {
+	_, err := LoadBuildResult(filepath.Join(t.TempDir(), "missing.json"))
+
+	require.Error(t, err)
+}
+
+func Test_validating_build_result(t *testing.T) {
+	api := newDeployService(t, `{
+		apiVersion: g2a-cli/v2.0, kind: Service, name: api,
+		tags: [ { git-tag: {} } ],
+		artifacts: [ { docker: {} }, { docker: {}, push: false }, { docker: {} } ],
+		releases: [ { helm: {} } ],
+	}`)
+	withoutBuild := newDeployService(t, `{
+		apiVersion: g2a-cli/v2.0, kind: Service, name: chart,
+		releases: [ { helm: {} } ],
+	}`)
+	withoutReleases := newDeployService(t, `{
+		apiVersion: g2a-cli/v2.0, kind: Service, name: lib,
+		artifacts: [ { docker: {} } ],
+	}`)
+
+	testCases := []struct {
+		name     string
+		result   BuildResult
+		services []object.Object
+		err      string
+	}{
+		{
+			name: "matching entries",
+			result: BuildResult{
+				Tags:            []ResultEntry{{Service: "api", Entry: 0, Result: "v1"}},
+				PushedArtifacts: []ResultEntry{{Service: "api", Entry: 0, Result: "a:v1"}, {Service: "api", Entry: 2, Result: "b:v1"}},
+			},
+			services: []object.Object{api},
+		},
+		{
+			name:     "only tags",
+			result:   BuildResult{Tags: []ResultEntry{{Service: "api", Entry: 0, Result: "v1"}}},
+			services: []object.Object{api},
+		},
+		{
+			name:     "services without build entries or releases",
+			result:   BuildResult{},
+			services: []object.Object{withoutBuild, withoutReleases},
+		},
+		{
+			name:     "missing service",
+			result:   BuildResult{Tags: []ResultEntry{{Service: "other", Entry: 0, Result: "v1"}}},
+			services: []object.Object{api},
+			err:      "build result doesn't match services:\n\ttags or artifacts of service \"api\" are missing",
+		},
+		{
+			name: "missing entries",
+			result: BuildResult{
+				Tags:            []ResultEntry{{Service: "api", Entry: 3, Result: "v1"}, {Service: "api", Entry: 3, Result: "v2"}},
+				PushedArtifacts: []ResultEntry{{Service: "api", Entry: 1, Result: "a:v1"}},
+			},
+			services: []object.Object{api},
+			err:      "build result doesn't match services:\n\ttag entry 3 of service \"api\" doesn't exist\n\tpush entry 1 of service \"api\" doesn't exist",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.result.Validate(tc.services)
+
+			if tc.err != "" {
+				require.EqualError(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_build_result_placeholder_values(t *testing.T) {
+	testCases := []struct {
+		name     string
+		result   *BuildResult
+		expected map[string]interface{}
+	}{
+		{
+			name:     "missing result",
+			result:   nil,
+			expected: map[string]interface{}{},
+		},
+		{
+			name:     "empty result",
+			result:   &BuildResult{},
+			expected: map[string]interface{}{"Artifacts": map[string]interface{}{}, "Tags": map[string]interface{}{}},
+		},
+		{
+			name: "results grouped by service in the order of entries",
+			result: &BuildResult{
+				Tags: []ResultEntry{{Service: "api", Entry: 0, Result: "v1"}},
+				PushedArtifacts: []ResultEntry{
+					{Service: "my-app", Entry: 2, Result: "c"},
+					{Service: "api", Entry: 0, Result: "a"},
+					{Service: "my-app", Entry: 0, Result: "b"},
+				},
+			},
+			expected: map[string]interface{}{
+				"Artifacts": map[string]interface{}{
+					"api":    map[string]interface{}{"0": "a"},
+					"my_app": map[string]interface{}{"0": "b", "1": "c"},
+				},
+				"Tags": map[string]interface{}{
+					"api": map[string]interface{}{"0": "v1"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, tc.result.PlaceholderValues())
+		})
+	}
+}
+
+func Test_getting_artifacts_of_service(t *testing.T) {
+	api := newDeployService(t, `{ apiVersion: g2a-cli/v2.0, kind: Service, name: api }`)
+
+	testCases := []struct {
+		name     string
+		result   *BuildResult
+		expected []script.Result
+	}{
+		{
+			name:     "missing result",
+			result:   nil,
+			expected: []script.Result{},
+		},
+		{
+			name: "artifacts of other services are skipped",
+			result: &BuildResult{
+				Tags: []ResultEntry{{Service: "api", Entry: 0, Result: "v1"}},
+				PushedArtifacts: []ResultEntry{
+					{Service: "api", Entry: 0, Result: "a", Metadata: map[string]interface{}{"digest": "sha256:1"}},
+					{Service: "other", Entry: 0, Result: "b"},
+					{Service: "api", Entry: 1, Result: "c"},
+				},
+			},
+			expected: []script.Result{
+				{ID: "a", Metadata: map[string]interface{}{"digest": "sha256:1"}},
+				{ID: "c"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, tc.result.Artifacts(api))
+		})
+	}
+}
+
+func newDeployService(t *testing.T, content string) object.Object {
+	node := &yaml.Node{}
+	require.NoError(t, yaml.Unmarshal([]byte(content), node))
+	service, err := object.NewDeployService("service.yaml", node)
+	require.NoError(t, err)
+	return service
+}
@@ -0,0 +1,162 @@
+package results
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/g2a-com/cicd/internal/object"
+)
+
+type Status string
+
+const (
+	Succeeded Status = "succeeded"
+	Failed    Status = "failed"
+	Skipped   Status = "skipped"
+	TimedOut  Status = "timedOut"
+)
+
+// Entry describes execution of a single service entry.
+type Entry struct {
+	// Service is empty for entries of the project (tasks run by run command)
+	Service      string      `json:"service,omitempty"`
+	Type         string      `json:"type"`
+	Entry        int         `json:"entry"`
+	ExecutorKind object.Kind `json:"executorKind"`
+	ExecutorName string      `json:"executorName"`
+	Status       Status      `json:"status"`
+	Error        string      `json:"error,omitempty"`
+	StartTime    *time.Time  `json:"startTime,omitempty"`
+	EndTime      *time.Time  `json:"endTime,omitempty"`
+	Duration     float64     `json:"duration,omitempty"`
+}
+
+// ResultEntry is a value (e.g. a tag or an artifact) returned by an entry of
+// the service, as stored in the result file of build command.
+type ResultEntry struct {
+	Service  string                 `json:"service"`
+	Entry    int                    `json:"entry"`
+	Result   string                 `json:"result"`
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// Summary is a part of the result file describing outcome of the whole
+// command, and of all executed entries. It is safe for concurrent use.
+type Summary struct {
+	Status  Status  `json:"status"`
+	Error   string  `json:"error,omitempty"`
+	Entries []Entry `json:"entries"`
+
+	// EntryTimeout is a time limit for entries which don't define their own
+	// one. 0 means no limit.
+	EntryTimeout time.Duration `json:"-"`
+
+	mutex sync.Mutex
+}
+
+// Run calls fn and records its outcome as a result of the entry. Context
+// passed to fn is done once time limit of the entry is exceeded, in which case
+// the entry is recorded as timed out. Error returned by fn is passed through.
+func (s *Summary) Run(ctx context.Context, service object.Object, entryType string, entry object.Entry, fn func(context.Context) error) error {
+	timeout := entry.Timeout()
+	if timeout == 0 {
+		timeout = s.EntryTimeout
+	}
+	var entryCtx context.Context
+	var cancel context.CancelFunc
+	if timeout > 0 {
+		entryCtx, cancel = context.WithTimeout(ctx, timeout)
+	} else {
+		entryCtx, cancel = context.WithCancel(ctx)
+	}
+	defer cancel()
+
+	start := time.Now()
+	err := fn(entryCtx)
+	end := time.Now()
+
+	e := newEntry(service, entryType, entry, Succeeded)
+	e.StartTime = &start
+	e.EndTime = &end
+	e.Duration = end.Sub(start).Seconds()
+	if err != nil && entryCtx.Err() == context.DeadlineExceeded {
+		e.Status = TimedOut
+		e.Error = err.Error()
+	} else if err != nil {
+		e.Status = Failed
+		e.Error = err.Error()
+	}
+	s.add(e)
+
+	return err
+}
+
+// RunEntries calls fn for all entries of the specified type. It stops at the
+// first failure, remaining entries are recorded as skipped.
+func (s *Summary) RunEntries(ctx context.Context, service object.Object, entryType string, fn func(context.Context, object.Entry) error) error {
+	entries := service.Entries(entryType)
+	for i, entry := range entries {
+		err := s.Run(ctx, service, entryType, entry, func(ctx context.Context) error {
+			return fn(ctx, entry)
+		})
+		if err != nil {
+			for _, e := range entries[i+1:] {
+				s.add(newEntry(service, entryType, e, Skipped))
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// SkipEntries records that entries of the specified types were not executed.
+func (s *Summary) SkipEntries(service object.Object, entryTypes ...string) {
+	for _, entryType := range entryTypes {
+		for _, entry := range service.Entries(entryType) {
+			s.add(newEntry(service, entryType, entry, Skipped))
+		}
+	}
+}
+
+// Finish sets overall status of the command. Reason should be a value
+// returned by recover(), nil means that command succeeded.
+func (s *Summary) Finish(reason interface{}) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if reason == nil {
+		s.Status = Succeeded
+		s.Error = ""
+	} else {
+		s.Status = Failed
+		s.Error = fmt.Sprint(reason)
+	}
+}
+
+func (s *Summary) add(e Entry) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.Entries = append(s.Entries, e)
+	sort.SliceStable(s.Entries, func(i, j int) bool {
+		return s.Entries[i].Service < s.Entries[j].Service
+	})
+}
+
+func newEntry(service object.Object, entryType string, entry object.Entry, status Status) Entry {
+	name := service.Name()
+	if service.Kind() == object.ProjectKind {
+		name = ""
+	}
+	return Entry{
+		Service:      name,
+		Type:         entryType,
+		Entry:        entry.Index(),
+		ExecutorKind: entry.ExecutorKind(),
+		ExecutorName: entry.ExecutorName(),
+		Status:       status,
+	}
+}
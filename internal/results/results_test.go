@@ -0,0 +1,142 @@
+package results
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/g2a-com/cicd/internal/object"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func Test_running_entry_records_its_status_and_timings(t *testing.T) {
+	s := &Summary{}
+	service := newService("api", "[{ first: {} }]")
+
+	err := s.Run(context.Background(), service, object.DeployEntryType, service.Entries(object.DeployEntryType)[0], func(context.Context) error {
+		return nil
+	})
+
+	assert.NoError(t, err)
+	if assert.Len(t, s.Entries, 1) {
+		e := s.Entries[0]
+		assert.Equal(t, "api", e.Service)
+		assert.Equal(t, "deploy", e.Type)
+		assert.Equal(t, 0, e.Entry)
+		assert.Equal(t, object.DeployerKind, e.ExecutorKind)
+		assert.Equal(t, "first", e.ExecutorName)
+		assert.Equal(t, Succeeded, e.Status)
+		assert.Empty(t, e.Error)
+		assert.NotNil(t, e.StartTime)
+		assert.NotNil(t, e.EndTime)
+		assert.False(t, e.EndTime.Before(*e.StartTime))
+	}
+}
+
+func Test_running_entries_skips_entries_after_failure(t *testing.T) {
+	s := &Summary{}
+	service := newService("api", "[{ first: {} }, { second: {} }, { third: {} }]")
+
+	err := s.RunEntries(context.Background(), service, object.DeployEntryType, func(_ context.Context, entry object.Entry) error {
+		if entry.ExecutorName() == "second" {
+			return errors.New("failure")
+		}
+		return nil
+	})
+
+	assert.EqualError(t, err, "failure")
+	if assert.Len(t, s.Entries, 3) {
+		assert.Equal(t, Succeeded, s.Entries[0].Status)
+		assert.Equal(t, Failed, s.Entries[1].Status)
+		assert.Equal(t, "failure", s.Entries[1].Error)
+		assert.Equal(t, Skipped, s.Entries[2].Status)
+		assert.Nil(t, s.Entries[2].StartTime)
+	}
+}
+
+func Test_entries_exceeding_their_time_limit_are_recorded_as_timed_out(t *testing.T) {
+	s := &Summary{}
+	service := newService("api", "[{ first: {}, timeout: 10ms }]")
+
+	err := s.RunEntries(context.Background(), service, object.DeployEntryType, func(ctx context.Context, entry object.Entry) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	assert.EqualError(t, err, "context deadline exceeded")
+	if assert.Len(t, s.Entries, 1) {
+		assert.Equal(t, TimedOut, s.Entries[0].Status)
+		assert.Equal(t, "context deadline exceeded", s.Entries[0].Error)
+	}
+}
+
+func Test_default_time_limit_is_used_for_entries_without_their_own_one(t *testing.T) {
+	s := &Summary{EntryTimeout: 10 * time.Millisecond}
+	service := newService("api", "[{ first: {} }]")
+
+	err := s.RunEntries(context.Background(), service, object.DeployEntryType, func(ctx context.Context, entry object.Entry) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	assert.Error(t, err)
+	if assert.Len(t, s.Entries, 1) {
+		assert.Equal(t, TimedOut, s.Entries[0].Status)
+	}
+}
+
+func Test_skipping_entries_records_all_of_them(t *testing.T) {
+	s := &Summary{}
+	service := newService("api", "[{ first: {} }, { second: {} }]")
+
+	s.SkipEntries(service, object.DeployEntryType)
+
+	assert.Len(t, s.Entries, 2)
+	for _, e := range s.Entries {
+		assert.Equal(t, Skipped, e.Status)
+	}
+}
+
+func Test_finishing_sets_overall_status(t *testing.T) {
+	s := &Summary{}
+
+	s.Finish(nil)
+	assert.Equal(t, Succeeded, s.Status)
+
+	s.Finish(errors.New("failure"))
+	assert.Equal(t, Failed, s.Status)
+	assert.Equal(t, "failure", s.Error)
+}
+
+func Test_entries_of_project_tasks_have_no_service(t *testing.T) {
+	s := &Summary{}
+	var node yaml.Node
+	_ = yaml.Unmarshal([]byte(`{ kind: Project, name: test, tasks: { lint: [ { first: {} } ] } }`), &node)
+	project, _ := object.NewRunProject("file.yaml", &node)
+
+	err := s.RunEntries(context.Background(), project, "lint", func(context.Context, object.Entry) error {
+		return nil
+	})
+
+	assert.NoError(t, err)
+	if assert.Len(t, s.Entries, 1) {
+		assert.Equal(t, "", s.Entries[0].Service)
+		assert.Equal(t, "lint", s.Entries[0].Type)
+		assert.Equal(t, Succeeded, s.Entries[0].Status)
+	}
+}
+
+func newService(name string, releases string) object.Object {
+	var node yaml.Node
+	err := yaml.Unmarshal([]byte(`{ kind: Service, name: `+name+`, releases: `+releases+` }`), &node)
+	if err != nil {
+		panic(err)
+	}
+	service, err := object.NewObject("deploy", "file.yaml", &node)
+	if err != nil {
+		panic(err)
+	}
+	return service
+}
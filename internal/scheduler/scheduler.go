@@ -0,0 +1,212 @@
+package scheduler
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/g2a-com/cicd/internal/object"
+	log "github.com/g2a-com/klio-logger-go/v2"
+	"github.com/hashicorp/go-multierror"
+)
+
+// Job is a function processing single service. Logger passed to the job
+// should be used for all output related to the service.
+type Job func(service object.Object, l log.Logger) error
+
+// Scheduler runs jobs for services using a bounded number of workers.
+type Scheduler struct {
+	// Concurrency is a maximal number of services processed at the same time.
+	// Values lower than 2 make scheduler process services sequentially.
+	Concurrency int
+	// Logger is used as a base for loggers passed to jobs.
+	Logger log.Logger
+	// KeepGoing makes scheduler start remaining jobs after a failure. Only
+	// services depending on failed ones are skipped. Returned errors are
+	// prefixed with names of the failed services.
+	KeepGoing bool
+	// OnSkip is called for every service skipped because some of services it
+	// depends on failed. It's used only if KeepGoing is set.
+	OnSkip func(service object.Object)
+	// Context stops scheduler from starting new jobs once it's done (e.g.
+	// because command was interrupted). Defaults to context.Background().
+	Context context.Context
+
+	mutex sync.Mutex
+}
+
+// Run calls the job for every service. Jobs are started in the order of the
+// list, but a job for a service is started only after jobs for all services it
+// depends on (and which are present in the list) have succeeded. Once any job
+// fails (unless KeepGoing is set) or the Context is done, no more jobs are
+// started, but already running ones are allowed to finish. When jobs are run
+// concurrently, logs of each job are buffered and printed after it finishes,
+// so they are not interleaved with logs of other jobs.
+func (s *Scheduler) Run(services []object.Object, job Job) error {
+	type jobResult struct {
+		service object.Object
+		err     error
+	}
+
+	var errs error
+
+	ctx := s.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	concurrency := s.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	pending := make([]object.Object, len(services))
+	copy(pending, services)
+
+	listed := map[string]bool{}
+	for _, service := range services {
+		listed[service.Name()] = true
+	}
+
+	done := map[string]bool{}
+	failed := map[string]bool{}
+	finished := make(chan jobResult)
+	running := 0
+
+	for {
+		for (errs == nil || s.KeepGoing) && running < concurrency && ctx.Err() == nil {
+			i := nextReady(pending, listed, done)
+			if i < 0 {
+				break
+			}
+
+			service := pending[i]
+			pending = append(pending[:i], pending[i+1:]...)
+			running++
+
+			go func() {
+				finished <- jobResult{service, s.runJob(service, job)}
+			}()
+		}
+
+		if running == 0 {
+			break
+		}
+
+		r := <-finished
+		running--
+
+		if r.err != nil && s.KeepGoing {
+			errs = multierror.Append(errs, fmt.Errorf("service %q: %w", r.service.Name(), r.err))
+			failed[r.service.Name()] = true
+			pending = s.skipBlocked(pending, failed)
+		} else if r.err != nil {
+			errs = multierror.Append(errs, r.err)
+		} else {
+			done[r.service.Name()] = true
+		}
+	}
+
+	if (errs == nil || s.KeepGoing) && len(pending) > 0 {
+		names := make([]string, len(pending))
+		for i, service := range pending {
+			names[i] = service.Name()
+		}
+		if ctx.Err() != nil {
+			errs = multierror.Append(errs, fmt.Errorf("services were not processed (%s): %s", ctx.Err(), strings.Join(names, ", ")))
+		} else {
+			errs = multierror.Append(errs, fmt.Errorf("cannot resolve dependencies of services: %s", strings.Join(names, ", ")))
+		}
+	}
+
+	if merr, ok := errs.(*multierror.Error); ok && len(merr.Errors) == 1 {
+		return merr.Errors[0]
+	}
+	return errs
+}
+
+// skipBlocked removes services depending (directly or not) on failed ones
+// from the pending list. Skipped services are marked as failed.
+func (s *Scheduler) skipBlocked(pending []object.Object, failed map[string]bool) []object.Object {
+	for changed := true; changed; {
+		changed = false
+		for i := 0; i < len(pending); i++ {
+			service := pending[i]
+			if !dependsOnAny(service, failed) {
+				continue
+			}
+
+			failed[service.Name()] = true
+			pending = append(pending[:i], pending[i+1:]...)
+			i--
+			changed = true
+
+			if s.OnSkip != nil {
+				s.OnSkip(service)
+			}
+		}
+	}
+	return pending
+}
+
+func dependsOnAny(service object.Object, names map[string]bool) bool {
+	if s, ok := service.(object.Service); ok {
+		for _, dep := range s.DependsOn() {
+			if names[dep] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// nextReady returns index of the first service which dependencies are done,
+// or -1 if there is no such service.
+func nextReady(services []object.Object, listed map[string]bool, done map[string]bool) int {
+	for i, service := range services {
+		ready := true
+		if s, ok := service.(object.Service); ok {
+			for _, dep := range s.DependsOn() {
+				if listed[dep] && !done[dep] {
+					ready = false
+					break
+				}
+			}
+		}
+		if ready {
+			return i
+		}
+	}
+	return -1
+}
+
+func (s *Scheduler) runJob(service object.Object, job Job) (err error) {
+	l := s.Logger
+
+	if s.Concurrency > 1 {
+		buf := &bytes.Buffer{}
+		l = l.WithOutput(buf)
+		defer s.flush(buf)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			if e, ok := r.(error); ok {
+				err = e
+			} else {
+				err = fmt.Errorf("%v", r)
+			}
+		}
+	}()
+
+	return job(service, l)
+}
+
+func (s *Scheduler) flush(buf *bytes.Buffer) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.Logger.Output().Write(buf.Bytes())
+}
@@ -0,0 +1,257 @@
+package scheduler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/g2a-com/cicd/internal/object"
+	log "github.com/g2a-com/klio-logger-go/v2"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func Test_jobs_are_run_for_all_services(t *testing.T) {
+	services := newServices("a", "b", "c")
+	scheduler := &Scheduler{Concurrency: 2, Logger: log.New(&bytes.Buffer{})}
+	processed := map[string]bool{}
+	mutex := sync.Mutex{}
+
+	err := scheduler.Run(services, func(service object.Object, l log.Logger) error {
+		mutex.Lock()
+		defer mutex.Unlock()
+		processed[service.Name()] = true
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]bool{"a": true, "b": true, "c": true}, processed)
+}
+
+func Test_jobs_are_run_sequentially_by_default(t *testing.T) {
+	services := newServices("a", "b", "c")
+	scheduler := &Scheduler{Logger: log.New(&bytes.Buffer{})}
+	order := []string{}
+
+	err := scheduler.Run(services, func(service object.Object, l log.Logger) error {
+		order = append(order, service.Name())
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c"}, order)
+}
+
+func Test_number_of_concurrently_run_jobs_is_limited(t *testing.T) {
+	services := newServices("a", "b", "c", "d", "e", "f")
+	scheduler := &Scheduler{Concurrency: 2, Logger: log.New(&bytes.Buffer{})}
+	running, maxRunning := 0, 0
+	mutex := sync.Mutex{}
+
+	err := scheduler.Run(services, func(service object.Object, l log.Logger) error {
+		mutex.Lock()
+		running++
+		if running > maxRunning {
+			maxRunning = running
+		}
+		mutex.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+
+		mutex.Lock()
+		running--
+		mutex.Unlock()
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, maxRunning)
+}
+
+func Test_no_more_jobs_are_started_after_a_failure(t *testing.T) {
+	services := newServices("a", "b", "c")
+	scheduler := &Scheduler{Logger: log.New(&bytes.Buffer{})}
+	order := []string{}
+
+	err := scheduler.Run(services, func(service object.Object, l log.Logger) error {
+		order = append(order, service.Name())
+		if service.Name() == "b" {
+			return errors.New("failure")
+		}
+		return nil
+	})
+
+	assert.EqualError(t, err, "failure")
+	assert.Equal(t, []string{"a", "b"}, order)
+}
+
+func Test_no_more_jobs_are_started_after_context_is_done(t *testing.T) {
+	services := newServices("a", "b", "c")
+	ctx, cancel := context.WithCancel(context.Background())
+	scheduler := &Scheduler{Logger: log.New(&bytes.Buffer{}), Context: ctx}
+	order := []string{}
+
+	err := scheduler.Run(services, func(service object.Object, l log.Logger) error {
+		order = append(order, service.Name())
+		cancel()
+		return nil
+	})
+
+	assert.EqualError(t, err, "services were not processed (context canceled): b, c")
+	assert.Equal(t, []string{"a"}, order)
+}
+
+func Test_panics_in_jobs_are_returned_as_errors(t *testing.T) {
+	services := newServices("a")
+	scheduler := &Scheduler{Concurrency: 2, Logger: log.New(&bytes.Buffer{})}
+
+	err := scheduler.Run(services, func(service object.Object, l log.Logger) error {
+		panic("failure")
+	})
+
+	assert.EqualError(t, err, "failure")
+}
+
+func Test_jobs_wait_for_dependencies_to_finish(t *testing.T) {
+	services := []object.Object{
+		newService("base"),
+		newService("a", "base"),
+		newService("b", "base"),
+		newService("other"),
+	}
+	scheduler := &Scheduler{Concurrency: 4, Logger: log.New(&bytes.Buffer{})}
+	finished := map[string]bool{}
+	mutex := sync.Mutex{}
+
+	err := scheduler.Run(services, func(service object.Object, l log.Logger) error {
+		for _, dep := range service.(object.Service).DependsOn() {
+			mutex.Lock()
+			assert.True(t, finished[dep], "%s started before %s finished", service.Name(), dep)
+			mutex.Unlock()
+		}
+		time.Sleep(10 * time.Millisecond)
+		mutex.Lock()
+		finished[service.Name()] = true
+		mutex.Unlock()
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, finished, 4)
+}
+
+func Test_jobs_depending_on_failed_service_are_not_started(t *testing.T) {
+	services := []object.Object{
+		newService("a"),
+		newService("b", "a"),
+	}
+	scheduler := &Scheduler{Concurrency: 2, Logger: log.New(&bytes.Buffer{})}
+	order := []string{}
+
+	err := scheduler.Run(services, func(service object.Object, l log.Logger) error {
+		order = append(order, service.Name())
+		return errors.New("failure")
+	})
+
+	assert.EqualError(t, err, "failure")
+	assert.Equal(t, []string{"a"}, order)
+}
+
+func Test_logs_of_concurrently_run_jobs_are_grouped(t *testing.T) {
+	services := newServices("a", "b", "c")
+	output := &bytes.Buffer{}
+	scheduler := &Scheduler{Concurrency: 3, Logger: log.New(output)}
+
+	err := scheduler.Run(services, func(service object.Object, l log.Logger) error {
+		for i := 0; i < 3; i++ {
+			l.Print(service.Name())
+			time.Sleep(time.Millisecond)
+		}
+		return nil
+	})
+
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+	assert.Len(t, lines, 9)
+	for i := 0; i < len(lines); i += 3 {
+		assert.Equal(t, lines[i], lines[i+1])
+		assert.Equal(t, lines[i], lines[i+2])
+	}
+}
+
+func newServices(names ...string) []object.Object {
+	services := make([]object.Object, len(names))
+	for i, name := range names {
+		services[i] = newService(name)
+	}
+	return services
+}
+
+func newService(name string, dependsOn ...string) object.Object {
+	var node yaml.Node
+	deps, _ := json.Marshal(dependsOn)
+	err := yaml.Unmarshal([]byte(fmt.Sprintf(`{ kind: Service, name: %q, dependsOn: %s }`, name, deps)), &node)
+	if err != nil {
+		panic(err)
+	}
+	service, err := object.NewObject("build", "file.yaml", &node)
+	if err != nil {
+		panic(err)
+	}
+	return service
+}
+
+func Test_jobs_are_started_after_a_failure_in_keep_going_mode(t *testing.T) {
+	services := newServices("a", "b", "c")
+	scheduler := &Scheduler{KeepGoing: true, Logger: log.New(&bytes.Buffer{})}
+	order := []string{}
+
+	err := scheduler.Run(services, func(service object.Object, l log.Logger) error {
+		order = append(order, service.Name())
+		if service.Name() != "b" {
+			return errors.New("failure")
+		}
+		return nil
+	})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), `service "a": failure`)
+	assert.Contains(t, err.Error(), `service "c": failure`)
+	assert.Equal(t, []string{"a", "b", "c"}, order)
+}
+
+func Test_jobs_depending_on_failed_service_are_skipped_in_keep_going_mode(t *testing.T) {
+	services := []object.Object{
+		newService("a"),
+		newService("b", "a"),
+		newService("c", "b"),
+		newService("other"),
+	}
+	skipped := []string{}
+	scheduler := &Scheduler{
+		KeepGoing: true,
+		Logger:    log.New(&bytes.Buffer{}),
+		OnSkip: func(service object.Object) {
+			skipped = append(skipped, service.Name())
+		},
+	}
+	order := []string{}
+
+	err := scheduler.Run(services, func(service object.Object, l log.Logger) error {
+		order = append(order, service.Name())
+		if service.Name() == "a" {
+			return errors.New("failure")
+		}
+		return nil
+	})
+
+	assert.EqualError(t, err, `service "a": failure`)
+	assert.Equal(t, []string{"a", "other"}, order)
+	assert.Equal(t, []string{"b", "c"}, skipped)
+}
@@ -0,0 +1,114 @@
+package schema
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/qri-io/jsonschema"
+	"gopkg.in/yaml.v3"
+)
+
+// ValidationError describes a single problem found in a configuration file.
+type ValidationError struct {
+	Filename string `json:"file,omitempty"`
+	Line     int    `json:"line,omitempty"`
+	Pointer  string `json:"pointer,omitempty"`
+	Message  string `json:"message"`
+}
+
+// NewValidationError creates error for the value pointed by the JSON pointer
+// within the document node.
+func NewValidationError(filename string, node *yaml.Node, pointer string, message string) *ValidationError {
+	return &ValidationError{
+		Filename: filename,
+		Line:     FindLine(node, pointer),
+		Pointer:  pointer,
+		Message:  message,
+	}
+}
+
+func newValidationErrorFromKeyError(node *yaml.Node, e jsonschema.KeyError) *ValidationError {
+	return NewValidationError("", node, e.PropertyPath, KeyErrorMessage(e))
+}
+
+// KeyErrorMessage returns message of the JSON schema error without the
+// property path.
+func KeyErrorMessage(e jsonschema.KeyError) string {
+	if e.InvalidValue != nil {
+		return jsonschema.InvalidValueString(e.InvalidValue) + " " + e.Message
+	}
+	return e.Message
+}
+
+// Location returns "file:line" string pointing to the problem.
+func (e *ValidationError) Location() string {
+	if e.Filename == "" {
+		return ""
+	}
+	if e.Line == 0 {
+		return e.Filename
+	}
+	return fmt.Sprintf("%s:%d", e.Filename, e.Line)
+}
+
+func (e *ValidationError) Error() string {
+	msg := e.Message
+	if e.Pointer != "" && e.Pointer != "/" {
+		msg = fmt.Sprintf("%s: %s", e.Pointer, msg)
+	}
+	if location := e.Location(); location != "" {
+		msg = fmt.Sprintf("%s\n\t  at %s", msg, location)
+	}
+	return msg
+}
+
+// FindLine returns line number of the value pointed by JSON pointer (for
+// object properties line of the key is used). If there is no such value,
+// line of the closest existing parent is returned. Nodes created by migration
+// have no line, line of their closest parent is used as well.
+func FindLine(node *yaml.Node, pointer string) int {
+	if node == nil {
+		return 0
+	}
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+
+	line := node.Line
+
+	for _, token := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		if token == "" {
+			continue
+		}
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+
+		var next *yaml.Node
+		switch node.Kind {
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if node.Content[i].Value == token {
+					next = node.Content[i+1]
+					if node.Content[i].Line != 0 {
+						line = node.Content[i].Line
+					}
+					break
+				}
+			}
+		case yaml.SequenceNode:
+			if i, err := strconv.Atoi(token); err == nil && i >= 0 && i < len(node.Content) {
+				next = node.Content[i]
+				if next.Line != 0 {
+					line = next.Line
+				}
+			}
+		}
+
+		if next == nil {
+			break
+		}
+		node = next
+	}
+
+	return line
+}
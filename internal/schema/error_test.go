@@ -0,0 +1,42 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func Test_finding_line_of_value_pointed_by_json_pointer(t *testing.T) {
+	var node yaml.Node
+	_ = yaml.Unmarshal([]byte("name: test\nreleases:\n  - a/b: {}\n  - helm:\n      name: x\n"), &node)
+
+	assert.Equal(t, 1, FindLine(&node, ""))
+	assert.Equal(t, 1, FindLine(&node, "/name"))
+	assert.Equal(t, 3, FindLine(&node, "/releases/0"))
+	assert.Equal(t, 3, FindLine(&node, "/releases/0/a~1b"))
+	assert.Equal(t, 5, FindLine(&node, "/releases/1/helm/name"))
+}
+
+func Test_finding_line_of_missing_value_returns_line_of_closest_parent(t *testing.T) {
+	var node yaml.Node
+	_ = yaml.Unmarshal([]byte("name: test\nreleases:\n  - helm:\n      name: x\n"), &node)
+
+	assert.Equal(t, 3, FindLine(&node, "/releases/0/helm/missing"))
+	assert.Equal(t, 2, FindLine(&node, "/releases/7"))
+}
+
+func Test_finding_line_of_node_without_position_returns_line_of_closest_parent(t *testing.T) {
+	var node yaml.Node
+	_ = yaml.Unmarshal([]byte("name: test\nreleases:\n  - helm:\n      name: x\n"), &node)
+	releases := node.Content[0].Content[3]
+	releases.Content = append(releases.Content, createMappingNode(createStrScalarNode("script"), createMappingNode()))
+
+	assert.Equal(t, 2, FindLine(&node, "/releases/1/script"))
+}
+
+func Test_validation_error_message_contains_pointer_and_location(t *testing.T) {
+	err := &ValidationError{Filename: "dir/file.yaml", Line: 3, Pointer: "/name", Message: "invalid name"}
+
+	assert.Equal(t, "/name: invalid name\n\t  at dir/file.yaml:3", err.Error())
+}
@@ -36,6 +36,15 @@ func Migrate(input []byte) ([]byte, error) {
 	return NewMigrator(LatestVersion).Migrate(input)
 }
 
+// MigrateDocument updates decoded YAML document to the latest version in
+// place. Unlike Migrate, it keeps positions of nodes moved by the migration,
+// so problems found in the migrated document can point to lines of the source
+// file. Keep in mind that document should be pre-validated.
+func MigrateDocument(document *yaml.Node) error {
+	m := &migrator{LatestVersion}
+	return m.migrateDocument(document)
+}
+
 type migrator struct {
 	TargetVersion string
 }
@@ -49,7 +58,7 @@ func NewMigrator(targetVersion string) Migrator {
 func (m *migrator) Migrate(input []byte) ([]byte, error) {
 	result := &bytes.Buffer{}
 	decoder := yaml.NewDecoder(bytes.NewReader(input))
-	encoder := yaml.NewEncoder(result)
+	encoder := NewEncoder(result)
 
 	for {
 		var document yaml.Node
@@ -76,6 +85,13 @@ func (m *migrator) Migrate(input []byte) ([]byte, error) {
 	return result.Bytes(), nil
 }
 
+// NewEncoder creates YAML encoder for migrated documents. Everything which
+// writes migrated documents should use it, so they are formatted the same
+// way.
+func NewEncoder(w io.Writer) *yaml.Encoder {
+	return yaml.NewEncoder(w)
+}
+
 func (m *migrator) migrateDocument(rootNode *yaml.Node) error {
 	if rootNode.Kind == yaml.DocumentNode {
 		return m.migrateDocument(rootNode.Content[0])
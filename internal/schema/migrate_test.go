@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
 )
 
 func Test_migrating_empty_service_from_v1beta4_to_v2_0(t *testing.T) {
@@ -431,6 +432,30 @@ func Test_migrating_environment_from_v1beta4_to_v2_0_replaces_legacy_placeholder
 
 // testInput validates input against schema and returns it back. Use only in
 // tests.
+func Test_migrating_document_keeps_lines_of_moved_nodes(t *testing.T) {
+	var document yaml.Node
+	_ = yaml.Unmarshal([]byte(`apiVersion: g2a-cli/v1beta4
+kind: Service
+name: test
+build:
+  artifacts:
+    - script:
+        sh: echo build
+deploy:
+  releases:
+    - script:
+        sh: echo deploy
+`), &document)
+
+	err := MigrateDocument(&document)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "g2a-cli/v2.0", findMapValue(document.Content[0], "apiVersion").Value)
+	assert.Equal(t, 6, FindLine(&document, "/artifacts/0/script"))
+	assert.Equal(t, 7, FindLine(&document, "/artifacts/0/script/sh"))
+	assert.Equal(t, 11, FindLine(&document, "/releases/0/script/sh"))
+}
+
 func testInput(input string) string {
 	_, err := Validate([]byte(input))
 	if err != nil {
@@ -399,10 +399,99 @@ var SCHEMAS = map[string][]byte{
 		  "type": "object",
 		  "additionalProperties": true,
 		  "required": [
+		    "status",
 		    "artifacts",
 		    "pushed"
 		  ],
 		  "properties": {
+		    "status": {
+		      "description": "Outcome of the command or an entry.",
+		      "enum": [
+		        "succeeded",
+		        "failed",
+		        "skipped",
+		        "timedOut"
+		      ]
+		    },
+		    "error": {
+		      "description": "Error which caused the command to fail, present only if status is \"failed\".",
+		      "type": "string"
+		    },
+		    "entries": {
+		      "description": "Outcome of each processed entry. Entries which were not executed (e.g. because previous entry of the same service failed) have \"skipped\" status, entries interrupted because of exceeded time limit have \"timedOut\" status. Entries of tasks defined in the project don't have \"service\".\n",
+		      "type": "array",
+		      "items": {
+		        "examples": [
+		          {
+		            "service": "generic-service",
+		            "type": "build",
+		            "entry": 0,
+		            "executorKind": "Builder",
+		            "executorName": "docker",
+		            "status": "succeeded",
+		            "startTime": "2022-03-01T12:00:00Z",
+		            "endTime": "2022-03-01T12:00:05.5Z",
+		            "duration": 5.5
+		          }
+		        ],
+		        "type": "object",
+		        "additionalProperties": true,
+		        "required": [
+		          "type",
+		          "entry",
+		          "executorKind",
+		          "executorName",
+		          "status"
+		        ],
+		        "properties": {
+		          "service": {
+		            "description": "Name of the object, unique within the kind.",
+		            "type": "string",
+		            "minLength": 1,
+		            "pattern": "^[a-z][A-Za-z0-9_-]*$"
+		          },
+		          "type": {
+		            "description": "Type of the entry (e.g. \"tag\", \"build\", \"push\" or \"deploy\").",
+		            "type": "string"
+		          },
+		          "entry": {
+		            "type": "integer",
+		            "min": 0
+		          },
+		          "executorKind": {
+		            "type": "string"
+		          },
+		          "executorName": {
+		            "type": "string"
+		          },
+		          "status": {
+		            "description": "Outcome of the command or an entry.",
+		            "enum": [
+		              "succeeded",
+		              "failed",
+		              "skipped",
+		              "timedOut"
+		            ]
+		          },
+		          "error": {
+		            "description": "Error message, present only if entry failed.",
+		            "type": "string"
+		          },
+		          "startTime": {
+		            "type": "string",
+		            "format": "date-time"
+		          },
+		          "endTime": {
+		            "type": "string",
+		            "format": "date-time"
+		          },
+		          "duration": {
+		            "description": "Duration of the entry in seconds.",
+		            "type": "number"
+		          }
+		        }
+		      }
+		    },
 		    "tags": {
 		      "type": "array",
 		      "items": {
@@ -411,6 +500,14 @@ var SCHEMAS = map[string][]byte{
 		            "service": "generic-service",
 		            "entry": 0,
 		            "result": "some result"
+		          },
+		          {
+		            "service": "generic-service",
+		            "entry": 0,
+		            "result": "registry/app:1.0.0",
+		            "metadata": {
+		              "digest": "sha256:4f5e..."
+		            }
 		          }
 		        ],
 		        "type": "object",
@@ -428,6 +525,11 @@ var SCHEMAS = map[string][]byte{
 		          },
 		          "result": {
 		            "type": "string"
+		          },
+		          "metadata": {
+		            "description": "Metadata added by the executor together with the result (e.g. digest of a built image). Present only for artifacts reported as maps with the \"id\" field, which is stored as the result.\n",
+		            "type": "object",
+		            "additionalProperties": true
 		          }
 		        }
 		      }
@@ -440,6 +542,14 @@ var SCHEMAS = map[string][]byte{
 		            "service": "generic-service",
 		            "entry": 0,
 		            "result": "some result"
+		          },
+		          {
+		            "service": "generic-service",
+		            "entry": 0,
+		            "result": "registry/app:1.0.0",
+		            "metadata": {
+		              "digest": "sha256:4f5e..."
+		            }
 		          }
 		        ],
 		        "type": "object",
@@ -457,6 +567,11 @@ var SCHEMAS = map[string][]byte{
 		          },
 		          "result": {
 		            "type": "string"
+		          },
+		          "metadata": {
+		            "description": "Metadata added by the executor together with the result (e.g. digest of a built image). Present only for artifacts reported as maps with the \"id\" field, which is stored as the result.\n",
+		            "type": "object",
+		            "additionalProperties": true
 		          }
 		        }
 		      }
@@ -469,6 +584,14 @@ var SCHEMAS = map[string][]byte{
 		            "service": "generic-service",
 		            "entry": 0,
 		            "result": "some result"
+		          },
+		          {
+		            "service": "generic-service",
+		            "entry": 0,
+		            "result": "registry/app:1.0.0",
+		            "metadata": {
+		              "digest": "sha256:4f5e..."
+		            }
 		          }
 		        ],
 		        "type": "object",
@@ -486,9 +609,24 @@ var SCHEMAS = map[string][]byte{
 		          },
 		          "result": {
 		            "type": "string"
+		          },
+		          "metadata": {
+		            "description": "Metadata added by the executor together with the result (e.g. digest of a built image). Present only for artifacts reported as maps with the \"id\" field, which is stored as the result.\n",
+		            "type": "object",
+		            "additionalProperties": true
 		          }
 		        }
 		      }
+		    },
+		    "cached": {
+		      "description": "Names of the services which were not built, because nothing changed since the last build. Their artifacts are taken from the build cache.\n",
+		      "type": "array",
+		      "items": {
+		        "description": "Name of the object, unique within the kind.",
+		        "type": "string",
+		        "minLength": 1,
+		        "pattern": "^[a-z][A-Za-z0-9_-]*$"
+		      }
 		    }
 		  }
 		}
@@ -500,9 +638,23 @@ var SCHEMAS = map[string][]byte{
 		  "required": [
 		    "apiVersion",
 		    "kind",
-		    "name",
-		    "script"
+		    "name"
 		  ],
+		  "if": {
+		    "required": [
+		      "command"
+		    ]
+		  },
+		  "then": {
+		    "properties": {
+		      "script": false
+		    }
+		  },
+		  "else": {
+		    "required": [
+		      "script"
+		    ]
+		  },
 		  "additionalProperties": false,
 		  "properties": {
 		    "apiVersion": {
@@ -982,6 +1134,42 @@ var SCHEMAS = map[string][]byte{
 		    },
 		    "script": {
 		      "type": "string"
+		    },
+		    "allowOutsideProject": {
+		      "type": "boolean"
+		    },
+		    "commands": {
+		      "description": "Commands which the executor runs using the \"exec\" module. When specified, other commands are rejected. Project's \"executorPolicy\" may further restrict commands available to executors.\n",
+		      "examples": [
+		        [
+		          "docker",
+		          "git"
+		        ]
+		      ],
+		      "type": "array",
+		      "items": {
+		        "type": "string",
+		        "minLength": 1
+		      }
+		    },
+		    "command": {
+		      "description": "External program implementing the executor, used instead of the script. The first item is the program (paths containing a slash are relative to the executor's file), remaining items are its arguments. The program receives input as JSON on stdin and writes results, log messages and errors as JSON lines to stdout. Its stderr is logged.\n",
+		      "examples": [
+		        [
+		          "./deployer.py"
+		        ],
+		        [
+		          "python3",
+		          "./deployer.py",
+		          "--verbose"
+		        ]
+		      ],
+		      "type": "array",
+		      "minItems": 1,
+		      "items": {
+		        "type": "string",
+		        "minLength": 1
+		      }
 		    }
 		  }
 		}
@@ -991,9 +1179,98 @@ var SCHEMAS = map[string][]byte{
 		  "type": "object",
 		  "additionalProperties": true,
 		  "required": [
+		    "status",
 		    "releases"
 		  ],
 		  "properties": {
+		    "status": {
+		      "description": "Outcome of the command or an entry.",
+		      "enum": [
+		        "succeeded",
+		        "failed",
+		        "skipped",
+		        "timedOut"
+		      ]
+		    },
+		    "error": {
+		      "description": "Error which caused the command to fail, present only if status is \"failed\".",
+		      "type": "string"
+		    },
+		    "entries": {
+		      "description": "Outcome of each processed entry. Entries which were not executed (e.g. because previous entry of the same service failed) have \"skipped\" status, entries interrupted because of exceeded time limit have \"timedOut\" status. Entries of tasks defined in the project don't have \"service\".\n",
+		      "type": "array",
+		      "items": {
+		        "examples": [
+		          {
+		            "service": "generic-service",
+		            "type": "build",
+		            "entry": 0,
+		            "executorKind": "Builder",
+		            "executorName": "docker",
+		            "status": "succeeded",
+		            "startTime": "2022-03-01T12:00:00Z",
+		            "endTime": "2022-03-01T12:00:05.5Z",
+		            "duration": 5.5
+		          }
+		        ],
+		        "type": "object",
+		        "additionalProperties": true,
+		        "required": [
+		          "type",
+		          "entry",
+		          "executorKind",
+		          "executorName",
+		          "status"
+		        ],
+		        "properties": {
+		          "service": {
+		            "description": "Name of the object, unique within the kind.",
+		            "type": "string",
+		            "minLength": 1,
+		            "pattern": "^[a-z][A-Za-z0-9_-]*$"
+		          },
+		          "type": {
+		            "description": "Type of the entry (e.g. \"tag\", \"build\", \"push\" or \"deploy\").",
+		            "type": "string"
+		          },
+		          "entry": {
+		            "type": "integer",
+		            "min": 0
+		          },
+		          "executorKind": {
+		            "type": "string"
+		          },
+		          "executorName": {
+		            "type": "string"
+		          },
+		          "status": {
+		            "description": "Outcome of the command or an entry.",
+		            "enum": [
+		              "succeeded",
+		              "failed",
+		              "skipped",
+		              "timedOut"
+		            ]
+		          },
+		          "error": {
+		            "description": "Error message, present only if entry failed.",
+		            "type": "string"
+		          },
+		          "startTime": {
+		            "type": "string",
+		            "format": "date-time"
+		          },
+		          "endTime": {
+		            "type": "string",
+		            "format": "date-time"
+		          },
+		          "duration": {
+		            "description": "Duration of the entry in seconds.",
+		            "type": "number"
+		          }
+		        }
+		      }
+		    },
 		    "releases": {
 		      "type": "array",
 		      "items": {
@@ -1002,6 +1279,14 @@ var SCHEMAS = map[string][]byte{
 		            "service": "generic-service",
 		            "entry": 0,
 		            "result": "some result"
+		          },
+		          {
+		            "service": "generic-service",
+		            "entry": 0,
+		            "result": "registry/app:1.0.0",
+		            "metadata": {
+		              "digest": "sha256:4f5e..."
+		            }
 		          }
 		        ],
 		        "type": "object",
@@ -1019,6 +1304,57 @@ var SCHEMAS = map[string][]byte{
 		          },
 		          "result": {
 		            "type": "string"
+		          },
+		          "metadata": {
+		            "description": "Metadata added by the executor together with the result (e.g. digest of a built image). Present only for artifacts reported as maps with the \"id\" field, which is stored as the result.\n",
+		            "type": "object",
+		            "additionalProperties": true
+		          }
+		        }
+		      }
+		    },
+		    "rollbacks": {
+		      "description": "Outcome of the rollback of already deployed releases, present only if deployment failed and rollback was requested.\n",
+		      "type": "array",
+		      "items": {
+		        "examples": [
+		          {
+		            "service": "generic-service",
+		            "entry": 0,
+		            "releases": [
+		              "some release"
+		            ],
+		            "status": "succeeded"
+		          }
+		        ],
+		        "type": "object",
+		        "additionalProperties": true,
+		        "properties": {
+		          "service": {
+		            "description": "Name of the object, unique within the kind.",
+		            "type": "string",
+		            "minLength": 1,
+		            "pattern": "^[a-z][A-Za-z0-9_-]*$"
+		          },
+		          "entry": {
+		            "type": "integer",
+		            "min": 0
+		          },
+		          "releases": {
+		            "type": "array",
+		            "items": {
+		              "type": "string"
+		            }
+		          },
+		          "status": {
+		            "enum": [
+		              "succeeded",
+		              "failed",
+		              "skipped"
+		            ]
+		          },
+		          "error": {
+		            "type": "string"
 		          }
 		        }
 		      }
@@ -1033,9 +1369,23 @@ var SCHEMAS = map[string][]byte{
 		  "required": [
 		    "apiVersion",
 		    "kind",
-		    "name",
-		    "script"
+		    "name"
 		  ],
+		  "if": {
+		    "required": [
+		      "command"
+		    ]
+		  },
+		  "then": {
+		    "properties": {
+		      "script": false
+		    }
+		  },
+		  "else": {
+		    "required": [
+		      "script"
+		    ]
+		  },
 		  "additionalProperties": false,
 		  "properties": {
 		    "apiVersion": {
@@ -1515,6 +1865,45 @@ var SCHEMAS = map[string][]byte{
 		    },
 		    "script": {
 		      "type": "string"
+		    },
+		    "rollback": {
+		      "type": "string"
+		    },
+		    "allowOutsideProject": {
+		      "type": "boolean"
+		    },
+		    "commands": {
+		      "description": "Commands which the executor runs using the \"exec\" module. When specified, other commands are rejected. Project's \"executorPolicy\" may further restrict commands available to executors.\n",
+		      "examples": [
+		        [
+		          "docker",
+		          "git"
+		        ]
+		      ],
+		      "type": "array",
+		      "items": {
+		        "type": "string",
+		        "minLength": 1
+		      }
+		    },
+		    "command": {
+		      "description": "External program implementing the executor, used instead of the script. The first item is the program (paths containing a slash are relative to the executor's file), remaining items are its arguments. The program receives input as JSON on stdin and writes results, log messages and errors as JSON lines to stdout. Its stderr is logged.\n",
+		      "examples": [
+		        [
+		          "./deployer.py"
+		        ],
+		        [
+		          "python3",
+		          "./deployer.py",
+		          "--verbose"
+		        ]
+		      ],
+		      "type": "array",
+		      "minItems": 1,
+		      "items": {
+		        "type": "string",
+		        "minLength": 1
+		      }
 		    }
 		  }
 		}
@@ -1566,6 +1955,55 @@ var SCHEMAS = map[string][]byte{
 		          "type": "string"
 		        }
 		      }
+		    },
+		    "secrets": {
+		      "description": "Definitions of the secret variables to use in the configuration files. Values are read from environment variables or files (relative to the directory of the document), and they are masked in logs and result files. Names are case-insensitive.\n",
+		      "examples": [
+		        {
+		          "registryPassword": {
+		            "env": "REGISTRY_PASSWORD"
+		          },
+		          "kubeToken": {
+		            "file": "secrets/kube-token"
+		          }
+		        }
+		      ],
+		      "type": "object",
+		      "patternProperties": {
+		        "^[a-zA-Z][a-zA-Z0-9]*$": {
+		          "oneOf": [
+		            {
+		              "type": "object",
+		              "additionalProperties": false,
+		              "required": [
+		                "env"
+		              ],
+		              "properties": {
+		                "env": {
+		                  "description": "Name of the environment variable containing the value.",
+		                  "type": "string",
+		                  "minLength": 1
+		                }
+		              }
+		            },
+		            {
+		              "type": "object",
+		              "additionalProperties": false,
+		              "required": [
+		                "file"
+		              ],
+		              "properties": {
+		                "file": {
+		                  "description": "Path of the file containing the value. Trailing newline is removed from its content.\n",
+		                  "type": "string",
+		                  "minLength": 1
+		                }
+		              }
+		            }
+		          ]
+		        }
+		      },
+		      "additionalProperties": false
 		    }
 		  }
 		}
@@ -1577,9 +2015,23 @@ var SCHEMAS = map[string][]byte{
 		  "required": [
 		    "apiVersion",
 		    "kind",
-		    "name",
-		    "script"
+		    "name"
 		  ],
+		  "if": {
+		    "required": [
+		      "command"
+		    ]
+		  },
+		  "then": {
+		    "properties": {
+		      "script": false
+		    }
+		  },
+		  "else": {
+		    "required": [
+		      "script"
+		    ]
+		  },
 		  "additionalProperties": false,
 		  "properties": {
 		    "apiVersion": {
@@ -1595,7 +2047,8 @@ var SCHEMAS = map[string][]byte{
 		        "Builder",
 		        "Deployer",
 		        "Tagger",
-		        "Pusher"
+		        "Pusher",
+		        "Runner"
 		      ]
 		    },
 		    "name": {
@@ -2092,23 +2545,124 @@ var SCHEMAS = map[string][]byte{
 		        "for (let tag of input.tags) {\n  let image = input.spec.image + ':' + tag\n  let context = input.spec.context || \".\"\n\n  exec(\"docker\", [ 'build', context, '-t', image ])\n\n  output = image\n}\n"
 		      ],
 		      "type": "string"
-		    }
-		  }
-		}
-	`),
-	"g2a-cli/v2.0/Object": []byte(`
-		{
-		  "title": "Object",
-		  "oneOf": [
+		    },
+		    "rollback": {
+		      "description": "Optional implementation of the rollback, supported only by deployers. It's run for releases already deployed when deployment of another release fails. Input contains the same values as for the script, and additionally \"releases\" returned by the script.\n",
+		      "examples": [
+		        "exec := import(\"exec\")\nfor release in input.releases {\n  exec.run(\"helm\", \"rollback\", release)\n}\n"
+		      ],
+		      "type": "string"
+		    },
+		    "allowOutsideProject": {
+		      "description": "Allows the \"fs\" module to access files outside of the project directory. By default, paths outside of the project directory (and temporary directories created by the script) are rejected.\n",
+		      "examples": [
+		        true
+		      ],
+		      "type": "boolean"
+		    },
+		    "commands": {
+		      "description": "Commands which the executor runs using the \"exec\" module. When specified, other commands are rejected. Project's \"executorPolicy\" may further restrict commands available to executors.\n",
+		      "examples": [
+		        [
+		          "docker",
+		          "git"
+		        ]
+		      ],
+		      "type": "array",
+		      "items": {
+		        "type": "string",
+		        "minLength": 1
+		      }
+		    },
+		    "command": {
+		      "description": "External program implementing the executor, used instead of the script. The first item is the program (paths containing a slash are relative to the executor's file), remaining items are its arguments. The program receives input as JSON on stdin and writes results, log messages and errors as JSON lines to stdout. Its stderr is logged.\n",
+		      "examples": [
+		        [
+		          "./deployer.py"
+		        ],
+		        [
+		          "python3",
+		          "./deployer.py",
+		          "--verbose"
+		        ]
+		      ],
+		      "type": "array",
+		      "minItems": 1,
+		      "items": {
+		        "type": "string",
+		        "minLength": 1
+		      }
+		    }
+		  }
+		}
+	`),
+	"g2a-cli/v2.0/Library": []byte(`
+		{
+		  "title": "Library",
+		  "description": "Script shared between executors. Executors (and other libraries) can import it using its name, e.g. import(\"docker-helpers\").\n",
+		  "type": "object",
+		  "required": [
+		    "apiVersion",
+		    "kind",
+		    "name",
+		    "script"
+		  ],
+		  "additionalProperties": false,
+		  "properties": {
+		    "apiVersion": {
+		      "description": "Version of the configuration format.",
+		      "const": "g2a-cli/v2.0"
+		    },
+		    "kind": {
+		      "description": "Determines type of the document.",
+		      "const": "Library"
+		    },
+		    "name": {
+		      "description": "Name used to import the library. It cannot be the same as a name of a module from the standard library.\n",
+		      "examples": [
+		        "docker-helpers"
+		      ],
+		      "type": "string",
+		      "minLength": 1,
+		      "pattern": "^[a-z][A-Za-z0-9_-]*$"
+		    },
+		    "script": {
+		      "description": "Implementation of the library. Values exported by the script are available to scripts importing the library. Unlike executor scripts, libraries don't have access to \"input\", \"addResult\" and \"abort\".\n",
+		      "examples": [
+		        "text := import(\"text\")\nexport {\n  trim_newline: func(s) { return text.trim_suffix(s, \"\\n\") }\n}\n"
+		      ],
+		      "type": "string"
+		    }
+		  }
+		}
+	`),
+	"g2a-cli/v2.0/Object": []byte(`
+		{
+		  "title": "Object",
+		  "oneOf": [
 		    {
 		      "title": "Builder",
 		      "type": "object",
 		      "required": [
 		        "apiVersion",
 		        "kind",
-		        "name",
-		        "script"
+		        "name"
 		      ],
+		      "if": {
+		        "required": [
+		          "command"
+		        ]
+		      },
+		      "then": {
+		        "properties": {
+		          "script": false
+		        }
+		      },
+		      "else": {
+		        "required": [
+		          "script"
+		        ]
+		      },
 		      "additionalProperties": false,
 		      "properties": {
 		        "apiVersion": {
@@ -2588,6 +3142,42 @@ var SCHEMAS = map[string][]byte{
 		        },
 		        "script": {
 		          "type": "string"
+		        },
+		        "allowOutsideProject": {
+		          "type": "boolean"
+		        },
+		        "commands": {
+		          "description": "Commands which the executor runs using the \"exec\" module. When specified, other commands are rejected. Project's \"executorPolicy\" may further restrict commands available to executors.\n",
+		          "examples": [
+		            [
+		              "docker",
+		              "git"
+		            ]
+		          ],
+		          "type": "array",
+		          "items": {
+		            "type": "string",
+		            "minLength": 1
+		          }
+		        },
+		        "command": {
+		          "description": "External program implementing the executor, used instead of the script. The first item is the program (paths containing a slash are relative to the executor's file), remaining items are its arguments. The program receives input as JSON on stdin and writes results, log messages and errors as JSON lines to stdout. Its stderr is logged.\n",
+		          "examples": [
+		            [
+		              "./deployer.py"
+		            ],
+		            [
+		              "python3",
+		              "./deployer.py",
+		              "--verbose"
+		            ]
+		          ],
+		          "type": "array",
+		          "minItems": 1,
+		          "items": {
+		            "type": "string",
+		            "minLength": 1
+		          }
 		        }
 		      }
 		    },
@@ -2597,9 +3187,23 @@ var SCHEMAS = map[string][]byte{
 		      "required": [
 		        "apiVersion",
 		        "kind",
-		        "name",
-		        "script"
+		        "name"
 		      ],
+		      "if": {
+		        "required": [
+		          "command"
+		        ]
+		      },
+		      "then": {
+		        "properties": {
+		          "script": false
+		        }
+		      },
+		      "else": {
+		        "required": [
+		          "script"
+		        ]
+		      },
 		      "additionalProperties": false,
 		      "properties": {
 		        "apiVersion": {
@@ -3079,6 +3683,45 @@ var SCHEMAS = map[string][]byte{
 		        },
 		        "script": {
 		          "type": "string"
+		        },
+		        "rollback": {
+		          "type": "string"
+		        },
+		        "allowOutsideProject": {
+		          "type": "boolean"
+		        },
+		        "commands": {
+		          "description": "Commands which the executor runs using the \"exec\" module. When specified, other commands are rejected. Project's \"executorPolicy\" may further restrict commands available to executors.\n",
+		          "examples": [
+		            [
+		              "docker",
+		              "git"
+		            ]
+		          ],
+		          "type": "array",
+		          "items": {
+		            "type": "string",
+		            "minLength": 1
+		          }
+		        },
+		        "command": {
+		          "description": "External program implementing the executor, used instead of the script. The first item is the program (paths containing a slash are relative to the executor's file), remaining items are its arguments. The program receives input as JSON on stdin and writes results, log messages and errors as JSON lines to stdout. Its stderr is logged.\n",
+		          "examples": [
+		            [
+		              "./deployer.py"
+		            ],
+		            [
+		              "python3",
+		              "./deployer.py",
+		              "--verbose"
+		            ]
+		          ],
+		          "type": "array",
+		          "minItems": 1,
+		          "items": {
+		            "type": "string",
+		            "minLength": 1
+		          }
 		        }
 		      }
 		    },
@@ -3128,6 +3771,93 @@ var SCHEMAS = map[string][]byte{
 		              "type": "string"
 		            }
 		          }
+		        },
+		        "secrets": {
+		          "description": "Definitions of the secret variables to use in the configuration files. Values are read from environment variables or files (relative to the directory of the document), and they are masked in logs and result files. Names are case-insensitive.\n",
+		          "examples": [
+		            {
+		              "registryPassword": {
+		                "env": "REGISTRY_PASSWORD"
+		              },
+		              "kubeToken": {
+		                "file": "secrets/kube-token"
+		              }
+		            }
+		          ],
+		          "type": "object",
+		          "patternProperties": {
+		            "^[a-zA-Z][a-zA-Z0-9]*$": {
+		              "oneOf": [
+		                {
+		                  "type": "object",
+		                  "additionalProperties": false,
+		                  "required": [
+		                    "env"
+		                  ],
+		                  "properties": {
+		                    "env": {
+		                      "description": "Name of the environment variable containing the value.",
+		                      "type": "string",
+		                      "minLength": 1
+		                    }
+		                  }
+		                },
+		                {
+		                  "type": "object",
+		                  "additionalProperties": false,
+		                  "required": [
+		                    "file"
+		                  ],
+		                  "properties": {
+		                    "file": {
+		                      "description": "Path of the file containing the value. Trailing newline is removed from its content.\n",
+		                      "type": "string",
+		                      "minLength": 1
+		                    }
+		                  }
+		                }
+		              ]
+		            }
+		          },
+		          "additionalProperties": false
+		        }
+		      }
+		    },
+		    {
+		      "title": "Library",
+		      "description": "Script shared between executors. Executors (and other libraries) can import it using its name, e.g. import(\"docker-helpers\").\n",
+		      "type": "object",
+		      "required": [
+		        "apiVersion",
+		        "kind",
+		        "name",
+		        "script"
+		      ],
+		      "additionalProperties": false,
+		      "properties": {
+		        "apiVersion": {
+		          "description": "Version of the configuration format.",
+		          "const": "g2a-cli/v2.0"
+		        },
+		        "kind": {
+		          "description": "Determines type of the document.",
+		          "const": "Library"
+		        },
+		        "name": {
+		          "description": "Name of the object, unique within the kind.",
+		          "type": "string",
+		          "minLength": 1,
+		          "pattern": "^[a-z][A-Za-z0-9_-]*$",
+		          "examples": [
+		            "docker-helpers"
+		          ]
+		        },
+		        "script": {
+		          "description": "Implementation of the library. Values exported by the script are available to scripts importing the library. Unlike executor scripts, libraries don't have access to \"input\", \"addResult\" and \"abort\".\n",
+		          "examples": [
+		            "text := import(\"text\")\nexport {\n  trim_newline: func(s) { return text.trim_suffix(s, \"\\n\") }\n}\n"
+		          ],
+		          "type": "string"
 		        }
 		      }
 		    },
@@ -3185,54 +3915,224 @@ var SCHEMAS = map[string][]byte{
 		            }
 		          }
 		        },
-		        "tasks": {
-		          "description": "Definitions of the tasks used by commands \"prepare\", \"test\", \"lint\" and \"run\". These tasks may be also specified in services definitions.",
+		        "secrets": {
+		          "description": "Definitions of the secret variables to use in the configuration files. Values are read from environment variables or files (relative to the directory of the document), and they are masked in logs and result files. Names are case-insensitive.\n",
+		          "examples": [
+		            {
+		              "registryPassword": {
+		                "env": "REGISTRY_PASSWORD"
+		              },
+		              "kubeToken": {
+		                "file": "secrets/kube-token"
+		              }
+		            }
+		          ],
+		          "type": "object",
+		          "patternProperties": {
+		            "^[a-zA-Z][a-zA-Z0-9]*$": {
+		              "oneOf": [
+		                {
+		                  "type": "object",
+		                  "additionalProperties": false,
+		                  "required": [
+		                    "env"
+		                  ],
+		                  "properties": {
+		                    "env": {
+		                      "description": "Name of the environment variable containing the value.",
+		                      "type": "string",
+		                      "minLength": 1
+		                    }
+		                  }
+		                },
+		                {
+		                  "type": "object",
+		                  "additionalProperties": false,
+		                  "required": [
+		                    "file"
+		                  ],
+		                  "properties": {
+		                    "file": {
+		                      "description": "Path of the file containing the value. Trailing newline is removed from its content.\n",
+		                      "type": "string",
+		                      "minLength": 1
+		                    }
+		                  }
+		                }
+		              ]
+		            }
+		          },
+		          "additionalProperties": false
+		        },
+		        "timeout": {
+		          "description": "Time limit for the whole command (e.g. build or deploy). May be overridden using \"--timeout\" flag.\n",
+		          "examples": [
+		            "10m"
+		          ],
+		          "type": "string",
+		          "pattern": "^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+		        },
+		        "entryTimeout": {
+		          "description": "Default time limit for entries which don't specify their own \"timeout\". May be overridden using \"--entry-timeout\" flag.\n",
+		          "examples": [
+		            "10m"
+		          ],
+		          "type": "string",
+		          "pattern": "^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+		        },
+		        "executorPolicy": {
+		          "description": "Restricts commands which executors (including ones loaded from other files) can run using the \"exec\" module.\n",
 		          "type": "object",
+		          "additionalProperties": false,
 		          "properties": {
-		            "prepare": {
-		              "description": "Defines steps required to preapre freshly clonned repository for development, tests or build. This definition is used by \"prepare\" command.\n",
+		            "requireCommands": {
+		              "description": "Executors which don't declare \"commands\" cannot run any commands, unless they are approved.\n",
+		              "type": "boolean"
+		            },
+		            "allowedCommands": {
+		              "description": "Commands which executors can run, unless they are approved. Executors which declare \"commands\" can run only commands present in both lists.\n",
 		              "examples": [
 		                [
-		                  {
-		                    "make": {
-		                      "target": "prepare"
-		                    }
-		                  }
+		                  "docker",
+		                  "git",
+		                  "helm"
 		                ]
 		              ],
 		              "type": "array",
 		              "items": {
-		                "oneOf": [
-		                  {
-		                    "type": "object",
-		                    "minProperties": 1,
-		                    "maxProperties": 1,
-		                    "additionalProperties": true
-		                  },
-		                  {
-		                    "type": "string"
-		                  }
-		                ]
+		                "type": "string",
+		                "minLength": 1
 		              }
 		            },
-		            "test": {
-		              "description": "Defines how to run tests. This definition is used by \"test\" command.\n",
+		            "approvedExecutors": {
+		              "description": "Executors which can run all commands they declare (or any commands, if they don't declare them), regardless of other options.\n",
 		              "examples": [
 		                [
 		                  {
-		                    "script": {
-		                      "sh": "go test ./..."
-		                    }
+		                    "kind": "Deployer",
+		                    "name": "helm3"
 		                  }
 		                ]
 		              ],
 		              "type": "array",
 		              "items": {
-		                "oneOf": [
-		                  {
+		                "type": "object",
+		                "additionalProperties": false,
+		                "required": [
+		                  "kind",
+		                  "name"
+		                ],
+		                "properties": {
+		                  "kind": {
+		                    "enum": [
+		                      "Builder",
+		                      "Deployer",
+		                      "Pusher",
+		                      "Runner",
+		                      "Tagger"
+		                    ]
+		                  },
+		                  "name": {
+		                    "description": "Name of the object, unique within the kind.",
+		                    "type": "string",
+		                    "minLength": 1,
+		                    "pattern": "^[a-z][A-Za-z0-9_-]*$"
+		                  }
+		                }
+		              }
+		            }
+		          }
+		        },
+		        "tasks": {
+		          "description": "Definitions of the tasks used by commands \"prepare\", \"test\", \"lint\" and \"run\". These tasks may be also specified in services definitions.",
+		          "type": "object",
+		          "properties": {
+		            "prepare": {
+		              "description": "Defines steps required to preapre freshly clonned repository for development, tests or build. This definition is used by \"prepare\" command.\n",
+		              "examples": [
+		                [
+		                  {
+		                    "make": {
+		                      "target": "prepare"
+		                    }
+		                  }
+		                ]
+		              ],
+		              "type": "array",
+		              "items": {
+		                "oneOf": [
+		                  {
 		                    "type": "object",
-		                    "minProperties": 1,
-		                    "maxProperties": 1,
+		                    "properties": {
+		                      "timeout": {
+		                        "description": "Time limit for the entry. Executor is interrupted when it's exceeded and the entry fails.\n",
+		                        "examples": [
+		                          "10m"
+		                        ],
+		                        "type": "string",
+		                        "pattern": "^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+		                      }
+		                    },
+		                    "if": {
+		                      "required": [
+		                        "timeout"
+		                      ]
+		                    },
+		                    "then": {
+		                      "minProperties": 2,
+		                      "maxProperties": 2
+		                    },
+		                    "else": {
+		                      "minProperties": 1,
+		                      "maxProperties": 1
+		                    },
+		                    "additionalProperties": true
+		                  },
+		                  {
+		                    "type": "string"
+		                  }
+		                ]
+		              }
+		            },
+		            "test": {
+		              "description": "Defines how to run tests. This definition is used by \"test\" command.\n",
+		              "examples": [
+		                [
+		                  {
+		                    "script": {
+		                      "sh": "go test ./..."
+		                    }
+		                  }
+		                ]
+		              ],
+		              "type": "array",
+		              "items": {
+		                "oneOf": [
+		                  {
+		                    "type": "object",
+		                    "properties": {
+		                      "timeout": {
+		                        "description": "Time limit for the entry. Executor is interrupted when it's exceeded and the entry fails.\n",
+		                        "examples": [
+		                          "10m"
+		                        ],
+		                        "type": "string",
+		                        "pattern": "^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+		                      }
+		                    },
+		                    "if": {
+		                      "required": [
+		                        "timeout"
+		                      ]
+		                    },
+		                    "then": {
+		                      "minProperties": 2,
+		                      "maxProperties": 2
+		                    },
+		                    "else": {
+		                      "minProperties": 1,
+		                      "maxProperties": 1
+		                    },
 		                    "additionalProperties": true
 		                  },
 		                  {
@@ -3253,8 +4153,29 @@ var SCHEMAS = map[string][]byte{
 		                "oneOf": [
 		                  {
 		                    "type": "object",
-		                    "minProperties": 1,
-		                    "maxProperties": 1,
+		                    "properties": {
+		                      "timeout": {
+		                        "description": "Time limit for the entry. Executor is interrupted when it's exceeded and the entry fails.\n",
+		                        "examples": [
+		                          "10m"
+		                        ],
+		                        "type": "string",
+		                        "pattern": "^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+		                      }
+		                    },
+		                    "if": {
+		                      "required": [
+		                        "timeout"
+		                      ]
+		                    },
+		                    "then": {
+		                      "minProperties": 2,
+		                      "maxProperties": 2
+		                    },
+		                    "else": {
+		                      "minProperties": 1,
+		                      "maxProperties": 1
+		                    },
 		                    "additionalProperties": true
 		                  },
 		                  {
@@ -3281,8 +4202,29 @@ var SCHEMAS = map[string][]byte{
 		              "oneOf": [
 		                {
 		                  "type": "object",
-		                  "minProperties": 1,
-		                  "maxProperties": 1,
+		                  "properties": {
+		                    "timeout": {
+		                      "description": "Time limit for the entry. Executor is interrupted when it's exceeded and the entry fails.\n",
+		                      "examples": [
+		                        "10m"
+		                      ],
+		                      "type": "string",
+		                      "pattern": "^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+		                    }
+		                  },
+		                  "if": {
+		                    "required": [
+		                      "timeout"
+		                    ]
+		                  },
+		                  "then": {
+		                    "minProperties": 2,
+		                    "maxProperties": 2
+		                  },
+		                  "else": {
+		                    "minProperties": 1,
+		                    "maxProperties": 1
+		                  },
 		                  "additionalProperties": true
 		                },
 		                {
@@ -3298,8 +4240,29 @@ var SCHEMAS = map[string][]byte{
 		                "oneOf": [
 		                  {
 		                    "type": "object",
-		                    "minProperties": 1,
-		                    "maxProperties": 1,
+		                    "properties": {
+		                      "timeout": {
+		                        "description": "Time limit for the entry. Executor is interrupted when it's exceeded and the entry fails.\n",
+		                        "examples": [
+		                          "10m"
+		                        ],
+		                        "type": "string",
+		                        "pattern": "^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+		                      }
+		                    },
+		                    "if": {
+		                      "required": [
+		                        "timeout"
+		                      ]
+		                    },
+		                    "then": {
+		                      "minProperties": 2,
+		                      "maxProperties": 2
+		                    },
+		                    "else": {
+		                      "minProperties": 1,
+		                      "maxProperties": 1
+		                    },
 		                    "additionalProperties": true
 		                  },
 		                  {
@@ -3329,9 +4292,23 @@ var SCHEMAS = map[string][]byte{
 		      "required": [
 		        "apiVersion",
 		        "kind",
-		        "name",
-		        "script"
+		        "name"
 		      ],
+		      "if": {
+		        "required": [
+		          "command"
+		        ]
+		      },
+		      "then": {
+		        "properties": {
+		          "script": false
+		        }
+		      },
+		      "else": {
+		        "required": [
+		          "script"
+		        ]
+		      },
 		      "additionalProperties": false,
 		      "properties": {
 		        "apiVersion": {
@@ -3811,594 +4788,533 @@ var SCHEMAS = map[string][]byte{
 		        },
 		        "script": {
 		          "type": "string"
+		        },
+		        "allowOutsideProject": {
+		          "type": "boolean"
+		        },
+		        "commands": {
+		          "description": "Commands which the executor runs using the \"exec\" module. When specified, other commands are rejected. Project's \"executorPolicy\" may further restrict commands available to executors.\n",
+		          "examples": [
+		            [
+		              "docker",
+		              "git"
+		            ]
+		          ],
+		          "type": "array",
+		          "items": {
+		            "type": "string",
+		            "minLength": 1
+		          }
+		        },
+		        "command": {
+		          "description": "External program implementing the executor, used instead of the script. The first item is the program (paths containing a slash are relative to the executor's file), remaining items are its arguments. The program receives input as JSON on stdin and writes results, log messages and errors as JSON lines to stdout. Its stderr is logged.\n",
+		          "examples": [
+		            [
+		              "./deployer.py"
+		            ],
+		            [
+		              "python3",
+		              "./deployer.py",
+		              "--verbose"
+		            ]
+		          ],
+		          "type": "array",
+		          "minItems": 1,
+		          "items": {
+		            "type": "string",
+		            "minLength": 1
+		          }
 		        }
 		      }
 		    },
 		    {
-		      "title": "Service",
+		      "title": "Runner",
 		      "type": "object",
 		      "required": [
 		        "apiVersion",
 		        "kind",
 		        "name"
 		      ],
+		      "if": {
+		        "required": [
+		          "command"
+		        ]
+		      },
+		      "then": {
+		        "properties": {
+		          "script": false
+		        }
+		      },
+		      "else": {
+		        "required": [
+		          "script"
+		        ]
+		      },
+		      "additionalProperties": false,
 		      "properties": {
 		        "apiVersion": {
 		          "description": "Version of the configuration format.",
 		          "const": "g2a-cli/v2.0"
 		        },
 		        "kind": {
-		          "description": "Determines type of the document.",
-		          "const": "Service"
+		          "const": "Runner"
 		        },
 		        "name": {
 		          "description": "Name of the object, unique within the kind.",
 		          "type": "string",
 		          "minLength": 1,
-		          "pattern": "^[a-z][A-Za-z0-9_-]*$",
-		          "examples": [
-		            "example-api"
-		          ]
+		          "pattern": "^[a-z][A-Za-z0-9_-]*$"
 		        },
-		        "artifacts": {
-		          "description": "List of artifacts to produce by build command. Each entry describes single artifact like docker image or npm package.\n",
-		          "type": "array",
-		          "items": {
-		            "examples": [
-		              {
-		                "docker": {
-		                  "image": "example.com/test/image"
-		                }
+		        "schema": {
+		          "$schema": "https://json-schema.org/draft/2019-09/schema",
+		          "$id": "https://json-schema.org/draft/2019-09/schema",
+		          "$vocabulary": {
+		            "https://json-schema.org/draft/2019-09/vocab/core": true,
+		            "https://json-schema.org/draft/2019-09/vocab/applicator": true,
+		            "https://json-schema.org/draft/2019-09/vocab/validation": true,
+		            "https://json-schema.org/draft/2019-09/vocab/meta-data": true,
+		            "https://json-schema.org/draft/2019-09/vocab/format": false,
+		            "https://json-schema.org/draft/2019-09/vocab/content": true
+		          },
+		          "$recursiveAnchor": true,
+		          "title": "Core and Validation specifications meta-schema",
+		          "allOf": [
+		            {
+		              "$schema": "https://json-schema.org/draft/2019-09/schema",
+		              "$id": "https://json-schema.org/draft/2019-09/meta/core",
+		              "$vocabulary": {
+		                "https://json-schema.org/draft/2019-09/vocab/core": true
 		              },
-		              {
-		                "hugo": {
-		                  "dir": "{{ .Service.Dir }}/docs"
+		              "$recursiveAnchor": true,
+		              "title": "Core vocabulary meta-schema",
+		              "type": [
+		                "object",
+		                "boolean"
+		              ],
+		              "properties": {
+		                "$id": {
+		                  "type": "string",
+		                  "format": "uri-reference",
+		                  "$comment": "Non-empty fragments not allowed.",
+		                  "pattern": "^[^#]*#?$"
 		                },
-		                "push": {
-		                  "artifactory": {
-		                    "path": {
-		                      "source": "{{ .Service.Dir }}/docs/public/*",
-		                      "target": "docs-snapshot-local/generic-api/{{ .Tag }}/"
-		                    }
+		                "$schema": {
+		                  "type": "string",
+		                  "format": "uri"
+		                },
+		                "$anchor": {
+		                  "type": "string",
+		                  "pattern": "^[A-Za-z][-A-Za-z0-9.:_]*$"
+		                },
+		                "$ref": {
+		                  "type": "string",
+		                  "format": "uri-reference"
+		                },
+		                "$recursiveRef": {
+		                  "type": "string",
+		                  "format": "uri-reference"
+		                },
+		                "$recursiveAnchor": {
+		                  "type": "boolean",
+		                  "default": false
+		                },
+		                "$vocabulary": {
+		                  "type": "object",
+		                  "propertyNames": {
+		                    "type": "string",
+		                    "format": "uri"
+		                  },
+		                  "additionalProperties": {
+		                    "type": "boolean"
 		                  }
+		                },
+		                "$comment": {
+		                  "type": "string"
+		                },
+		                "$defs": {
+		                  "type": "object",
+		                  "additionalProperties": {
+		                    "$recursiveRef": "#"
+		                  },
+		                  "default": {}
 		                }
+		              }
+		            },
+		            {
+		              "$schema": "https://json-schema.org/draft/2019-09/schema",
+		              "$id": "https://json-schema.org/draft/2019-09/meta/applicator",
+		              "$vocabulary": {
+		                "https://json-schema.org/draft/2019-09/vocab/applicator": true
 		              },
-		              {
-		                "docker": {
-		                  "image": "example.com/test/image2"
+		              "$recursiveAnchor": true,
+		              "title": "Applicator vocabulary meta-schema",
+		              "type": [
+		                "object",
+		                "boolean"
+		              ],
+		              "properties": {
+		                "additionalItems": {
+		                  "$recursiveRef": "#"
 		                },
-		                "push": false
-		              }
-		            ],
-		            "x-examplesDescriptions": [
-		              "Each artifact definition contains a single property defining names of executors (builder and pusher) used to handle it. Format of the configuration within is determined by a schema attached to Builder definition. If there is a matching Pusher, configuration must conform to its schema as well.",
-		              "If you want to use Pusher and Builder with different names or different configuration formats, add \"push\" property with a separate pusher definition.",
-		              "If you don't want to push artifact, set \"push\" property to false."
-		            ],
-		            "oneOf": [
-		              {
-		                "oneOf": [
-		                  {
-		                    "type": "object",
-		                    "minProperties": 1,
-		                    "maxProperties": 1,
-		                    "additionalProperties": true
-		                  },
-		                  {
-		                    "type": "string"
-		                  }
-		                ]
-		              },
-		              {
-		                "type": "object",
-		                "minProperties": 2,
-		                "maxProperties": 2,
-		                "required": [
-		                  "push"
-		                ],
-		                "properties": {
-		                  "push": {
-		                    "tsType": "false | Record<string, unknown>",
-		                    "oneOf": [
-		                      {
-		                        "oneOf": [
-		                          {
-		                            "type": "object",
-		                            "minProperties": 1,
-		                            "maxProperties": 1,
-		                            "additionalProperties": true
-		                          },
-		                          {
-		                            "type": "string"
-		                          }
-		                        ]
-		                      },
-		                      {
-		                        "const": false
+		                "unevaluatedItems": {
+		                  "$recursiveRef": "#"
+		                },
+		                "items": {
+		                  "anyOf": [
+		                    {
+		                      "$recursiveRef": "#"
+		                    },
+		                    {
+		                      "type": "array",
+		                      "minItems": 1,
+		                      "items": {
+		                        "$recursiveRef": "#"
 		                      }
-		                    ]
-		                  }
+		                    }
+		                  ]
 		                },
-		                "additionalProperties": true
-		              }
-		            ]
-		          }
-		        },
-		        "tags": {
-		          "description": "Describes how to generate tags used when pushing artifacts to registry.\n",
-		          "type": "array",
-		          "items": {
-		            "oneOf": [
-		              {
-		                "type": "object",
-		                "minProperties": 1,
-		                "maxProperties": 1,
-		                "additionalProperties": true
-		              },
-		              {
-		                "type": "string"
-		              }
-		            ],
-		            "examples": [
-		              "gitSha",
-		              "gitTag"
-		            ]
-		          }
-		        },
-		        "releases": {
-		          "description": "List of releases to do by deploy command.\n",
-		          "type": "array",
-		          "items": {
-		            "oneOf": [
-		              {
-		                "type": "object",
-		                "minProperties": 1,
-		                "maxProperties": 1,
-		                "additionalProperties": true
-		              },
-		              {
-		                "type": "string"
-		              }
-		            ],
-		            "examples": [
-		              {
-		                "helm": {
-		                  "name": "redis",
-		                  "chartPath": "bitnami/redis",
-		                  "valuesFiles": [
-		                    "{{ .Environment.Dir }}/redis.yaml"
-		                  ],
-		                  "chartRepository": {
-		                    "name": "bitnami",
-		                    "url": "https://charts.bitnami.com/bitnami"
+		                "contains": {
+		                  "$recursiveRef": "#"
+		                },
+		                "additionalProperties": {
+		                  "$recursiveRef": "#"
+		                },
+		                "unevaluatedProperties": {
+		                  "$recursiveRef": "#"
+		                },
+		                "properties": {
+		                  "type": "object",
+		                  "additionalProperties": {
+		                    "$recursiveRef": "#"
+		                  },
+		                  "default": {}
+		                },
+		                "patternProperties": {
+		                  "type": "object",
+		                  "additionalProperties": {
+		                    "$recursiveRef": "#"
+		                  },
+		                  "propertyNames": {
+		                    "format": "regex"
+		                  },
+		                  "default": {}
+		                },
+		                "dependentSchemas": {
+		                  "type": "object",
+		                  "additionalProperties": {
+		                    "$recursiveRef": "#"
 		                  }
-		                }
-		              }
-		            ]
-		          }
-		        },
-		        "tasks": {
-		          "description": "Definitions of the tasks used by commands \"prepare\", \"test\", \"lint\" and \"run\". These tasks may be also specified in the Project definition.",
-		          "type": "object",
-		          "properties": {
-		            "prepare": {
-		              "description": "Defines steps required to preapre freshly clonned repository for development, tests or build. This definition is used by \"prepare\" command.\n",
-		              "examples": [
-		                [
-		                  {
-		                    "make": {
-		                      "target": "prepare"
-		                    }
+		                },
+		                "propertyNames": {
+		                  "$recursiveRef": "#"
+		                },
+		                "if": {
+		                  "$recursiveRef": "#"
+		                },
+		                "then": {
+		                  "$recursiveRef": "#"
+		                },
+		                "else": {
+		                  "$recursiveRef": "#"
+		                },
+		                "allOf": {
+		                  "type": "array",
+		                  "minItems": 1,
+		                  "items": {
+		                    "$recursiveRef": "#"
 		                  }
-		                ]
-		              ],
-		              "type": "array",
-		              "items": {
-		                "oneOf": [
-		                  {
-		                    "type": "object",
-		                    "minProperties": 1,
-		                    "maxProperties": 1,
-		                    "additionalProperties": true
-		                  },
-		                  {
-		                    "type": "string"
+		                },
+		                "anyOf": {
+		                  "type": "array",
+		                  "minItems": 1,
+		                  "items": {
+		                    "$recursiveRef": "#"
 		                  }
-		                ]
-		              }
-		            },
-		            "test": {
-		              "description": "Defines how to run tests. This definition is used by \"test\" command.\n",
-		              "examples": [
-		                [
-		                  {
-		                    "script": {
-		                      "sh": "go test ./..."
-		                    }
+		                },
+		                "oneOf": {
+		                  "type": "array",
+		                  "minItems": 1,
+		                  "items": {
+		                    "$recursiveRef": "#"
 		                  }
-		                ]
-		              ],
-		              "type": "array",
-		              "items": {
-		                "oneOf": [
-		                  {
-		                    "type": "object",
-		                    "minProperties": 1,
-		                    "maxProperties": 1,
-		                    "additionalProperties": true
-		                  },
-		                  {
-		                    "type": "string"
+		                },
+		                "not": {
+		                  "$recursiveRef": "#"
+		                }
+		              },
+		              "$defs": {
+		                "schemaArray": {
+		                  "type": "array",
+		                  "minItems": 1,
+		                  "items": {
+		                    "$recursiveRef": "#"
 		                  }
-		                ]
+		                }
 		              }
 		            },
-		            "lint": {
-		              "description": "Defines how to lint the code. Ideally should try to fix the issues. This definition is used by \"lint\" command.\n",
-		              "examples": [
-		                [
-		                  "prettier"
-		                ]
-		              ],
-		              "type": "array",
-		              "items": {
-		                "oneOf": [
-		                  {
-		                    "type": "object",
-		                    "minProperties": 1,
-		                    "maxProperties": 1,
-		                    "additionalProperties": true
-		                  },
-		                  {
-		                    "type": "string"
-		                  }
-		                ]
-		              }
-		            }
-		          },
-		          "additionalProperties": {
-		            "description": "You are not bound to use pre-defined tasks. All tasks (including custom ones) may be run using \"run\" command.\n",
-		            "examples": [
-		              [
-		                {
-		                  "runnerName": {
-		                    "some": "params"
-		                  }
-		                }
-		              ]
-		            ],
-		            "tsType": "({ [k: string]: unknown; } | string )[] | undefined",
-		            "type": "array",
-		            "items": {
-		              "oneOf": [
-		                {
-		                  "type": "object",
-		                  "minProperties": 1,
-		                  "maxProperties": 1,
-		                  "additionalProperties": true
-		                },
-		                {
-		                  "type": "string"
-		                }
-		              ]
-		            }
-		          },
-		          "$defs": {
-		            "task": {
-		              "type": "array",
-		              "items": {
-		                "oneOf": [
-		                  {
-		                    "type": "object",
-		                    "minProperties": 1,
-		                    "maxProperties": 1,
-		                    "additionalProperties": true
-		                  },
-		                  {
-		                    "type": "string"
-		                  }
-		                ]
-		              }
-		            }
-		          }
-		        }
-		      }
-		    },
-		    {
-		      "title": "Tagger",
-		      "type": "object",
-		      "required": [
-		        "apiVersion",
-		        "kind",
-		        "name",
-		        "script"
-		      ],
-		      "additionalProperties": false,
-		      "properties": {
-		        "apiVersion": {
-		          "description": "Version of the configuration format.",
-		          "const": "g2a-cli/v2.0"
-		        },
-		        "kind": {
-		          "const": "Tagger"
-		        },
-		        "name": {
-		          "description": "Name of the object, unique within the kind.",
-		          "type": "string",
-		          "minLength": 1,
-		          "pattern": "^[a-z][A-Za-z0-9_-]*$"
-		        },
-		        "schema": {
-		          "$schema": "https://json-schema.org/draft/2019-09/schema",
-		          "$id": "https://json-schema.org/draft/2019-09/schema",
-		          "$vocabulary": {
-		            "https://json-schema.org/draft/2019-09/vocab/core": true,
-		            "https://json-schema.org/draft/2019-09/vocab/applicator": true,
-		            "https://json-schema.org/draft/2019-09/vocab/validation": true,
-		            "https://json-schema.org/draft/2019-09/vocab/meta-data": true,
-		            "https://json-schema.org/draft/2019-09/vocab/format": false,
-		            "https://json-schema.org/draft/2019-09/vocab/content": true
-		          },
-		          "$recursiveAnchor": true,
-		          "title": "Core and Validation specifications meta-schema",
-		          "allOf": [
-		            {
-		              "$schema": "https://json-schema.org/draft/2019-09/schema",
-		              "$id": "https://json-schema.org/draft/2019-09/meta/core",
-		              "$vocabulary": {
-		                "https://json-schema.org/draft/2019-09/vocab/core": true
-		              },
-		              "$recursiveAnchor": true,
-		              "title": "Core vocabulary meta-schema",
-		              "type": [
-		                "object",
-		                "boolean"
+		            {
+		              "$schema": "https://json-schema.org/draft/2019-09/schema",
+		              "$id": "https://json-schema.org/draft/2019-09/meta/validation",
+		              "$vocabulary": {
+		                "https://json-schema.org/draft/2019-09/vocab/validation": true
+		              },
+		              "$recursiveAnchor": true,
+		              "title": "Validation vocabulary meta-schema",
+		              "type": [
+		                "object",
+		                "boolean"
 		              ],
 		              "properties": {
-		                "$id": {
-		                  "type": "string",
-		                  "format": "uri-reference",
-		                  "$comment": "Non-empty fragments not allowed.",
-		                  "pattern": "^[^#]*#?$"
+		                "multipleOf": {
+		                  "type": "number",
+		                  "exclusiveMinimum": 0
 		                },
-		                "$schema": {
-		                  "type": "string",
-		                  "format": "uri"
+		                "maximum": {
+		                  "type": "number"
 		                },
-		                "$anchor": {
-		                  "type": "string",
-		                  "pattern": "^[A-Za-z][-A-Za-z0-9.:_]*$"
+		                "exclusiveMaximum": {
+		                  "type": "number"
 		                },
-		                "$ref": {
-		                  "type": "string",
-		                  "format": "uri-reference"
+		                "minimum": {
+		                  "type": "number"
 		                },
-		                "$recursiveRef": {
+		                "exclusiveMinimum": {
+		                  "type": "number"
+		                },
+		                "maxLength": {
+		                  "type": "integer",
+		                  "minimum": 0
+		                },
+		                "minLength": {
+		                  "default": 0,
+		                  "type": "integer",
+		                  "minimum": 0
+		                },
+		                "pattern": {
 		                  "type": "string",
-		                  "format": "uri-reference"
+		                  "format": "regex"
 		                },
-		                "$recursiveAnchor": {
+		                "maxItems": {
+		                  "type": "integer",
+		                  "minimum": 0
+		                },
+		                "minItems": {
+		                  "default": 0,
+		                  "type": "integer",
+		                  "minimum": 0
+		                },
+		                "uniqueItems": {
 		                  "type": "boolean",
 		                  "default": false
 		                },
-		                "$vocabulary": {
-		                  "type": "object",
-		                  "propertyNames": {
-		                    "type": "string",
-		                    "format": "uri"
-		                  },
-		                  "additionalProperties": {
-		                    "type": "boolean"
-		                  }
+		                "maxContains": {
+		                  "type": "integer",
+		                  "minimum": 0
 		                },
-		                "$comment": {
-		                  "type": "string"
+		                "minContains": {
+		                  "type": "integer",
+		                  "minimum": 0,
+		                  "default": 1
 		                },
-		                "$defs": {
+		                "maxProperties": {
+		                  "type": "integer",
+		                  "minimum": 0
+		                },
+		                "minProperties": {
+		                  "default": 0,
+		                  "type": "integer",
+		                  "minimum": 0
+		                },
+		                "required": {
+		                  "type": "array",
+		                  "items": {
+		                    "type": "string"
+		                  },
+		                  "uniqueItems": true,
+		                  "default": []
+		                },
+		                "dependentRequired": {
 		                  "type": "object",
 		                  "additionalProperties": {
-		                    "$recursiveRef": "#"
-		                  },
-		                  "default": {}
-		                }
-		              }
-		            },
-		            {
-		              "$schema": "https://json-schema.org/draft/2019-09/schema",
-		              "$id": "https://json-schema.org/draft/2019-09/meta/applicator",
-		              "$vocabulary": {
-		                "https://json-schema.org/draft/2019-09/vocab/applicator": true
-		              },
-		              "$recursiveAnchor": true,
-		              "title": "Applicator vocabulary meta-schema",
-		              "type": [
-		                "object",
-		                "boolean"
-		              ],
-		              "properties": {
-		                "additionalItems": {
-		                  "$recursiveRef": "#"
+		                    "type": "array",
+		                    "items": {
+		                      "type": "string"
+		                    },
+		                    "uniqueItems": true,
+		                    "default": []
+		                  }
 		                },
-		                "unevaluatedItems": {
-		                  "$recursiveRef": "#"
+		                "const": true,
+		                "enum": {
+		                  "type": "array",
+		                  "items": true
 		                },
-		                "items": {
+		                "type": {
 		                  "anyOf": [
 		                    {
-		                      "$recursiveRef": "#"
+		                      "enum": [
+		                        "array",
+		                        "boolean",
+		                        "integer",
+		                        "null",
+		                        "number",
+		                        "object",
+		                        "string"
+		                      ]
 		                    },
 		                    {
 		                      "type": "array",
-		                      "minItems": 1,
 		                      "items": {
-		                        "$recursiveRef": "#"
-		                      }
+		                        "enum": [
+		                          "array",
+		                          "boolean",
+		                          "integer",
+		                          "null",
+		                          "number",
+		                          "object",
+		                          "string"
+		                        ]
+		                      },
+		                      "minItems": 1,
+		                      "uniqueItems": true
 		                    }
 		                  ]
+		                }
+		              },
+		              "$defs": {
+		                "nonNegativeInteger": {
+		                  "type": "integer",
+		                  "minimum": 0
 		                },
-		                "contains": {
-		                  "$recursiveRef": "#"
-		                },
-		                "additionalProperties": {
-		                  "$recursiveRef": "#"
-		                },
-		                "unevaluatedProperties": {
-		                  "$recursiveRef": "#"
-		                },
-		                "properties": {
-		                  "type": "object",
-		                  "additionalProperties": {
-		                    "$recursiveRef": "#"
-		                  },
-		                  "default": {}
-		                },
-		                "patternProperties": {
-		                  "type": "object",
-		                  "additionalProperties": {
-		                    "$recursiveRef": "#"
-		                  },
-		                  "propertyNames": {
-		                    "format": "regex"
-		                  },
-		                  "default": {}
-		                },
-		                "dependentSchemas": {
-		                  "type": "object",
-		                  "additionalProperties": {
-		                    "$recursiveRef": "#"
-		                  }
-		                },
-		                "propertyNames": {
-		                  "$recursiveRef": "#"
-		                },
-		                "if": {
-		                  "$recursiveRef": "#"
-		                },
-		                "then": {
-		                  "$recursiveRef": "#"
-		                },
-		                "else": {
-		                  "$recursiveRef": "#"
-		                },
-		                "allOf": {
-		                  "type": "array",
-		                  "minItems": 1,
-		                  "items": {
-		                    "$recursiveRef": "#"
-		                  }
-		                },
-		                "anyOf": {
-		                  "type": "array",
-		                  "minItems": 1,
-		                  "items": {
-		                    "$recursiveRef": "#"
-		                  }
+		                "nonNegativeIntegerDefault0": {
+		                  "type": "integer",
+		                  "minimum": 0,
+		                  "default": 0
 		                },
-		                "oneOf": {
-		                  "type": "array",
-		                  "minItems": 1,
-		                  "items": {
-		                    "$recursiveRef": "#"
-		                  }
+		                "simpleTypes": {
+		                  "enum": [
+		                    "array",
+		                    "boolean",
+		                    "integer",
+		                    "null",
+		                    "number",
+		                    "object",
+		                    "string"
+		                  ]
 		                },
-		                "not": {
-		                  "$recursiveRef": "#"
-		                }
-		              },
-		              "$defs": {
-		                "schemaArray": {
+		                "stringArray": {
 		                  "type": "array",
-		                  "minItems": 1,
 		                  "items": {
-		                    "$recursiveRef": "#"
-		                  }
+		                    "type": "string"
+		                  },
+		                  "uniqueItems": true,
+		                  "default": []
 		                }
 		              }
 		            },
 		            {
 		              "$schema": "https://json-schema.org/draft/2019-09/schema",
-		              "$id": "https://json-schema.org/draft/2019-09/meta/validation",
+		              "$id": "https://json-schema.org/draft/2019-09/meta/meta-data",
 		              "$vocabulary": {
-		                "https://json-schema.org/draft/2019-09/vocab/validation": true
+		                "https://json-schema.org/draft/2019-09/vocab/meta-data": true
 		              },
 		              "$recursiveAnchor": true,
-		              "title": "Validation vocabulary meta-schema",
+		              "title": "Meta-data vocabulary meta-schema",
 		              "type": [
 		                "object",
 		                "boolean"
 		              ],
 		              "properties": {
-		                "multipleOf": {
-		                  "type": "number",
-		                  "exclusiveMinimum": 0
-		                },
-		                "maximum": {
-		                  "type": "number"
-		                },
-		                "exclusiveMaximum": {
-		                  "type": "number"
-		                },
-		                "minimum": {
-		                  "type": "number"
-		                },
-		                "exclusiveMinimum": {
-		                  "type": "number"
-		                },
-		                "maxLength": {
-		                  "type": "integer",
-		                  "minimum": 0
-		                },
-		                "minLength": {
-		                  "default": 0,
-		                  "type": "integer",
-		                  "minimum": 0
-		                },
-		                "pattern": {
-		                  "type": "string",
-		                  "format": "regex"
-		                },
-		                "maxItems": {
-		                  "type": "integer",
-		                  "minimum": 0
+		                "title": {
+		                  "type": "string"
 		                },
-		                "minItems": {
-		                  "default": 0,
-		                  "type": "integer",
-		                  "minimum": 0
+		                "description": {
+		                  "type": "string"
 		                },
-		                "uniqueItems": {
+		                "default": true,
+		                "deprecated": {
 		                  "type": "boolean",
 		                  "default": false
 		                },
-		                "maxContains": {
-		                  "type": "integer",
-		                  "minimum": 0
+		                "readOnly": {
+		                  "type": "boolean",
+		                  "default": false
 		                },
-		                "minContains": {
-		                  "type": "integer",
-		                  "minimum": 0,
-		                  "default": 1
+		                "writeOnly": {
+		                  "type": "boolean",
+		                  "default": false
 		                },
-		                "maxProperties": {
-		                  "type": "integer",
-		                  "minimum": 0
+		                "examples": {
+		                  "type": "array",
+		                  "items": true
+		                }
+		              }
+		            },
+		            {
+		              "$schema": "https://json-schema.org/draft/2019-09/schema",
+		              "$id": "https://json-schema.org/draft/2019-09/meta/format",
+		              "$vocabulary": {
+		                "https://json-schema.org/draft/2019-09/vocab/format": true
+		              },
+		              "$recursiveAnchor": true,
+		              "title": "Format vocabulary meta-schema",
+		              "type": [
+		                "object",
+		                "boolean"
+		              ],
+		              "properties": {
+		                "format": {
+		                  "type": "string"
+		                }
+		              }
+		            },
+		            {
+		              "$schema": "https://json-schema.org/draft/2019-09/schema",
+		              "$id": "https://json-schema.org/draft/2019-09/meta/content",
+		              "$vocabulary": {
+		                "https://json-schema.org/draft/2019-09/vocab/content": true
+		              },
+		              "$recursiveAnchor": true,
+		              "title": "Content vocabulary meta-schema",
+		              "type": [
+		                "object",
+		                "boolean"
+		              ],
+		              "properties": {
+		                "contentMediaType": {
+		                  "type": "string"
 		                },
-		                "minProperties": {
-		                  "default": 0,
-		                  "type": "integer",
-		                  "minimum": 0
+		                "contentEncoding": {
+		                  "type": "string"
 		                },
-		                "required": {
-		                  "type": "array",
-		                  "items": {
-		                    "type": "string"
+		                "contentSchema": {
+		                  "$recursiveRef": "#"
+		                }
+		              }
+		            }
+		          ],
+		          "type": [
+		            "object",
+		            "boolean"
+		          ],
+		          "properties": {
+		            "definitions": {
+		              "$comment": "While no longer an official keyword as it is replaced by $defs, this keyword is retained in the meta-schema to prevent incompatible extensions as it remains in common use.",
+		              "type": "object",
+		              "additionalProperties": {
+		                "$recursiveRef": "#"
+		              },
+		              "default": {}
+		            },
+		            "dependencies": {
+		              "$comment": "\"dependencies\" is no longer a keyword, but schema authors should avoid redefining it to facilitate a smooth transition to \"dependentSchemas\" and \"dependentRequired\"",
+		              "type": "object",
+		              "additionalProperties": {
+		                "anyOf": [
+		                  {
+		                    "$recursiveRef": "#"
 		                  },
-		                  "uniqueItems": true,
-		                  "default": []
-		                },
-		                "dependentRequired": {
-		                  "type": "object",
-		                  "additionalProperties": {
+		                  {
 		                    "type": "array",
 		                    "items": {
 		                      "type": "string"
@@ -4406,55 +5322,1851 @@ var SCHEMAS = map[string][]byte{
 		                    "uniqueItems": true,
 		                    "default": []
 		                  }
-		                },
-		                "const": true,
-		                "enum": {
-		                  "type": "array",
-		                  "items": true
-		                },
-		                "type": {
-		                  "anyOf": [
-		                    {
-		                      "enum": [
-		                        "array",
-		                        "boolean",
-		                        "integer",
-		                        "null",
-		                        "number",
-		                        "object",
-		                        "string"
-		                      ]
-		                    },
-		                    {
-		                      "type": "array",
-		                      "items": {
-		                        "enum": [
-		                          "array",
-		                          "boolean",
-		                          "integer",
-		                          "null",
-		                          "number",
-		                          "object",
-		                          "string"
+		                ]
+		              }
+		            }
+		          }
+		        },
+		        "script": {
+		          "type": "string"
+		        },
+		        "allowOutsideProject": {
+		          "type": "boolean"
+		        },
+		        "commands": {
+		          "description": "Commands which the executor runs using the \"exec\" module. When specified, other commands are rejected. Project's \"executorPolicy\" may further restrict commands available to executors.\n",
+		          "examples": [
+		            [
+		              "docker",
+		              "git"
+		            ]
+		          ],
+		          "type": "array",
+		          "items": {
+		            "type": "string",
+		            "minLength": 1
+		          }
+		        },
+		        "command": {
+		          "description": "External program implementing the executor, used instead of the script. The first item is the program (paths containing a slash are relative to the executor's file), remaining items are its arguments. The program receives input as JSON on stdin and writes results, log messages and errors as JSON lines to stdout. Its stderr is logged.\n",
+		          "examples": [
+		            [
+		              "./deployer.py"
+		            ],
+		            [
+		              "python3",
+		              "./deployer.py",
+		              "--verbose"
+		            ]
+		          ],
+		          "type": "array",
+		          "minItems": 1,
+		          "items": {
+		            "type": "string",
+		            "minLength": 1
+		          }
+		        }
+		      }
+		    },
+		    {
+		      "title": "Service",
+		      "type": "object",
+		      "required": [
+		        "apiVersion",
+		        "kind",
+		        "name"
+		      ],
+		      "properties": {
+		        "apiVersion": {
+		          "description": "Version of the configuration format.",
+		          "const": "g2a-cli/v2.0"
+		        },
+		        "kind": {
+		          "description": "Determines type of the document.",
+		          "const": "Service"
+		        },
+		        "name": {
+		          "description": "Name of the object, unique within the kind.",
+		          "type": "string",
+		          "minLength": 1,
+		          "pattern": "^[a-z][A-Za-z0-9_-]*$",
+		          "examples": [
+		            "example-api"
+		          ]
+		        },
+		        "dependsOn": {
+		          "description": "List of services which have to be handled before this one. Build and deploy commands process services in the order determined by these dependencies.\n",
+		          "examples": [
+		            [
+		              "database-migration"
+		            ]
+		          ],
+		          "type": "array",
+		          "items": {
+		            "description": "Name of the object, unique within the kind.",
+		            "type": "string",
+		            "minLength": 1,
+		            "pattern": "^[a-z][A-Za-z0-9_-]*$"
+		          }
+		        },
+		        "artifacts": {
+		          "description": "List of artifacts to produce by build command. Each entry describes single artifact like docker image or npm package.\n",
+		          "type": "array",
+		          "items": {
+		            "examples": [
+		              {
+		                "docker": {
+		                  "image": "example.com/test/image"
+		                }
+		              },
+		              {
+		                "hugo": {
+		                  "dir": "{{ .Service.Dir }}/docs"
+		                },
+		                "push": {
+		                  "artifactory": {
+		                    "path": {
+		                      "source": "{{ .Service.Dir }}/docs/public/*",
+		                      "target": "docs-snapshot-local/generic-api/{{ .Tag }}/"
+		                    }
+		                  }
+		                }
+		              },
+		              {
+		                "docker": {
+		                  "image": "example.com/test/image2"
+		                },
+		                "push": false
+		              }
+		            ],
+		            "x-examplesDescriptions": [
+		              "Each artifact definition contains a single property defining names of executors (builder and pusher) used to handle it. Format of the configuration within is determined by a schema attached to Builder definition. If there is a matching Pusher, configuration must conform to its schema as well.",
+		              "If you want to use Pusher and Builder with different names or different configuration formats, add \"push\" property with a separate pusher definition.",
+		              "If you don't want to push artifact, set \"push\" property to false."
+		            ],
+		            "oneOf": [
+		              {
+		                "oneOf": [
+		                  {
+		                    "type": "object",
+		                    "properties": {
+		                      "timeout": {
+		                        "description": "Time limit for the entry. Executor is interrupted when it's exceeded and the entry fails.\n",
+		                        "examples": [
+		                          "10m"
+		                        ],
+		                        "type": "string",
+		                        "pattern": "^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+		                      }
+		                    },
+		                    "if": {
+		                      "required": [
+		                        "timeout"
+		                      ]
+		                    },
+		                    "then": {
+		                      "minProperties": 2,
+		                      "maxProperties": 2
+		                    },
+		                    "else": {
+		                      "minProperties": 1,
+		                      "maxProperties": 1
+		                    },
+		                    "additionalProperties": true
+		                  },
+		                  {
+		                    "type": "string"
+		                  }
+		                ]
+		              },
+		              {
+		                "type": "object",
+		                "required": [
+		                  "push"
+		                ],
+		                "properties": {
+		                  "push": {
+		                    "tsType": "false | Record<string, unknown>",
+		                    "oneOf": [
+		                      {
+		                        "oneOf": [
+		                          {
+		                            "type": "object",
+		                            "properties": {
+		                              "timeout": {
+		                                "description": "Time limit for the entry. Executor is interrupted when it's exceeded and the entry fails.\n",
+		                                "examples": [
+		                                  "10m"
+		                                ],
+		                                "type": "string",
+		                                "pattern": "^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+		                              }
+		                            },
+		                            "if": {
+		                              "required": [
+		                                "timeout"
+		                              ]
+		                            },
+		                            "then": {
+		                              "minProperties": 2,
+		                              "maxProperties": 2
+		                            },
+		                            "else": {
+		                              "minProperties": 1,
+		                              "maxProperties": 1
+		                            },
+		                            "additionalProperties": true
+		                          },
+		                          {
+		                            "type": "string"
+		                          }
 		                        ]
 		                      },
-		                      "minItems": 1,
-		                      "uniqueItems": true
-		                    }
+		                      {
+		                        "const": false
+		                      }
+		                    ]
+		                  },
+		                  "timeout": {
+		                    "description": "Time limit for building the artifact. Unless \"push\" defines its own timeout, it's used for pushing as well.\n",
+		                    "examples": [
+		                      "10m"
+		                    ],
+		                    "type": "string",
+		                    "pattern": "^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+		                  }
+		                },
+		                "if": {
+		                  "required": [
+		                    "timeout"
+		                  ]
+		                },
+		                "then": {
+		                  "minProperties": 3,
+		                  "maxProperties": 3
+		                },
+		                "else": {
+		                  "minProperties": 2,
+		                  "maxProperties": 2
+		                },
+		                "additionalProperties": true
+		              }
+		            ]
+		          }
+		        },
+		        "tags": {
+		          "description": "Describes how to generate tags used when pushing artifacts to registry.\n",
+		          "type": "array",
+		          "items": {
+		            "oneOf": [
+		              {
+		                "type": "object",
+		                "properties": {
+		                  "timeout": {
+		                    "description": "Time limit for the entry. Executor is interrupted when it's exceeded and the entry fails.\n",
+		                    "examples": [
+		                      "10m"
+		                    ],
+		                    "type": "string",
+		                    "pattern": "^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+		                  }
+		                },
+		                "if": {
+		                  "required": [
+		                    "timeout"
+		                  ]
+		                },
+		                "then": {
+		                  "minProperties": 2,
+		                  "maxProperties": 2
+		                },
+		                "else": {
+		                  "minProperties": 1,
+		                  "maxProperties": 1
+		                },
+		                "additionalProperties": true
+		              },
+		              {
+		                "type": "string"
+		              }
+		            ],
+		            "examples": [
+		              "gitSha",
+		              "gitTag"
+		            ]
+		          }
+		        },
+		        "releases": {
+		          "description": "List of releases to do by deploy command.\n",
+		          "type": "array",
+		          "items": {
+		            "oneOf": [
+		              {
+		                "type": "object",
+		                "properties": {
+		                  "timeout": {
+		                    "description": "Time limit for the entry. Executor is interrupted when it's exceeded and the entry fails.\n",
+		                    "examples": [
+		                      "10m"
+		                    ],
+		                    "type": "string",
+		                    "pattern": "^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+		                  }
+		                },
+		                "if": {
+		                  "required": [
+		                    "timeout"
 		                  ]
+		                },
+		                "then": {
+		                  "minProperties": 2,
+		                  "maxProperties": 2
+		                },
+		                "else": {
+		                  "minProperties": 1,
+		                  "maxProperties": 1
+		                },
+		                "additionalProperties": true
+		              },
+		              {
+		                "type": "string"
+		              }
+		            ],
+		            "examples": [
+		              {
+		                "helm": {
+		                  "name": "redis",
+		                  "chartPath": "bitnami/redis",
+		                  "valuesFiles": [
+		                    "{{ .Environment.Dir }}/redis.yaml"
+		                  ],
+		                  "chartRepository": {
+		                    "name": "bitnami",
+		                    "url": "https://charts.bitnami.com/bitnami"
+		                  }
 		                }
+		              }
+		            ]
+		          }
+		        },
+		        "tasks": {
+		          "description": "Definitions of the tasks used by commands \"prepare\", \"test\", \"lint\" and \"run\". These tasks may be also specified in the Project definition.",
+		          "type": "object",
+		          "properties": {
+		            "prepare": {
+		              "description": "Defines steps required to preapre freshly clonned repository for development, tests or build. This definition is used by \"prepare\" command.\n",
+		              "examples": [
+		                [
+		                  {
+		                    "make": {
+		                      "target": "prepare"
+		                    }
+		                  }
+		                ]
+		              ],
+		              "type": "array",
+		              "items": {
+		                "oneOf": [
+		                  {
+		                    "type": "object",
+		                    "properties": {
+		                      "timeout": {
+		                        "description": "Time limit for the entry. Executor is interrupted when it's exceeded and the entry fails.\n",
+		                        "examples": [
+		                          "10m"
+		                        ],
+		                        "type": "string",
+		                        "pattern": "^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+		                      }
+		                    },
+		                    "if": {
+		                      "required": [
+		                        "timeout"
+		                      ]
+		                    },
+		                    "then": {
+		                      "minProperties": 2,
+		                      "maxProperties": 2
+		                    },
+		                    "else": {
+		                      "minProperties": 1,
+		                      "maxProperties": 1
+		                    },
+		                    "additionalProperties": true
+		                  },
+		                  {
+		                    "type": "string"
+		                  }
+		                ]
+		              }
+		            },
+		            "test": {
+		              "description": "Defines how to run tests. This definition is used by \"test\" command.\n",
+		              "examples": [
+		                [
+		                  {
+		                    "script": {
+		                      "sh": "go test ./..."
+		                    }
+		                  }
+		                ]
+		              ],
+		              "type": "array",
+		              "items": {
+		                "oneOf": [
+		                  {
+		                    "type": "object",
+		                    "properties": {
+		                      "timeout": {
+		                        "description": "Time limit for the entry. Executor is interrupted when it's exceeded and the entry fails.\n",
+		                        "examples": [
+		                          "10m"
+		                        ],
+		                        "type": "string",
+		                        "pattern": "^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+		                      }
+		                    },
+		                    "if": {
+		                      "required": [
+		                        "timeout"
+		                      ]
+		                    },
+		                    "then": {
+		                      "minProperties": 2,
+		                      "maxProperties": 2
+		                    },
+		                    "else": {
+		                      "minProperties": 1,
+		                      "maxProperties": 1
+		                    },
+		                    "additionalProperties": true
+		                  },
+		                  {
+		                    "type": "string"
+		                  }
+		                ]
+		              }
+		            },
+		            "lint": {
+		              "description": "Defines how to lint the code. Ideally should try to fix the issues. This definition is used by \"lint\" command.\n",
+		              "examples": [
+		                [
+		                  "prettier"
+		                ]
+		              ],
+		              "type": "array",
+		              "items": {
+		                "oneOf": [
+		                  {
+		                    "type": "object",
+		                    "properties": {
+		                      "timeout": {
+		                        "description": "Time limit for the entry. Executor is interrupted when it's exceeded and the entry fails.\n",
+		                        "examples": [
+		                          "10m"
+		                        ],
+		                        "type": "string",
+		                        "pattern": "^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+		                      }
+		                    },
+		                    "if": {
+		                      "required": [
+		                        "timeout"
+		                      ]
+		                    },
+		                    "then": {
+		                      "minProperties": 2,
+		                      "maxProperties": 2
+		                    },
+		                    "else": {
+		                      "minProperties": 1,
+		                      "maxProperties": 1
+		                    },
+		                    "additionalProperties": true
+		                  },
+		                  {
+		                    "type": "string"
+		                  }
+		                ]
+		              }
+		            }
+		          },
+		          "additionalProperties": {
+		            "description": "You are not bound to use pre-defined tasks. All tasks (including custom ones) may be run using \"run\" command.\n",
+		            "examples": [
+		              [
+		                {
+		                  "runnerName": {
+		                    "some": "params"
+		                  }
+		                }
+		              ]
+		            ],
+		            "tsType": "({ [k: string]: unknown; } | string )[] | undefined",
+		            "type": "array",
+		            "items": {
+		              "oneOf": [
+		                {
+		                  "type": "object",
+		                  "properties": {
+		                    "timeout": {
+		                      "description": "Time limit for the entry. Executor is interrupted when it's exceeded and the entry fails.\n",
+		                      "examples": [
+		                        "10m"
+		                      ],
+		                      "type": "string",
+		                      "pattern": "^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+		                    }
+		                  },
+		                  "if": {
+		                    "required": [
+		                      "timeout"
+		                    ]
+		                  },
+		                  "then": {
+		                    "minProperties": 2,
+		                    "maxProperties": 2
+		                  },
+		                  "else": {
+		                    "minProperties": 1,
+		                    "maxProperties": 1
+		                  },
+		                  "additionalProperties": true
+		                },
+		                {
+		                  "type": "string"
+		                }
+		              ]
+		            }
+		          },
+		          "$defs": {
+		            "task": {
+		              "type": "array",
+		              "items": {
+		                "oneOf": [
+		                  {
+		                    "type": "object",
+		                    "properties": {
+		                      "timeout": {
+		                        "description": "Time limit for the entry. Executor is interrupted when it's exceeded and the entry fails.\n",
+		                        "examples": [
+		                          "10m"
+		                        ],
+		                        "type": "string",
+		                        "pattern": "^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+		                      }
+		                    },
+		                    "if": {
+		                      "required": [
+		                        "timeout"
+		                      ]
+		                    },
+		                    "then": {
+		                      "minProperties": 2,
+		                      "maxProperties": 2
+		                    },
+		                    "else": {
+		                      "minProperties": 1,
+		                      "maxProperties": 1
+		                    },
+		                    "additionalProperties": true
+		                  },
+		                  {
+		                    "type": "string"
+		                  }
+		                ]
+		              }
+		            }
+		          }
+		        }
+		      }
+		    },
+		    {
+		      "title": "Tagger",
+		      "type": "object",
+		      "required": [
+		        "apiVersion",
+		        "kind",
+		        "name"
+		      ],
+		      "if": {
+		        "required": [
+		          "command"
+		        ]
+		      },
+		      "then": {
+		        "properties": {
+		          "script": false
+		        }
+		      },
+		      "else": {
+		        "required": [
+		          "script"
+		        ]
+		      },
+		      "additionalProperties": false,
+		      "properties": {
+		        "apiVersion": {
+		          "description": "Version of the configuration format.",
+		          "const": "g2a-cli/v2.0"
+		        },
+		        "kind": {
+		          "const": "Tagger"
+		        },
+		        "name": {
+		          "description": "Name of the object, unique within the kind.",
+		          "type": "string",
+		          "minLength": 1,
+		          "pattern": "^[a-z][A-Za-z0-9_-]*$"
+		        },
+		        "schema": {
+		          "$schema": "https://json-schema.org/draft/2019-09/schema",
+		          "$id": "https://json-schema.org/draft/2019-09/schema",
+		          "$vocabulary": {
+		            "https://json-schema.org/draft/2019-09/vocab/core": true,
+		            "https://json-schema.org/draft/2019-09/vocab/applicator": true,
+		            "https://json-schema.org/draft/2019-09/vocab/validation": true,
+		            "https://json-schema.org/draft/2019-09/vocab/meta-data": true,
+		            "https://json-schema.org/draft/2019-09/vocab/format": false,
+		            "https://json-schema.org/draft/2019-09/vocab/content": true
+		          },
+		          "$recursiveAnchor": true,
+		          "title": "Core and Validation specifications meta-schema",
+		          "allOf": [
+		            {
+		              "$schema": "https://json-schema.org/draft/2019-09/schema",
+		              "$id": "https://json-schema.org/draft/2019-09/meta/core",
+		              "$vocabulary": {
+		                "https://json-schema.org/draft/2019-09/vocab/core": true
+		              },
+		              "$recursiveAnchor": true,
+		              "title": "Core vocabulary meta-schema",
+		              "type": [
+		                "object",
+		                "boolean"
+		              ],
+		              "properties": {
+		                "$id": {
+		                  "type": "string",
+		                  "format": "uri-reference",
+		                  "$comment": "Non-empty fragments not allowed.",
+		                  "pattern": "^[^#]*#?$"
+		                },
+		                "$schema": {
+		                  "type": "string",
+		                  "format": "uri"
+		                },
+		                "$anchor": {
+		                  "type": "string",
+		                  "pattern": "^[A-Za-z][-A-Za-z0-9.:_]*$"
+		                },
+		                "$ref": {
+		                  "type": "string",
+		                  "format": "uri-reference"
+		                },
+		                "$recursiveRef": {
+		                  "type": "string",
+		                  "format": "uri-reference"
+		                },
+		                "$recursiveAnchor": {
+		                  "type": "boolean",
+		                  "default": false
+		                },
+		                "$vocabulary": {
+		                  "type": "object",
+		                  "propertyNames": {
+		                    "type": "string",
+		                    "format": "uri"
+		                  },
+		                  "additionalProperties": {
+		                    "type": "boolean"
+		                  }
+		                },
+		                "$comment": {
+		                  "type": "string"
+		                },
+		                "$defs": {
+		                  "type": "object",
+		                  "additionalProperties": {
+		                    "$recursiveRef": "#"
+		                  },
+		                  "default": {}
+		                }
+		              }
+		            },
+		            {
+		              "$schema": "https://json-schema.org/draft/2019-09/schema",
+		              "$id": "https://json-schema.org/draft/2019-09/meta/applicator",
+		              "$vocabulary": {
+		                "https://json-schema.org/draft/2019-09/vocab/applicator": true
+		              },
+		              "$recursiveAnchor": true,
+		              "title": "Applicator vocabulary meta-schema",
+		              "type": [
+		                "object",
+		                "boolean"
+		              ],
+		              "properties": {
+		                "additionalItems": {
+		                  "$recursiveRef": "#"
+		                },
+		                "unevaluatedItems": {
+		                  "$recursiveRef": "#"
+		                },
+		                "items": {
+		                  "anyOf": [
+		                    {
+		                      "$recursiveRef": "#"
+		                    },
+		                    {
+		                      "type": "array",
+		                      "minItems": 1,
+		                      "items": {
+		                        "$recursiveRef": "#"
+		                      }
+		                    }
+		                  ]
+		                },
+		                "contains": {
+		                  "$recursiveRef": "#"
+		                },
+		                "additionalProperties": {
+		                  "$recursiveRef": "#"
+		                },
+		                "unevaluatedProperties": {
+		                  "$recursiveRef": "#"
+		                },
+		                "properties": {
+		                  "type": "object",
+		                  "additionalProperties": {
+		                    "$recursiveRef": "#"
+		                  },
+		                  "default": {}
+		                },
+		                "patternProperties": {
+		                  "type": "object",
+		                  "additionalProperties": {
+		                    "$recursiveRef": "#"
+		                  },
+		                  "propertyNames": {
+		                    "format": "regex"
+		                  },
+		                  "default": {}
+		                },
+		                "dependentSchemas": {
+		                  "type": "object",
+		                  "additionalProperties": {
+		                    "$recursiveRef": "#"
+		                  }
+		                },
+		                "propertyNames": {
+		                  "$recursiveRef": "#"
+		                },
+		                "if": {
+		                  "$recursiveRef": "#"
+		                },
+		                "then": {
+		                  "$recursiveRef": "#"
+		                },
+		                "else": {
+		                  "$recursiveRef": "#"
+		                },
+		                "allOf": {
+		                  "type": "array",
+		                  "minItems": 1,
+		                  "items": {
+		                    "$recursiveRef": "#"
+		                  }
+		                },
+		                "anyOf": {
+		                  "type": "array",
+		                  "minItems": 1,
+		                  "items": {
+		                    "$recursiveRef": "#"
+		                  }
+		                },
+		                "oneOf": {
+		                  "type": "array",
+		                  "minItems": 1,
+		                  "items": {
+		                    "$recursiveRef": "#"
+		                  }
+		                },
+		                "not": {
+		                  "$recursiveRef": "#"
+		                }
+		              },
+		              "$defs": {
+		                "schemaArray": {
+		                  "type": "array",
+		                  "minItems": 1,
+		                  "items": {
+		                    "$recursiveRef": "#"
+		                  }
+		                }
+		              }
+		            },
+		            {
+		              "$schema": "https://json-schema.org/draft/2019-09/schema",
+		              "$id": "https://json-schema.org/draft/2019-09/meta/validation",
+		              "$vocabulary": {
+		                "https://json-schema.org/draft/2019-09/vocab/validation": true
+		              },
+		              "$recursiveAnchor": true,
+		              "title": "Validation vocabulary meta-schema",
+		              "type": [
+		                "object",
+		                "boolean"
+		              ],
+		              "properties": {
+		                "multipleOf": {
+		                  "type": "number",
+		                  "exclusiveMinimum": 0
+		                },
+		                "maximum": {
+		                  "type": "number"
+		                },
+		                "exclusiveMaximum": {
+		                  "type": "number"
+		                },
+		                "minimum": {
+		                  "type": "number"
+		                },
+		                "exclusiveMinimum": {
+		                  "type": "number"
+		                },
+		                "maxLength": {
+		                  "type": "integer",
+		                  "minimum": 0
+		                },
+		                "minLength": {
+		                  "default": 0,
+		                  "type": "integer",
+		                  "minimum": 0
+		                },
+		                "pattern": {
+		                  "type": "string",
+		                  "format": "regex"
+		                },
+		                "maxItems": {
+		                  "type": "integer",
+		                  "minimum": 0
+		                },
+		                "minItems": {
+		                  "default": 0,
+		                  "type": "integer",
+		                  "minimum": 0
+		                },
+		                "uniqueItems": {
+		                  "type": "boolean",
+		                  "default": false
+		                },
+		                "maxContains": {
+		                  "type": "integer",
+		                  "minimum": 0
+		                },
+		                "minContains": {
+		                  "type": "integer",
+		                  "minimum": 0,
+		                  "default": 1
+		                },
+		                "maxProperties": {
+		                  "type": "integer",
+		                  "minimum": 0
+		                },
+		                "minProperties": {
+		                  "default": 0,
+		                  "type": "integer",
+		                  "minimum": 0
+		                },
+		                "required": {
+		                  "type": "array",
+		                  "items": {
+		                    "type": "string"
+		                  },
+		                  "uniqueItems": true,
+		                  "default": []
+		                },
+		                "dependentRequired": {
+		                  "type": "object",
+		                  "additionalProperties": {
+		                    "type": "array",
+		                    "items": {
+		                      "type": "string"
+		                    },
+		                    "uniqueItems": true,
+		                    "default": []
+		                  }
+		                },
+		                "const": true,
+		                "enum": {
+		                  "type": "array",
+		                  "items": true
+		                },
+		                "type": {
+		                  "anyOf": [
+		                    {
+		                      "enum": [
+		                        "array",
+		                        "boolean",
+		                        "integer",
+		                        "null",
+		                        "number",
+		                        "object",
+		                        "string"
+		                      ]
+		                    },
+		                    {
+		                      "type": "array",
+		                      "items": {
+		                        "enum": [
+		                          "array",
+		                          "boolean",
+		                          "integer",
+		                          "null",
+		                          "number",
+		                          "object",
+		                          "string"
+		                        ]
+		                      },
+		                      "minItems": 1,
+		                      "uniqueItems": true
+		                    }
+		                  ]
+		                }
+		              },
+		              "$defs": {
+		                "nonNegativeInteger": {
+		                  "type": "integer",
+		                  "minimum": 0
+		                },
+		                "nonNegativeIntegerDefault0": {
+		                  "type": "integer",
+		                  "minimum": 0,
+		                  "default": 0
+		                },
+		                "simpleTypes": {
+		                  "enum": [
+		                    "array",
+		                    "boolean",
+		                    "integer",
+		                    "null",
+		                    "number",
+		                    "object",
+		                    "string"
+		                  ]
+		                },
+		                "stringArray": {
+		                  "type": "array",
+		                  "items": {
+		                    "type": "string"
+		                  },
+		                  "uniqueItems": true,
+		                  "default": []
+		                }
+		              }
+		            },
+		            {
+		              "$schema": "https://json-schema.org/draft/2019-09/schema",
+		              "$id": "https://json-schema.org/draft/2019-09/meta/meta-data",
+		              "$vocabulary": {
+		                "https://json-schema.org/draft/2019-09/vocab/meta-data": true
+		              },
+		              "$recursiveAnchor": true,
+		              "title": "Meta-data vocabulary meta-schema",
+		              "type": [
+		                "object",
+		                "boolean"
+		              ],
+		              "properties": {
+		                "title": {
+		                  "type": "string"
+		                },
+		                "description": {
+		                  "type": "string"
+		                },
+		                "default": true,
+		                "deprecated": {
+		                  "type": "boolean",
+		                  "default": false
+		                },
+		                "readOnly": {
+		                  "type": "boolean",
+		                  "default": false
+		                },
+		                "writeOnly": {
+		                  "type": "boolean",
+		                  "default": false
+		                },
+		                "examples": {
+		                  "type": "array",
+		                  "items": true
+		                }
+		              }
+		            },
+		            {
+		              "$schema": "https://json-schema.org/draft/2019-09/schema",
+		              "$id": "https://json-schema.org/draft/2019-09/meta/format",
+		              "$vocabulary": {
+		                "https://json-schema.org/draft/2019-09/vocab/format": true
+		              },
+		              "$recursiveAnchor": true,
+		              "title": "Format vocabulary meta-schema",
+		              "type": [
+		                "object",
+		                "boolean"
+		              ],
+		              "properties": {
+		                "format": {
+		                  "type": "string"
+		                }
+		              }
+		            },
+		            {
+		              "$schema": "https://json-schema.org/draft/2019-09/schema",
+		              "$id": "https://json-schema.org/draft/2019-09/meta/content",
+		              "$vocabulary": {
+		                "https://json-schema.org/draft/2019-09/vocab/content": true
+		              },
+		              "$recursiveAnchor": true,
+		              "title": "Content vocabulary meta-schema",
+		              "type": [
+		                "object",
+		                "boolean"
+		              ],
+		              "properties": {
+		                "contentMediaType": {
+		                  "type": "string"
+		                },
+		                "contentEncoding": {
+		                  "type": "string"
+		                },
+		                "contentSchema": {
+		                  "$recursiveRef": "#"
+		                }
+		              }
+		            }
+		          ],
+		          "type": [
+		            "object",
+		            "boolean"
+		          ],
+		          "properties": {
+		            "definitions": {
+		              "$comment": "While no longer an official keyword as it is replaced by $defs, this keyword is retained in the meta-schema to prevent incompatible extensions as it remains in common use.",
+		              "type": "object",
+		              "additionalProperties": {
+		                "$recursiveRef": "#"
+		              },
+		              "default": {}
+		            },
+		            "dependencies": {
+		              "$comment": "\"dependencies\" is no longer a keyword, but schema authors should avoid redefining it to facilitate a smooth transition to \"dependentSchemas\" and \"dependentRequired\"",
+		              "type": "object",
+		              "additionalProperties": {
+		                "anyOf": [
+		                  {
+		                    "$recursiveRef": "#"
+		                  },
+		                  {
+		                    "type": "array",
+		                    "items": {
+		                      "type": "string"
+		                    },
+		                    "uniqueItems": true,
+		                    "default": []
+		                  }
+		                ]
+		              }
+		            }
+		          }
+		        },
+		        "script": {
+		          "type": "string"
+		        },
+		        "allowOutsideProject": {
+		          "type": "boolean"
+		        },
+		        "commands": {
+		          "description": "Commands which the executor runs using the \"exec\" module. When specified, other commands are rejected. Project's \"executorPolicy\" may further restrict commands available to executors.\n",
+		          "examples": [
+		            [
+		              "docker",
+		              "git"
+		            ]
+		          ],
+		          "type": "array",
+		          "items": {
+		            "type": "string",
+		            "minLength": 1
+		          }
+		        },
+		        "command": {
+		          "description": "External program implementing the executor, used instead of the script. The first item is the program (paths containing a slash are relative to the executor's file), remaining items are its arguments. The program receives input as JSON on stdin and writes results, log messages and errors as JSON lines to stdout. Its stderr is logged.\n",
+		          "examples": [
+		            [
+		              "./deployer.py"
+		            ],
+		            [
+		              "python3",
+		              "./deployer.py",
+		              "--verbose"
+		            ]
+		          ],
+		          "type": "array",
+		          "minItems": 1,
+		          "items": {
+		            "type": "string",
+		            "minLength": 1
+		          }
+		        }
+		      }
+		    }
+		  ]
+		}
+	`),
+	"g2a-cli/v2.0/Project": []byte(`
+		{
+		  "title": "Project",
+		  "description": null,
+		  "type": "object",
+		  "required": [
+		    "apiVersion",
+		    "kind",
+		    "name"
+		  ],
+		  "properties": {
+		    "apiVersion": {
+		      "description": "Version of the configuration format.",
+		      "const": "g2a-cli/v2.0"
+		    },
+		    "kind": {
+		      "description": "Determines type of the document.",
+		      "const": "Project"
+		    },
+		    "name": {
+		      "examples": [
+		        "generic-api"
+		      ],
+		      "description": "Name of the object, unique within the kind.",
+		      "type": "string",
+		      "minLength": 1,
+		      "pattern": "^[a-z][A-Za-z0-9_-]*$"
+		    },
+		    "files": {
+		      "description": "List of the configuration files to load.",
+		      "type": "array",
+		      "items": {
+		        "description": "Paths to files may include wildcards like \"*\" which matches single path segment.\n",
+		        "examples": [
+		          "services/*/service.yaml",
+		          "environments/*/environments.yaml"
+		        ],
+		        "type": "string",
+		        "minLength": 1
+		      }
+		    },
+		    "variables": {
+		      "description": "Definitions of the variables to use in the configuration files. Names are case-insensitive.\n",
+		      "examples": [
+		        {
+		          "name": "value"
+		        }
+		      ],
+		      "type": "object",
+		      "patternProperties": {
+		        "^[a-zA-Z][a-zA-Z0-9]*$": {
+		          "type": "string"
+		        }
+		      }
+		    },
+		    "secrets": {
+		      "description": "Definitions of the secret variables to use in the configuration files. Values are read from environment variables or files (relative to the directory of the document), and they are masked in logs and result files. Names are case-insensitive.\n",
+		      "examples": [
+		        {
+		          "registryPassword": {
+		            "env": "REGISTRY_PASSWORD"
+		          },
+		          "kubeToken": {
+		            "file": "secrets/kube-token"
+		          }
+		        }
+		      ],
+		      "type": "object",
+		      "patternProperties": {
+		        "^[a-zA-Z][a-zA-Z0-9]*$": {
+		          "oneOf": [
+		            {
+		              "type": "object",
+		              "additionalProperties": false,
+		              "required": [
+		                "env"
+		              ],
+		              "properties": {
+		                "env": {
+		                  "description": "Name of the environment variable containing the value.",
+		                  "type": "string",
+		                  "minLength": 1
+		                }
+		              }
+		            },
+		            {
+		              "type": "object",
+		              "additionalProperties": false,
+		              "required": [
+		                "file"
+		              ],
+		              "properties": {
+		                "file": {
+		                  "description": "Path of the file containing the value. Trailing newline is removed from its content.\n",
+		                  "type": "string",
+		                  "minLength": 1
+		                }
+		              }
+		            }
+		          ]
+		        }
+		      },
+		      "additionalProperties": false
+		    },
+		    "timeout": {
+		      "description": "Time limit for the whole command (e.g. build or deploy). May be overridden using \"--timeout\" flag.\n",
+		      "examples": [
+		        "10m"
+		      ],
+		      "type": "string",
+		      "pattern": "^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+		    },
+		    "entryTimeout": {
+		      "description": "Default time limit for entries which don't specify their own \"timeout\". May be overridden using \"--entry-timeout\" flag.\n",
+		      "examples": [
+		        "10m"
+		      ],
+		      "type": "string",
+		      "pattern": "^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+		    },
+		    "executorPolicy": {
+		      "description": "Restricts commands which executors (including ones loaded from other files) can run using the \"exec\" module.\n",
+		      "type": "object",
+		      "additionalProperties": false,
+		      "properties": {
+		        "requireCommands": {
+		          "description": "Executors which don't declare \"commands\" cannot run any commands, unless they are approved.\n",
+		          "type": "boolean"
+		        },
+		        "allowedCommands": {
+		          "description": "Commands which executors can run, unless they are approved. Executors which declare \"commands\" can run only commands present in both lists.\n",
+		          "examples": [
+		            [
+		              "docker",
+		              "git",
+		              "helm"
+		            ]
+		          ],
+		          "type": "array",
+		          "items": {
+		            "type": "string",
+		            "minLength": 1
+		          }
+		        },
+		        "approvedExecutors": {
+		          "description": "Executors which can run all commands they declare (or any commands, if they don't declare them), regardless of other options.\n",
+		          "examples": [
+		            [
+		              {
+		                "kind": "Deployer",
+		                "name": "helm3"
+		              }
+		            ]
+		          ],
+		          "type": "array",
+		          "items": {
+		            "type": "object",
+		            "additionalProperties": false,
+		            "required": [
+		              "kind",
+		              "name"
+		            ],
+		            "properties": {
+		              "kind": {
+		                "enum": [
+		                  "Builder",
+		                  "Deployer",
+		                  "Pusher",
+		                  "Runner",
+		                  "Tagger"
+		                ]
+		              },
+		              "name": {
+		                "description": "Name of the object, unique within the kind.",
+		                "type": "string",
+		                "minLength": 1,
+		                "pattern": "^[a-z][A-Za-z0-9_-]*$"
+		              }
+		            }
+		          }
+		        }
+		      }
+		    },
+		    "tasks": {
+		      "description": "Definitions of the tasks used by commands \"prepare\", \"test\", \"lint\" and \"run\". These tasks may be also specified in services definitions.",
+		      "type": "object",
+		      "properties": {
+		        "prepare": {
+		          "description": "Defines steps required to preapre freshly clonned repository for development, tests or build. This definition is used by \"prepare\" command.\n",
+		          "examples": [
+		            [
+		              {
+		                "make": {
+		                  "target": "prepare"
+		                }
+		              }
+		            ]
+		          ],
+		          "type": "array",
+		          "items": {
+		            "oneOf": [
+		              {
+		                "type": "object",
+		                "properties": {
+		                  "timeout": {
+		                    "description": "Time limit for the entry. Executor is interrupted when it's exceeded and the entry fails.\n",
+		                    "examples": [
+		                      "10m"
+		                    ],
+		                    "type": "string",
+		                    "pattern": "^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+		                  }
+		                },
+		                "if": {
+		                  "required": [
+		                    "timeout"
+		                  ]
+		                },
+		                "then": {
+		                  "minProperties": 2,
+		                  "maxProperties": 2
+		                },
+		                "else": {
+		                  "minProperties": 1,
+		                  "maxProperties": 1
+		                },
+		                "additionalProperties": true
+		              },
+		              {
+		                "type": "string"
+		              }
+		            ]
+		          }
+		        },
+		        "test": {
+		          "description": "Defines how to run tests. This definition is used by \"test\" command.\n",
+		          "examples": [
+		            [
+		              {
+		                "script": {
+		                  "sh": "go test ./..."
+		                }
+		              }
+		            ]
+		          ],
+		          "type": "array",
+		          "items": {
+		            "oneOf": [
+		              {
+		                "type": "object",
+		                "properties": {
+		                  "timeout": {
+		                    "description": "Time limit for the entry. Executor is interrupted when it's exceeded and the entry fails.\n",
+		                    "examples": [
+		                      "10m"
+		                    ],
+		                    "type": "string",
+		                    "pattern": "^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+		                  }
+		                },
+		                "if": {
+		                  "required": [
+		                    "timeout"
+		                  ]
+		                },
+		                "then": {
+		                  "minProperties": 2,
+		                  "maxProperties": 2
+		                },
+		                "else": {
+		                  "minProperties": 1,
+		                  "maxProperties": 1
+		                },
+		                "additionalProperties": true
+		              },
+		              {
+		                "type": "string"
+		              }
+		            ]
+		          }
+		        },
+		        "lint": {
+		          "description": "Defines how to lint the code. Ideally should try to fix the issues. This definition is used by \"lint\" command.\n",
+		          "examples": [
+		            [
+		              "prettier"
+		            ]
+		          ],
+		          "type": "array",
+		          "items": {
+		            "oneOf": [
+		              {
+		                "type": "object",
+		                "properties": {
+		                  "timeout": {
+		                    "description": "Time limit for the entry. Executor is interrupted when it's exceeded and the entry fails.\n",
+		                    "examples": [
+		                      "10m"
+		                    ],
+		                    "type": "string",
+		                    "pattern": "^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+		                  }
+		                },
+		                "if": {
+		                  "required": [
+		                    "timeout"
+		                  ]
+		                },
+		                "then": {
+		                  "minProperties": 2,
+		                  "maxProperties": 2
+		                },
+		                "else": {
+		                  "minProperties": 1,
+		                  "maxProperties": 1
+		                },
+		                "additionalProperties": true
+		              },
+		              {
+		                "type": "string"
+		              }
+		            ]
+		          }
+		        }
+		      },
+		      "additionalProperties": {
+		        "description": "You are not bound to use pre-defined tasks. All tasks (including custom ones) may be run using \"run\" command.\n",
+		        "examples": [
+		          [
+		            {
+		              "runnerName": {
+		                "some": "params"
+		              }
+		            }
+		          ]
+		        ],
+		        "tsType": "({ [k: string]: unknown; } | string )[] | undefined",
+		        "type": "array",
+		        "items": {
+		          "oneOf": [
+		            {
+		              "type": "object",
+		              "properties": {
+		                "timeout": {
+		                  "description": "Time limit for the entry. Executor is interrupted when it's exceeded and the entry fails.\n",
+		                  "examples": [
+		                    "10m"
+		                  ],
+		                  "type": "string",
+		                  "pattern": "^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+		                }
+		              },
+		              "if": {
+		                "required": [
+		                  "timeout"
+		                ]
+		              },
+		              "then": {
+		                "minProperties": 2,
+		                "maxProperties": 2
+		              },
+		              "else": {
+		                "minProperties": 1,
+		                "maxProperties": 1
+		              },
+		              "additionalProperties": true
+		            },
+		            {
+		              "type": "string"
+		            }
+		          ]
+		        }
+		      },
+		      "$defs": {
+		        "task": {
+		          "type": "array",
+		          "items": {
+		            "oneOf": [
+		              {
+		                "type": "object",
+		                "properties": {
+		                  "timeout": {
+		                    "description": "Time limit for the entry. Executor is interrupted when it's exceeded and the entry fails.\n",
+		                    "examples": [
+		                      "10m"
+		                    ],
+		                    "type": "string",
+		                    "pattern": "^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+		                  }
+		                },
+		                "if": {
+		                  "required": [
+		                    "timeout"
+		                  ]
+		                },
+		                "then": {
+		                  "minProperties": 2,
+		                  "maxProperties": 2
+		                },
+		                "else": {
+		                  "minProperties": 1,
+		                  "maxProperties": 1
+		                },
+		                "additionalProperties": true
+		              },
+		              {
+		                "type": "string"
+		              }
+		            ]
+		          }
+		        }
+		      }
+		    }
+		  }
+		}
+	`),
+	"g2a-cli/v2.0/Pusher": []byte(`
+		{
+		  "title": "Pusher",
+		  "type": "object",
+		  "required": [
+		    "apiVersion",
+		    "kind",
+		    "name"
+		  ],
+		  "if": {
+		    "required": [
+		      "command"
+		    ]
+		  },
+		  "then": {
+		    "properties": {
+		      "script": false
+		    }
+		  },
+		  "else": {
+		    "required": [
+		      "script"
+		    ]
+		  },
+		  "additionalProperties": false,
+		  "properties": {
+		    "apiVersion": {
+		      "description": "Version of the configuration format.",
+		      "const": "g2a-cli/v2.0"
+		    },
+		    "kind": {
+		      "const": "Pusher"
+		    },
+		    "name": {
+		      "description": "Name of the object, unique within the kind.",
+		      "type": "string",
+		      "minLength": 1,
+		      "pattern": "^[a-z][A-Za-z0-9_-]*$"
+		    },
+		    "schema": {
+		      "$schema": "https://json-schema.org/draft/2019-09/schema",
+		      "$id": "https://json-schema.org/draft/2019-09/schema",
+		      "$vocabulary": {
+		        "https://json-schema.org/draft/2019-09/vocab/core": true,
+		        "https://json-schema.org/draft/2019-09/vocab/applicator": true,
+		        "https://json-schema.org/draft/2019-09/vocab/validation": true,
+		        "https://json-schema.org/draft/2019-09/vocab/meta-data": true,
+		        "https://json-schema.org/draft/2019-09/vocab/format": false,
+		        "https://json-schema.org/draft/2019-09/vocab/content": true
+		      },
+		      "$recursiveAnchor": true,
+		      "title": "Core and Validation specifications meta-schema",
+		      "allOf": [
+		        {
+		          "$schema": "https://json-schema.org/draft/2019-09/schema",
+		          "$id": "https://json-schema.org/draft/2019-09/meta/core",
+		          "$vocabulary": {
+		            "https://json-schema.org/draft/2019-09/vocab/core": true
+		          },
+		          "$recursiveAnchor": true,
+		          "title": "Core vocabulary meta-schema",
+		          "type": [
+		            "object",
+		            "boolean"
+		          ],
+		          "properties": {
+		            "$id": {
+		              "type": "string",
+		              "format": "uri-reference",
+		              "$comment": "Non-empty fragments not allowed.",
+		              "pattern": "^[^#]*#?$"
+		            },
+		            "$schema": {
+		              "type": "string",
+		              "format": "uri"
+		            },
+		            "$anchor": {
+		              "type": "string",
+		              "pattern": "^[A-Za-z][-A-Za-z0-9.:_]*$"
+		            },
+		            "$ref": {
+		              "type": "string",
+		              "format": "uri-reference"
+		            },
+		            "$recursiveRef": {
+		              "type": "string",
+		              "format": "uri-reference"
+		            },
+		            "$recursiveAnchor": {
+		              "type": "boolean",
+		              "default": false
+		            },
+		            "$vocabulary": {
+		              "type": "object",
+		              "propertyNames": {
+		                "type": "string",
+		                "format": "uri"
+		              },
+		              "additionalProperties": {
+		                "type": "boolean"
+		              }
+		            },
+		            "$comment": {
+		              "type": "string"
+		            },
+		            "$defs": {
+		              "type": "object",
+		              "additionalProperties": {
+		                "$recursiveRef": "#"
+		              },
+		              "default": {}
+		            }
+		          }
+		        },
+		        {
+		          "$schema": "https://json-schema.org/draft/2019-09/schema",
+		          "$id": "https://json-schema.org/draft/2019-09/meta/applicator",
+		          "$vocabulary": {
+		            "https://json-schema.org/draft/2019-09/vocab/applicator": true
+		          },
+		          "$recursiveAnchor": true,
+		          "title": "Applicator vocabulary meta-schema",
+		          "type": [
+		            "object",
+		            "boolean"
+		          ],
+		          "properties": {
+		            "additionalItems": {
+		              "$recursiveRef": "#"
+		            },
+		            "unevaluatedItems": {
+		              "$recursiveRef": "#"
+		            },
+		            "items": {
+		              "anyOf": [
+		                {
+		                  "$recursiveRef": "#"
+		                },
+		                {
+		                  "type": "array",
+		                  "minItems": 1,
+		                  "items": {
+		                    "$recursiveRef": "#"
+		                  }
+		                }
+		              ]
+		            },
+		            "contains": {
+		              "$recursiveRef": "#"
+		            },
+		            "additionalProperties": {
+		              "$recursiveRef": "#"
+		            },
+		            "unevaluatedProperties": {
+		              "$recursiveRef": "#"
+		            },
+		            "properties": {
+		              "type": "object",
+		              "additionalProperties": {
+		                "$recursiveRef": "#"
+		              },
+		              "default": {}
+		            },
+		            "patternProperties": {
+		              "type": "object",
+		              "additionalProperties": {
+		                "$recursiveRef": "#"
+		              },
+		              "propertyNames": {
+		                "format": "regex"
+		              },
+		              "default": {}
+		            },
+		            "dependentSchemas": {
+		              "type": "object",
+		              "additionalProperties": {
+		                "$recursiveRef": "#"
+		              }
+		            },
+		            "propertyNames": {
+		              "$recursiveRef": "#"
+		            },
+		            "if": {
+		              "$recursiveRef": "#"
+		            },
+		            "then": {
+		              "$recursiveRef": "#"
+		            },
+		            "else": {
+		              "$recursiveRef": "#"
+		            },
+		            "allOf": {
+		              "type": "array",
+		              "minItems": 1,
+		              "items": {
+		                "$recursiveRef": "#"
+		              }
+		            },
+		            "anyOf": {
+		              "type": "array",
+		              "minItems": 1,
+		              "items": {
+		                "$recursiveRef": "#"
+		              }
+		            },
+		            "oneOf": {
+		              "type": "array",
+		              "minItems": 1,
+		              "items": {
+		                "$recursiveRef": "#"
+		              }
+		            },
+		            "not": {
+		              "$recursiveRef": "#"
+		            }
+		          },
+		          "$defs": {
+		            "schemaArray": {
+		              "type": "array",
+		              "minItems": 1,
+		              "items": {
+		                "$recursiveRef": "#"
+		              }
+		            }
+		          }
+		        },
+		        {
+		          "$schema": "https://json-schema.org/draft/2019-09/schema",
+		          "$id": "https://json-schema.org/draft/2019-09/meta/validation",
+		          "$vocabulary": {
+		            "https://json-schema.org/draft/2019-09/vocab/validation": true
+		          },
+		          "$recursiveAnchor": true,
+		          "title": "Validation vocabulary meta-schema",
+		          "type": [
+		            "object",
+		            "boolean"
+		          ],
+		          "properties": {
+		            "multipleOf": {
+		              "type": "number",
+		              "exclusiveMinimum": 0
+		            },
+		            "maximum": {
+		              "type": "number"
+		            },
+		            "exclusiveMaximum": {
+		              "type": "number"
+		            },
+		            "minimum": {
+		              "type": "number"
+		            },
+		            "exclusiveMinimum": {
+		              "type": "number"
+		            },
+		            "maxLength": {
+		              "type": "integer",
+		              "minimum": 0
+		            },
+		            "minLength": {
+		              "default": 0,
+		              "type": "integer",
+		              "minimum": 0
+		            },
+		            "pattern": {
+		              "type": "string",
+		              "format": "regex"
+		            },
+		            "maxItems": {
+		              "type": "integer",
+		              "minimum": 0
+		            },
+		            "minItems": {
+		              "default": 0,
+		              "type": "integer",
+		              "minimum": 0
+		            },
+		            "uniqueItems": {
+		              "type": "boolean",
+		              "default": false
+		            },
+		            "maxContains": {
+		              "type": "integer",
+		              "minimum": 0
+		            },
+		            "minContains": {
+		              "type": "integer",
+		              "minimum": 0,
+		              "default": 1
+		            },
+		            "maxProperties": {
+		              "type": "integer",
+		              "minimum": 0
+		            },
+		            "minProperties": {
+		              "default": 0,
+		              "type": "integer",
+		              "minimum": 0
+		            },
+		            "required": {
+		              "type": "array",
+		              "items": {
+		                "type": "string"
 		              },
-		              "$defs": {
-		                "nonNegativeInteger": {
-		                  "type": "integer",
-		                  "minimum": 0
-		                },
-		                "nonNegativeIntegerDefault0": {
-		                  "type": "integer",
-		                  "minimum": 0,
-		                  "default": 0
+		              "uniqueItems": true,
+		              "default": []
+		            },
+		            "dependentRequired": {
+		              "type": "object",
+		              "additionalProperties": {
+		                "type": "array",
+		                "items": {
+		                  "type": "string"
 		                },
-		                "simpleTypes": {
+		                "uniqueItems": true,
+		                "default": []
+		              }
+		            },
+		            "const": true,
+		            "enum": {
+		              "type": "array",
+		              "items": true
+		            },
+		            "type": {
+		              "anyOf": [
+		                {
 		                  "enum": [
 		                    "array",
 		                    "boolean",
@@ -4465,315 +7177,352 @@ var SCHEMAS = map[string][]byte{
 		                    "string"
 		                  ]
 		                },
-		                "stringArray": {
+		                {
 		                  "type": "array",
 		                  "items": {
-		                    "type": "string"
+		                    "enum": [
+		                      "array",
+		                      "boolean",
+		                      "integer",
+		                      "null",
+		                      "number",
+		                      "object",
+		                      "string"
+		                    ]
 		                  },
-		                  "uniqueItems": true,
-		                  "default": []
+		                  "minItems": 1,
+		                  "uniqueItems": true
 		                }
-		              }
+		              ]
+		            }
+		          },
+		          "$defs": {
+		            "nonNegativeInteger": {
+		              "type": "integer",
+		              "minimum": 0
 		            },
-		            {
-		              "$schema": "https://json-schema.org/draft/2019-09/schema",
-		              "$id": "https://json-schema.org/draft/2019-09/meta/meta-data",
-		              "$vocabulary": {
-		                "https://json-schema.org/draft/2019-09/vocab/meta-data": true
-		              },
-		              "$recursiveAnchor": true,
-		              "title": "Meta-data vocabulary meta-schema",
-		              "type": [
-		                "object",
-		                "boolean"
-		              ],
-		              "properties": {
-		                "title": {
-		                  "type": "string"
-		                },
-		                "description": {
-		                  "type": "string"
-		                },
-		                "default": true,
-		                "deprecated": {
-		                  "type": "boolean",
-		                  "default": false
-		                },
-		                "readOnly": {
-		                  "type": "boolean",
-		                  "default": false
-		                },
-		                "writeOnly": {
-		                  "type": "boolean",
-		                  "default": false
-		                },
-		                "examples": {
-		                  "type": "array",
-		                  "items": true
-		                }
-		              }
+		            "nonNegativeIntegerDefault0": {
+		              "type": "integer",
+		              "minimum": 0,
+		              "default": 0
 		            },
-		            {
-		              "$schema": "https://json-schema.org/draft/2019-09/schema",
-		              "$id": "https://json-schema.org/draft/2019-09/meta/format",
-		              "$vocabulary": {
-		                "https://json-schema.org/draft/2019-09/vocab/format": true
-		              },
-		              "$recursiveAnchor": true,
-		              "title": "Format vocabulary meta-schema",
-		              "type": [
+		            "simpleTypes": {
+		              "enum": [
+		                "array",
+		                "boolean",
+		                "integer",
+		                "null",
+		                "number",
 		                "object",
-		                "boolean"
-		              ],
-		              "properties": {
-		                "format": {
-		                  "type": "string"
-		                }
-		              }
+		                "string"
+		              ]
 		            },
-		            {
-		              "$schema": "https://json-schema.org/draft/2019-09/schema",
-		              "$id": "https://json-schema.org/draft/2019-09/meta/content",
-		              "$vocabulary": {
-		                "https://json-schema.org/draft/2019-09/vocab/content": true
+		            "stringArray": {
+		              "type": "array",
+		              "items": {
+		                "type": "string"
 		              },
-		              "$recursiveAnchor": true,
-		              "title": "Content vocabulary meta-schema",
-		              "type": [
-		                "object",
-		                "boolean"
-		              ],
-		              "properties": {
-		                "contentMediaType": {
-		                  "type": "string"
-		                },
-		                "contentEncoding": {
-		                  "type": "string"
-		                },
-		                "contentSchema": {
-		                  "$recursiveRef": "#"
-		                }
-		              }
+		              "uniqueItems": true,
+		              "default": []
+		            }
+		          }
+		        },
+		        {
+		          "$schema": "https://json-schema.org/draft/2019-09/schema",
+		          "$id": "https://json-schema.org/draft/2019-09/meta/meta-data",
+		          "$vocabulary": {
+		            "https://json-schema.org/draft/2019-09/vocab/meta-data": true
+		          },
+		          "$recursiveAnchor": true,
+		          "title": "Meta-data vocabulary meta-schema",
+		          "type": [
+		            "object",
+		            "boolean"
+		          ],
+		          "properties": {
+		            "title": {
+		              "type": "string"
+		            },
+		            "description": {
+		              "type": "string"
+		            },
+		            "default": true,
+		            "deprecated": {
+		              "type": "boolean",
+		              "default": false
+		            },
+		            "readOnly": {
+		              "type": "boolean",
+		              "default": false
+		            },
+		            "writeOnly": {
+		              "type": "boolean",
+		              "default": false
+		            },
+		            "examples": {
+		              "type": "array",
+		              "items": true
 		            }
+		          }
+		        },
+		        {
+		          "$schema": "https://json-schema.org/draft/2019-09/schema",
+		          "$id": "https://json-schema.org/draft/2019-09/meta/format",
+		          "$vocabulary": {
+		            "https://json-schema.org/draft/2019-09/vocab/format": true
+		          },
+		          "$recursiveAnchor": true,
+		          "title": "Format vocabulary meta-schema",
+		          "type": [
+		            "object",
+		            "boolean"
 		          ],
+		          "properties": {
+		            "format": {
+		              "type": "string"
+		            }
+		          }
+		        },
+		        {
+		          "$schema": "https://json-schema.org/draft/2019-09/schema",
+		          "$id": "https://json-schema.org/draft/2019-09/meta/content",
+		          "$vocabulary": {
+		            "https://json-schema.org/draft/2019-09/vocab/content": true
+		          },
+		          "$recursiveAnchor": true,
+		          "title": "Content vocabulary meta-schema",
 		          "type": [
 		            "object",
 		            "boolean"
 		          ],
 		          "properties": {
-		            "definitions": {
-		              "$comment": "While no longer an official keyword as it is replaced by $defs, this keyword is retained in the meta-schema to prevent incompatible extensions as it remains in common use.",
-		              "type": "object",
-		              "additionalProperties": {
+		            "contentMediaType": {
+		              "type": "string"
+		            },
+		            "contentEncoding": {
+		              "type": "string"
+		            },
+		            "contentSchema": {
+		              "$recursiveRef": "#"
+		            }
+		          }
+		        }
+		      ],
+		      "type": [
+		        "object",
+		        "boolean"
+		      ],
+		      "properties": {
+		        "definitions": {
+		          "$comment": "While no longer an official keyword as it is replaced by $defs, this keyword is retained in the meta-schema to prevent incompatible extensions as it remains in common use.",
+		          "type": "object",
+		          "additionalProperties": {
+		            "$recursiveRef": "#"
+		          },
+		          "default": {}
+		        },
+		        "dependencies": {
+		          "$comment": "\"dependencies\" is no longer a keyword, but schema authors should avoid redefining it to facilitate a smooth transition to \"dependentSchemas\" and \"dependentRequired\"",
+		          "type": "object",
+		          "additionalProperties": {
+		            "anyOf": [
+		              {
 		                "$recursiveRef": "#"
 		              },
-		              "default": {}
-		            },
-		            "dependencies": {
-		              "$comment": "\"dependencies\" is no longer a keyword, but schema authors should avoid redefining it to facilitate a smooth transition to \"dependentSchemas\" and \"dependentRequired\"",
-		              "type": "object",
-		              "additionalProperties": {
-		                "anyOf": [
-		                  {
-		                    "$recursiveRef": "#"
-		                  },
-		                  {
-		                    "type": "array",
-		                    "items": {
-		                      "type": "string"
-		                    },
-		                    "uniqueItems": true,
-		                    "default": []
-		                  }
-		                ]
+		              {
+		                "type": "array",
+		                "items": {
+		                  "type": "string"
+		                },
+		                "uniqueItems": true,
+		                "default": []
 		              }
-		            }
+		            ]
 		          }
-		        },
-		        "script": {
-		          "type": "string"
 		        }
 		      }
-		    }
-		  ]
-		}
-	`),
-	"g2a-cli/v2.0/Project": []byte(`
-		{
-		  "title": "Project",
-		  "description": null,
-		  "type": "object",
-		  "required": [
-		    "apiVersion",
-		    "kind",
-		    "name"
-		  ],
-		  "properties": {
-		    "apiVersion": {
-		      "description": "Version of the configuration format.",
-		      "const": "g2a-cli/v2.0"
 		    },
-		    "kind": {
-		      "description": "Determines type of the document.",
-		      "const": "Project"
+		    "script": {
+		      "type": "string"
 		    },
-		    "name": {
+		    "allowOutsideProject": {
+		      "type": "boolean"
+		    },
+		    "commands": {
+		      "description": "Commands which the executor runs using the \"exec\" module. When specified, other commands are rejected. Project's \"executorPolicy\" may further restrict commands available to executors.\n",
 		      "examples": [
-		        "generic-api"
+		        [
+		          "docker",
+		          "git"
+		        ]
 		      ],
-		      "description": "Name of the object, unique within the kind.",
-		      "type": "string",
-		      "minLength": 1,
-		      "pattern": "^[a-z][A-Za-z0-9_-]*$"
-		    },
-		    "files": {
-		      "description": "List of the configuration files to load.",
 		      "type": "array",
 		      "items": {
-		        "description": "Paths to files may include wildcards like \"*\" which matches single path segment.\n",
-		        "examples": [
-		          "services/*/service.yaml",
-		          "environments/*/environments.yaml"
-		        ],
 		        "type": "string",
 		        "minLength": 1
 		      }
 		    },
-		    "variables": {
-		      "description": "Definitions of the variables to use in the configuration files. Names are case-insensitive.\n",
+		    "command": {
+		      "description": "External program implementing the executor, used instead of the script. The first item is the program (paths containing a slash are relative to the executor's file), remaining items are its arguments. The program receives input as JSON on stdin and writes results, log messages and errors as JSON lines to stdout. Its stderr is logged.\n",
 		      "examples": [
-		        {
-		          "name": "value"
-		        }
+		        [
+		          "./deployer.py"
+		        ],
+		        [
+		          "python3",
+		          "./deployer.py",
+		          "--verbose"
+		        ]
 		      ],
-		      "type": "object",
-		      "patternProperties": {
-		        "^[a-zA-Z][a-zA-Z0-9]*$": {
-		          "type": "string"
-		        }
+		      "type": "array",
+		      "minItems": 1,
+		      "items": {
+		        "type": "string",
+		        "minLength": 1
 		      }
+		    }
+		  }
+		}
+	`),
+	"g2a-cli/v2.0/Run-result": []byte(`
+		{
+		  "type": "object",
+		  "additionalProperties": true,
+		  "required": [
+		    "status",
+		    "task",
+		    "results"
+		  ],
+		  "properties": {
+		    "status": {
+		      "description": "Outcome of the command or an entry.",
+		      "enum": [
+		        "succeeded",
+		        "failed",
+		        "skipped",
+		        "timedOut"
+		      ]
 		    },
-		    "tasks": {
-		      "description": "Definitions of the tasks used by commands \"prepare\", \"test\", \"lint\" and \"run\". These tasks may be also specified in services definitions.",
-		      "type": "object",
-		      "properties": {
-		        "prepare": {
-		          "description": "Defines steps required to preapre freshly clonned repository for development, tests or build. This definition is used by \"prepare\" command.\n",
-		          "examples": [
-		            [
-		              {
-		                "make": {
-		                  "target": "prepare"
-		                }
-		              }
-		            ]
-		          ],
-		          "type": "array",
-		          "items": {
-		            "oneOf": [
-		              {
-		                "type": "object",
-		                "minProperties": 1,
-		                "maxProperties": 1,
-		                "additionalProperties": true
-		              },
-		              {
-		                "type": "string"
-		              }
-		            ]
-		          }
-		        },
-		        "test": {
-		          "description": "Defines how to run tests. This definition is used by \"test\" command.\n",
-		          "examples": [
-		            [
-		              {
-		                "script": {
-		                  "sh": "go test ./..."
-		                }
-		              }
-		            ]
-		          ],
-		          "type": "array",
-		          "items": {
-		            "oneOf": [
-		              {
-		                "type": "object",
-		                "minProperties": 1,
-		                "maxProperties": 1,
-		                "additionalProperties": true
-		              },
-		              {
-		                "type": "string"
-		              }
-		            ]
-		          }
-		        },
-		        "lint": {
-		          "description": "Defines how to lint the code. Ideally should try to fix the issues. This definition is used by \"lint\" command.\n",
-		          "examples": [
-		            [
-		              "prettier"
-		            ]
-		          ],
-		          "type": "array",
-		          "items": {
-		            "oneOf": [
-		              {
-		                "type": "object",
-		                "minProperties": 1,
-		                "maxProperties": 1,
-		                "additionalProperties": true
-		              },
-		              {
-		                "type": "string"
-		              }
-		            ]
-		          }
-		        }
-		      },
-		      "additionalProperties": {
-		        "description": "You are not bound to use pre-defined tasks. All tasks (including custom ones) may be run using \"run\" command.\n",
+		    "error": {
+		      "description": "Error which caused the command to fail, present only if status is \"failed\".",
+		      "type": "string"
+		    },
+		    "entries": {
+		      "description": "Outcome of each processed entry. Entries which were not executed (e.g. because previous entry of the same service failed) have \"skipped\" status, entries interrupted because of exceeded time limit have \"timedOut\" status. Entries of tasks defined in the project don't have \"service\".\n",
+		      "type": "array",
+		      "items": {
 		        "examples": [
-		          [
-		            {
-		              "runnerName": {
-		                "some": "params"
-		              }
-		            }
-		          ]
+		          {
+		            "service": "generic-service",
+		            "type": "build",
+		            "entry": 0,
+		            "executorKind": "Builder",
+		            "executorName": "docker",
+		            "status": "succeeded",
+		            "startTime": "2022-03-01T12:00:00Z",
+		            "endTime": "2022-03-01T12:00:05.5Z",
+		            "duration": 5.5
+		          }
 		        ],
-		        "tsType": "({ [k: string]: unknown; } | string )[] | undefined",
-		        "type": "array",
-		        "items": {
-		          "oneOf": [
-		            {
-		              "type": "object",
-		              "minProperties": 1,
-		              "maxProperties": 1,
-		              "additionalProperties": true
-		            },
-		            {
-		              "type": "string"
-		            }
-		          ]
-		        }
-		      },
-		      "$defs": {
-		        "task": {
-		          "type": "array",
-		          "items": {
-		            "oneOf": [
-		              {
-		                "type": "object",
-		                "minProperties": 1,
-		                "maxProperties": 1,
-		                "additionalProperties": true
-		              },
-		              {
-		                "type": "string"
-		              }
+		        "type": "object",
+		        "additionalProperties": true,
+		        "required": [
+		          "type",
+		          "entry",
+		          "executorKind",
+		          "executorName",
+		          "status"
+		        ],
+		        "properties": {
+		          "service": {
+		            "description": "Name of the object, unique within the kind.",
+		            "type": "string",
+		            "minLength": 1,
+		            "pattern": "^[a-z][A-Za-z0-9_-]*$"
+		          },
+		          "type": {
+		            "description": "Type of the entry (e.g. \"tag\", \"build\", \"push\" or \"deploy\").",
+		            "type": "string"
+		          },
+		          "entry": {
+		            "type": "integer",
+		            "min": 0
+		          },
+		          "executorKind": {
+		            "type": "string"
+		          },
+		          "executorName": {
+		            "type": "string"
+		          },
+		          "status": {
+		            "description": "Outcome of the command or an entry.",
+		            "enum": [
+		              "succeeded",
+		              "failed",
+		              "skipped",
+		              "timedOut"
 		            ]
+		          },
+		          "error": {
+		            "description": "Error message, present only if entry failed.",
+		            "type": "string"
+		          },
+		          "startTime": {
+		            "type": "string",
+		            "format": "date-time"
+		          },
+		          "endTime": {
+		            "type": "string",
+		            "format": "date-time"
+		          },
+		          "duration": {
+		            "description": "Duration of the entry in seconds.",
+		            "type": "number"
+		          }
+		        }
+		      }
+		    },
+		    "task": {
+		      "type": "string"
+		    },
+		    "results": {
+		      "type": "array",
+		      "items": {
+		        "examples": [
+		          {
+		            "service": "generic-service",
+		            "entry": 0,
+		            "result": "some result"
+		          },
+		          {
+		            "service": "generic-service",
+		            "entry": 0,
+		            "result": "registry/app:1.0.0",
+		            "metadata": {
+		              "digest": "sha256:4f5e..."
+		            }
+		          }
+		        ],
+		        "type": "object",
+		        "additionalProperties": true,
+		        "properties": {
+		          "service": {
+		            "description": "Name of the object, unique within the kind.",
+		            "type": "string",
+		            "minLength": 1,
+		            "pattern": "^[a-z][A-Za-z0-9_-]*$"
+		          },
+		          "entry": {
+		            "type": "integer",
+		            "min": 0
+		          },
+		          "result": {
+		            "type": "string"
+		          },
+		          "metadata": {
+		            "description": "Metadata added by the executor together with the result (e.g. digest of a built image). Present only for artifacts reported as maps with the \"id\" field, which is stored as the result.\n",
+		            "type": "object",
+		            "additionalProperties": true
 		          }
 		        }
 		      }
@@ -4781,16 +7530,30 @@ var SCHEMAS = map[string][]byte{
 		  }
 		}
 	`),
-	"g2a-cli/v2.0/Pusher": []byte(`
+	"g2a-cli/v2.0/Runner": []byte(`
 		{
-		  "title": "Pusher",
+		  "title": "Runner",
 		  "type": "object",
 		  "required": [
 		    "apiVersion",
 		    "kind",
-		    "name",
-		    "script"
+		    "name"
 		  ],
+		  "if": {
+		    "required": [
+		      "command"
+		    ]
+		  },
+		  "then": {
+		    "properties": {
+		      "script": false
+		    }
+		  },
+		  "else": {
+		    "required": [
+		      "script"
+		    ]
+		  },
 		  "additionalProperties": false,
 		  "properties": {
 		    "apiVersion": {
@@ -4798,7 +7561,7 @@ var SCHEMAS = map[string][]byte{
 		      "const": "g2a-cli/v2.0"
 		    },
 		    "kind": {
-		      "const": "Pusher"
+		      "const": "Runner"
 		    },
 		    "name": {
 		      "description": "Name of the object, unique within the kind.",
@@ -5270,6 +8033,42 @@ var SCHEMAS = map[string][]byte{
 		    },
 		    "script": {
 		      "type": "string"
+		    },
+		    "allowOutsideProject": {
+		      "type": "boolean"
+		    },
+		    "commands": {
+		      "description": "Commands which the executor runs using the \"exec\" module. When specified, other commands are rejected. Project's \"executorPolicy\" may further restrict commands available to executors.\n",
+		      "examples": [
+		        [
+		          "docker",
+		          "git"
+		        ]
+		      ],
+		      "type": "array",
+		      "items": {
+		        "type": "string",
+		        "minLength": 1
+		      }
+		    },
+		    "command": {
+		      "description": "External program implementing the executor, used instead of the script. The first item is the program (paths containing a slash are relative to the executor's file), remaining items are its arguments. The program receives input as JSON on stdin and writes results, log messages and errors as JSON lines to stdout. Its stderr is logged.\n",
+		      "examples": [
+		        [
+		          "./deployer.py"
+		        ],
+		        [
+		          "python3",
+		          "./deployer.py",
+		          "--verbose"
+		        ]
+		      ],
+		      "type": "array",
+		      "minItems": 1,
+		      "items": {
+		        "type": "string",
+		        "minLength": 1
+		      }
 		    }
 		  }
 		}
@@ -5301,6 +8100,21 @@ var SCHEMAS = map[string][]byte{
 		      "minLength": 1,
 		      "pattern": "^[a-z][A-Za-z0-9_-]*$"
 		    },
+		    "dependsOn": {
+		      "description": "List of services which have to be handled before this one. Build and deploy commands process services in the order determined by these dependencies.\n",
+		      "examples": [
+		        [
+		          "database-migration"
+		        ]
+		      ],
+		      "type": "array",
+		      "items": {
+		        "description": "Name of the object, unique within the kind.",
+		        "type": "string",
+		        "minLength": 1,
+		        "pattern": "^[a-z][A-Za-z0-9_-]*$"
+		      }
+		    },
 		    "artifacts": {
 		      "description": "List of artifacts to produce by build command. Each entry describes single artifact like docker image or npm package.\n",
 		      "type": "array",
@@ -5341,8 +8155,29 @@ var SCHEMAS = map[string][]byte{
 		            "oneOf": [
 		              {
 		                "type": "object",
-		                "minProperties": 1,
-		                "maxProperties": 1,
+		                "properties": {
+		                  "timeout": {
+		                    "description": "Time limit for the entry. Executor is interrupted when it's exceeded and the entry fails.\n",
+		                    "examples": [
+		                      "10m"
+		                    ],
+		                    "type": "string",
+		                    "pattern": "^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+		                  }
+		                },
+		                "if": {
+		                  "required": [
+		                    "timeout"
+		                  ]
+		                },
+		                "then": {
+		                  "minProperties": 2,
+		                  "maxProperties": 2
+		                },
+		                "else": {
+		                  "minProperties": 1,
+		                  "maxProperties": 1
+		                },
 		                "additionalProperties": true
 		              },
 		              {
@@ -5352,8 +8187,6 @@ var SCHEMAS = map[string][]byte{
 		          },
 		          {
 		            "type": "object",
-		            "minProperties": 2,
-		            "maxProperties": 2,
 		            "required": [
 		              "push"
 		            ],
@@ -5365,8 +8198,29 @@ var SCHEMAS = map[string][]byte{
 		                    "oneOf": [
 		                      {
 		                        "type": "object",
-		                        "minProperties": 1,
-		                        "maxProperties": 1,
+		                        "properties": {
+		                          "timeout": {
+		                            "description": "Time limit for the entry. Executor is interrupted when it's exceeded and the entry fails.\n",
+		                            "examples": [
+		                              "10m"
+		                            ],
+		                            "type": "string",
+		                            "pattern": "^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+		                          }
+		                        },
+		                        "if": {
+		                          "required": [
+		                            "timeout"
+		                          ]
+		                        },
+		                        "then": {
+		                          "minProperties": 2,
+		                          "maxProperties": 2
+		                        },
+		                        "else": {
+		                          "minProperties": 1,
+		                          "maxProperties": 1
+		                        },
 		                        "additionalProperties": true
 		                      },
 		                      {
@@ -5378,8 +8232,29 @@ var SCHEMAS = map[string][]byte{
 		                    "const": false
 		                  }
 		                ]
+		              },
+		              "timeout": {
+		                "description": "Time limit for building the artifact. Unless \"push\" defines its own timeout, it's used for pushing as well.\n",
+		                "examples": [
+		                  "10m"
+		                ],
+		                "type": "string",
+		                "pattern": "^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
 		              }
 		            },
+		            "if": {
+		              "required": [
+		                "timeout"
+		              ]
+		            },
+		            "then": {
+		              "minProperties": 3,
+		              "maxProperties": 3
+		            },
+		            "else": {
+		              "minProperties": 2,
+		              "maxProperties": 2
+		            },
 		            "additionalProperties": true
 		          }
 		        ]
@@ -5392,8 +8267,29 @@ var SCHEMAS = map[string][]byte{
 		        "oneOf": [
 		          {
 		            "type": "object",
-		            "minProperties": 1,
-		            "maxProperties": 1,
+		            "properties": {
+		              "timeout": {
+		                "description": "Time limit for the entry. Executor is interrupted when it's exceeded and the entry fails.\n",
+		                "examples": [
+		                  "10m"
+		                ],
+		                "type": "string",
+		                "pattern": "^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+		              }
+		            },
+		            "if": {
+		              "required": [
+		                "timeout"
+		              ]
+		            },
+		            "then": {
+		              "minProperties": 2,
+		              "maxProperties": 2
+		            },
+		            "else": {
+		              "minProperties": 1,
+		              "maxProperties": 1
+		            },
 		            "additionalProperties": true
 		          },
 		          {
@@ -5413,8 +8309,29 @@ var SCHEMAS = map[string][]byte{
 		        "oneOf": [
 		          {
 		            "type": "object",
-		            "minProperties": 1,
-		            "maxProperties": 1,
+		            "properties": {
+		              "timeout": {
+		                "description": "Time limit for the entry. Executor is interrupted when it's exceeded and the entry fails.\n",
+		                "examples": [
+		                  "10m"
+		                ],
+		                "type": "string",
+		                "pattern": "^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+		              }
+		            },
+		            "if": {
+		              "required": [
+		                "timeout"
+		              ]
+		            },
+		            "then": {
+		              "minProperties": 2,
+		              "maxProperties": 2
+		            },
+		            "else": {
+		              "minProperties": 1,
+		              "maxProperties": 1
+		            },
 		            "additionalProperties": true
 		          },
 		          {
@@ -5458,8 +8375,29 @@ var SCHEMAS = map[string][]byte{
 		            "oneOf": [
 		              {
 		                "type": "object",
-		                "minProperties": 1,
-		                "maxProperties": 1,
+		                "properties": {
+		                  "timeout": {
+		                    "description": "Time limit for the entry. Executor is interrupted when it's exceeded and the entry fails.\n",
+		                    "examples": [
+		                      "10m"
+		                    ],
+		                    "type": "string",
+		                    "pattern": "^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+		                  }
+		                },
+		                "if": {
+		                  "required": [
+		                    "timeout"
+		                  ]
+		                },
+		                "then": {
+		                  "minProperties": 2,
+		                  "maxProperties": 2
+		                },
+		                "else": {
+		                  "minProperties": 1,
+		                  "maxProperties": 1
+		                },
 		                "additionalProperties": true
 		              },
 		              {
@@ -5484,8 +8422,29 @@ var SCHEMAS = map[string][]byte{
 		            "oneOf": [
 		              {
 		                "type": "object",
-		                "minProperties": 1,
-		                "maxProperties": 1,
+		                "properties": {
+		                  "timeout": {
+		                    "description": "Time limit for the entry. Executor is interrupted when it's exceeded and the entry fails.\n",
+		                    "examples": [
+		                      "10m"
+		                    ],
+		                    "type": "string",
+		                    "pattern": "^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+		                  }
+		                },
+		                "if": {
+		                  "required": [
+		                    "timeout"
+		                  ]
+		                },
+		                "then": {
+		                  "minProperties": 2,
+		                  "maxProperties": 2
+		                },
+		                "else": {
+		                  "minProperties": 1,
+		                  "maxProperties": 1
+		                },
 		                "additionalProperties": true
 		              },
 		              {
@@ -5506,8 +8465,29 @@ var SCHEMAS = map[string][]byte{
 		            "oneOf": [
 		              {
 		                "type": "object",
-		                "minProperties": 1,
-		                "maxProperties": 1,
+		                "properties": {
+		                  "timeout": {
+		                    "description": "Time limit for the entry. Executor is interrupted when it's exceeded and the entry fails.\n",
+		                    "examples": [
+		                      "10m"
+		                    ],
+		                    "type": "string",
+		                    "pattern": "^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+		                  }
+		                },
+		                "if": {
+		                  "required": [
+		                    "timeout"
+		                  ]
+		                },
+		                "then": {
+		                  "minProperties": 2,
+		                  "maxProperties": 2
+		                },
+		                "else": {
+		                  "minProperties": 1,
+		                  "maxProperties": 1
+		                },
 		                "additionalProperties": true
 		              },
 		              {
@@ -5534,8 +8514,29 @@ var SCHEMAS = map[string][]byte{
 		          "oneOf": [
 		            {
 		              "type": "object",
-		              "minProperties": 1,
-		              "maxProperties": 1,
+		              "properties": {
+		                "timeout": {
+		                  "description": "Time limit for the entry. Executor is interrupted when it's exceeded and the entry fails.\n",
+		                  "examples": [
+		                    "10m"
+		                  ],
+		                  "type": "string",
+		                  "pattern": "^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+		                }
+		              },
+		              "if": {
+		                "required": [
+		                  "timeout"
+		                ]
+		              },
+		              "then": {
+		                "minProperties": 2,
+		                "maxProperties": 2
+		              },
+		              "else": {
+		                "minProperties": 1,
+		                "maxProperties": 1
+		              },
 		              "additionalProperties": true
 		            },
 		            {
@@ -5551,8 +8552,29 @@ var SCHEMAS = map[string][]byte{
 		            "oneOf": [
 		              {
 		                "type": "object",
-		                "minProperties": 1,
-		                "maxProperties": 1,
+		                "properties": {
+		                  "timeout": {
+		                    "description": "Time limit for the entry. Executor is interrupted when it's exceeded and the entry fails.\n",
+		                    "examples": [
+		                      "10m"
+		                    ],
+		                    "type": "string",
+		                    "pattern": "^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+		                  }
+		                },
+		                "if": {
+		                  "required": [
+		                    "timeout"
+		                  ]
+		                },
+		                "then": {
+		                  "minProperties": 2,
+		                  "maxProperties": 2
+		                },
+		                "else": {
+		                  "minProperties": 1,
+		                  "maxProperties": 1
+		                },
 		                "additionalProperties": true
 		              },
 		              {
@@ -5573,9 +8595,23 @@ var SCHEMAS = map[string][]byte{
 		  "required": [
 		    "apiVersion",
 		    "kind",
-		    "name",
-		    "script"
+		    "name"
 		  ],
+		  "if": {
+		    "required": [
+		      "command"
+		    ]
+		  },
+		  "then": {
+		    "properties": {
+		      "script": false
+		    }
+		  },
+		  "else": {
+		    "required": [
+		      "script"
+		    ]
+		  },
 		  "additionalProperties": false,
 		  "properties": {
 		    "apiVersion": {
@@ -6055,6 +9091,80 @@ var SCHEMAS = map[string][]byte{
 		    },
 		    "script": {
 		      "type": "string"
+		    },
+		    "allowOutsideProject": {
+		      "type": "boolean"
+		    },
+		    "commands": {
+		      "description": "Commands which the executor runs using the \"exec\" module. When specified, other commands are rejected. Project's \"executorPolicy\" may further restrict commands available to executors.\n",
+		      "examples": [
+		        [
+		          "docker",
+		          "git"
+		        ]
+		      ],
+		      "type": "array",
+		      "items": {
+		        "type": "string",
+		        "minLength": 1
+		      }
+		    },
+		    "command": {
+		      "description": "External program implementing the executor, used instead of the script. The first item is the program (paths containing a slash are relative to the executor's file), remaining items are its arguments. The program receives input as JSON on stdin and writes results, log messages and errors as JSON lines to stdout. Its stderr is logged.\n",
+		      "examples": [
+		        [
+		          "./deployer.py"
+		        ],
+		        [
+		          "python3",
+		          "./deployer.py",
+		          "--verbose"
+		        ]
+		      ],
+		      "type": "array",
+		      "minItems": 1,
+		      "items": {
+		        "type": "string",
+		        "minLength": 1
+		      }
+		    }
+		  }
+		}
+	`),
+	"g2a-cli/v2.0/Validate-result": []byte(`
+		{
+		  "type": "object",
+		  "additionalProperties": true,
+		  "required": [
+		    "problems"
+		  ],
+		  "properties": {
+		    "problems": {
+		      "type": "array",
+		      "items": {
+		        "type": "object",
+		        "additionalProperties": false,
+		        "required": [
+		          "message"
+		        ],
+		        "properties": {
+		          "file": {
+		            "description": "Path to the file containing the problem.",
+		            "type": "string"
+		          },
+		          "line": {
+		            "description": "Line in the file where the problem was found.",
+		            "type": "integer"
+		          },
+		          "pointer": {
+		            "description": "JSON pointer to the invalid value within the document.",
+		            "type": "string"
+		          },
+		          "message": {
+		            "type": "string"
+		          }
+		        }
+		      }
 		    }
 		  }
 		}
@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io"
 
@@ -15,34 +14,44 @@ import (
 )
 
 func Validate(input []byte) ([]byte, error) {
+	var errs error
+
 	reader := bytes.NewReader(input)
 	decoder := yaml.NewDecoder(reader)
 
 	for {
+		var node yaml.Node
 		var content interface{}
 
-		err := decoder.Decode(&content)
+		err := decoder.Decode(&node)
 		if err == io.EOF {
 			break
 		}
 		if err != nil {
 			return nil, err
 		}
+		err = node.Decode(&content)
+		if err != nil {
+			return nil, err
+		}
 
 		apiVersion, err := dyno.GetString(content, "apiVersion")
 		if err != nil {
-			return nil, errors.New("missing apiVersion")
+			errs = multierror.Append(errs, NewValidationError("", &node, "/apiVersion", "missing apiVersion"))
+			continue
 		}
 		kind, err := dyno.GetString(content, "kind")
 		if err != nil {
-			return nil, errors.New("missing kind")
+			errs = multierror.Append(errs, NewValidationError("", &node, "/kind", "missing kind"))
+			continue
 		}
 
 		ctx := context.Background()
 
 		schema, ok := SCHEMAS[apiVersion+"/"+kind]
 		if !ok {
-			return nil, fmt.Errorf("kind %q is not supported by api version %q", kind, apiVersion)
+			errs = multierror.Append(errs, NewValidationError("", &node, "/kind", fmt.Sprintf("kind %q is not supported by api version %q", kind, apiVersion)))
+			continue
 		}
 
 		validator := &jsonschema.Schema{}
@@ -51,14 +60,14 @@ func Validate(input []byte) ([]byte, error) {
 		}
 
 		result := validator.Validate(ctx, dyno.ConvertMapI2MapS(content))
-		if len(*result.Errs) > 0 {
-			var err error
-			for _, e := range *result.Errs {
-				err = multierror.Append(err, errors.New(e.Error()))
-			}
-			return nil, err
+		for _, e := range *result.Errs {
+			errs = multierror.Append(errs, newValidationErrorFromKeyError(&node, e))
 		}
 	}
 
+	if errs != nil {
+		return nil, errs
+	}
+
 	return input, nil
 }
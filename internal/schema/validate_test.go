@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/hashicorp/go-multierror"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -68,3 +69,51 @@ func Test_validating_document_with_invalid_apiVersion_and_kind_combination_retur
 		})
 	}
 }
+
+func Test_validation_errors_contain_pointer_and_line_of_invalid_value(t *testing.T) {
+	input := []byte("apiVersion: g2a-cli/v2.0\nkind: Service\nname: test\nreleases:\n  - 7\n")
+
+	_, err := Validate(input)
+
+	assert.Error(t, err)
+	merr, ok := err.(*multierror.Error)
+	assert.True(t, ok)
+	assert.Contains(t, merr.Errors, &ValidationError{Line: 5, Pointer: "/releases/0", Message: "7 did not match any of the specified OneOf schemas"})
+}
+
+func Test_validation_errors_are_reported_for_all_documents(t *testing.T) {
+	input := []byte(`{ apiVersion: g2a-cli/v2.0, kind: Project, name: 7 }` + "\n---\n" + `{ apiVersion: g2a-cli/v2.0, kind: Service, name: 8 }`)
+
+	_, err := Validate(input)
+
+	assert.Error(t, err)
+	merr, ok := err.(*multierror.Error)
+	assert.True(t, ok)
+	assert.Len(t, merr.Errors, 2)
+}
+
+func Test_validating_entries_with_timeouts(t *testing.T) {
+	valid := []string{
+		`releases: [{ helm: {}, timeout: 10m }]`,
+		`releases: [{ timeout: 1h30m, helm: {} }]`,
+		`artifacts: [{ docker: {}, timeout: 90s }]`,
+		`artifacts: [{ docker: {}, push: false, timeout: 90s }]`,
+		`artifacts: [{ docker: {}, push: { script: {}, timeout: 1m }, timeout: 90s }]`,
+		`tasks: { test: [{ go: {}, timeout: 1.5m }] }`,
+	}
+	invalid := []string{
+		`releases: [{ helm: {}, timeout: 10 }]`,
+		`releases: [{ helm: {}, timeout: soon }]`,
+		`releases: [{ timeout: 10m }]`,
+		`releases: [{ helm: {}, npm: {}, timeout: 10m }]`,
+	}
+
+	for _, c := range valid {
+		_, err := Validate([]byte(`{ apiVersion: g2a-cli/v2.0, kind: Service, name: test, ` + c + ` }`))
+		assert.NoError(t, err, c)
+	}
+	for _, c := range invalid {
+		_, err := Validate([]byte(`{ apiVersion: g2a-cli/v2.0, kind: Service, name: test, ` + c + ` }`))
+		assert.Error(t, err, c)
+	}
+}
@@ -0,0 +1,191 @@
+package script
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/d5/tengo/v2"
+	"github.com/g2a-com/cicd/internal/tengoutil"
+	logger "github.com/g2a-com/klio-logger-go/v2"
+)
+
+// Types of messages written by external executors to stdout.
+const (
+	resultMessage = "result"
+	logMessage    = "log"
+	errorMessage  = "error"
+)
+
+// commandMessage is a single line written by an external executor to stdout,
+// e.g.:
+//
+//	{"type": "result", "value": "registry/app:1.0.0"}
+//	{"type": "result", "value": {"id": "registry/app:1.0.0", "digest": "sha256:..."}}
+//	{"type": "log", "level": "verbose", "message": "Pushing image"}
+//	{"type": "error", "message": "Image not found"}
+//
+// Lines which aren't JSON objects with the "type" field are logged as they
+// are.
+type commandMessage struct {
+	Type    string      `json:"type"`
+	Value   interface{} `json:"value"`
+	Level   string      `json:"level"`
+	Message string      `json:"message"`
+}
+
+// runCommand runs the external program implementing the executor. Input is
+// written as JSON to its stdin, messages are read from its stdout (see
+// commandMessage) and its stderr is logged. The program is stopped once the
+// context is done.
+func (s *Script) runCommand(ctx context.Context, log logger.Logger, input interface{}) (results []Result, err error) {
+	displayName := s.displayName
+	name, args := s.command[0], s.command[1:]
+
+	err = s.execOptions().CheckAllowed(name)
+	if err != nil {
+		return results, fmt.Errorf("Cannot run %s:\n\t%s", displayName, err)
+	}
+
+	stdin, err := encodeInput(input)
+	if err != nil {
+		return results, fmt.Errorf("Cannot encode input for %s:\n\t%s", displayName, err)
+	}
+
+	// Programs referenced using relative paths are distributed together with
+	// the executor's definition, other programs are searched in PATH
+	if strings.Contains(name, "/") && !filepath.IsAbs(name) {
+		name = filepath.Join(s.executor.Directory(), name)
+	}
+
+	stdout := &messageWriter{logger: log}
+	stderr := log.WithLevel(logger.InfoLevel)
+	cmd := s.Exec.Command(name, args...)
+	cmd.SetStdin(bytes.NewReader(stdin))
+	cmd.SetStdout(stdout)
+	cmd.SetStderr(stderr)
+
+	// Don't start programs after the executor was interrupted
+	if ctx.Err() == nil {
+		done := make(chan error, 1)
+		go func() {
+			done <- cmd.Run()
+		}()
+
+		select {
+		case err = <-done:
+		case <-ctx.Done():
+			log.WithLevel(logger.WarnLevel).Printf("Stopping %s...", displayName)
+			cmd.Stop()
+			<-done
+		}
+	}
+	stdout.flush()
+	if f, ok := stderr.(interface{ Flush() error }); ok {
+		_ = f.Flush()
+	}
+
+	if ctx.Err() == context.DeadlineExceeded {
+		return stdout.results, fmt.Errorf("Time limit exceeded while running %s", displayName)
+	}
+	if ctx.Err() != nil {
+		return stdout.results, fmt.Errorf("Interrupted while running %s", displayName)
+	}
+	if len(stdout.errors) > 0 {
+		return stdout.results, fmt.Errorf("Error occurred while running %s:\n\t%s", displayName, strings.Join(stdout.errors, "\n\t"))
+	}
+	if err != nil {
+		return stdout.results, fmt.Errorf("Error occurred while running %s:\n\t%s", displayName, err)
+	}
+
+	return stdout.results, nil
+}
+
+// encodeInput converts input to JSON the same way it's converted to a Tengo
+// object for scripts, so both kinds of executors receive the same fields.
+func encodeInput(input interface{}) ([]byte, error) {
+	obj, err := tengoutil.ToObject(input)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(tengo.ToInterface(obj))
+}
+
+// messageWriter handles messages written by external executors to stdout.
+type messageWriter struct {
+	logger  logger.Logger
+	results []Result
+	errors  []string
+
+	mutex  sync.Mutex
+	buffer []byte
+}
+
+func (w *messageWriter) Write(data []byte) (int, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.buffer = append(w.buffer, data...)
+	for {
+		i := bytes.IndexByte(w.buffer, '\n')
+		if i < 0 {
+			break
+		}
+		w.handle(w.buffer[:i])
+		w.buffer = w.buffer[i+1:]
+	}
+	return len(data), nil
+}
+
+// flush handles the last line, if it isn't terminated by a newline.
+func (w *messageWriter) flush() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if len(w.buffer) > 0 {
+		w.handle(w.buffer)
+		w.buffer = nil
+	}
+}
+
+func (w *messageWriter) handle(line []byte) {
+	line = bytes.TrimSuffix(line, []byte("\r"))
+	if len(bytes.TrimSpace(line)) == 0 {
+		return
+	}
+
+	var msg commandMessage
+	if err := json.Unmarshal(line, &msg); err != nil || msg.Type == "" {
+		w.logger.WithLevel(logger.InfoLevel).Print(string(line))
+		return
+	}
+
+	switch msg.Type {
+	case resultMessage:
+		r, err := newResult(msg.Value)
+		if err != nil {
+			w.errors = append(w.errors, fmt.Sprintf("invalid result message: %s: %s", err, line))
+			return
+		}
+		w.results = append(w.results, r)
+	case logMessage:
+		level := logger.InfoLevel
+		if msg.Level != "" {
+			parsed, ok := logger.ParseLevel(msg.Level)
+			if !ok {
+				w.errors = append(w.errors, fmt.Sprintf("unknown level %q of the log message: %s", msg.Level, msg.Message))
+				return
+			}
+			level = parsed
+		}
+		w.logger.WithLevel(level).Print(msg.Message)
+	case errorMessage:
+		w.errors = append(w.errors, msg.Message)
+	default:
+		w.errors = append(w.errors, fmt.Sprintf("unknown type %q of the message: %s", msg.Type, line))
+	}
+}
@@ -0,0 +1,222 @@
+package script
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/g2a-com/cicd/internal/object"
+	fakelogger "github.com/g2a-com/cicd/internal/utils/fake_logger"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+	"k8s.io/utils/exec"
+	testingexec "k8s.io/utils/exec/testing"
+)
+
+func Test_command_receives_input_as_json_and_returns_results(t *testing.T) {
+	var stdin []byte
+	cmd := &testingexec.FakeCmd{}
+	cmd.RunScript = []testingexec.FakeAction{
+		func() ([]byte, []byte, error) {
+			stdin, _ = io.ReadAll(cmd.Stdin)
+			return []byte("{\"type\": \"result\", \"value\": \"a\"}\n{\"type\": \"result\", \"value\": \"b\"}"), nil, nil
+		},
+	}
+	script := New(newCommandExecutor("./executor.py", "--verbose"))
+	script.Logger = fakelogger.New()
+	script.Exec = prepareFakeExec(cmd)
+
+	result, err := script.Run(map[string]interface{}{
+		"spec": map[string]interface{}{"image": "app"},
+		"tags": []string{"1.0.0"},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, IDs(result))
+	assert.JSONEq(t, `{"spec": {"image": "app"}, "tags": ["1.0.0"]}`, string(stdin))
+	assert.Equal(t, []string{"dir/executor.py", "--verbose"}, cmd.Argv)
+}
+
+func Test_command_returns_results_with_metadata(t *testing.T) {
+	stdout := "{\"type\": \"result\", \"value\": {\"id\": \"a\", \"digest\": \"sha256:1\"}}\n"
+	script := New(newCommandExecutor("executor"))
+	script.Logger = fakelogger.New()
+	script.Exec = prepareFakeExec(prepareFakeCmd(stdout, "", nil))
+
+	result, err := script.Run(nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []Result{{ID: "a", Metadata: map[string]interface{}{"digest": "sha256:1"}}}, result)
+}
+
+func Test_command_found_in_path_is_not_resolved(t *testing.T) {
+	cmd := prepareFakeCmd("", "", nil)
+	script := New(newCommandExecutor("python3", "executor.py"))
+	script.Logger = fakelogger.New()
+	script.Exec = prepareFakeExec(cmd)
+
+	_, err := script.Run(nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"python3", "executor.py"}, cmd.Argv)
+}
+
+func Test_command_logs_messages_stderr_and_other_output(t *testing.T) {
+	log := fakelogger.New()
+	stdout := "{\"type\": \"log\", \"level\": \"verbose\", \"message\": \"from message\"}\nplain text\n"
+	script := New(newCommandExecutor("executor"))
+	script.Logger = log
+	script.Exec = prepareFakeExec(prepareFakeCmd(stdout, "from stderr\n", nil))
+
+	_, err := script.Run(nil)
+
+	assert.NoError(t, err)
+	assert.Contains(t, log.Messages, fakelogger.Message{Level: "verbose", Method: "Print", Args: []interface{}{"from message"}})
+	assert.Contains(t, log.Messages, fakelogger.Message{Level: "info", Method: "Print", Args: []interface{}{"plain text"}})
+	assert.Contains(t, log.Messages, fakelogger.Message{Level: "info", Method: "Write", Args: []interface{}{[]byte("from stderr\n")}})
+}
+
+func Test_command_fails_when_it_reports_errors(t *testing.T) {
+	stdout := "{\"type\": \"result\", \"value\": \"a\"}\n{\"type\": \"error\", \"message\": \"first\"}\n{\"type\": \"error\", \"message\": \"second\"}\n"
+	script := New(newCommandExecutor("executor"))
+	script.Logger = fakelogger.New()
+	script.Exec = prepareFakeExec(prepareFakeCmd(stdout, "", nil))
+
+	result, err := script.Run(nil)
+
+	assert.Equal(t, []string{"a"}, IDs(result))
+	if assert.Error(t, err) {
+		assert.Equal(t, "Error occurred while running builder \"test\":\n\tfirst\n\tsecond", err.Error())
+	}
+}
+
+func Test_command_fails_when_it_exits_with_non_zero_code(t *testing.T) {
+	exitErr := exec.CodeExitError{Code: 2, Err: errors.New("exit status 2")}
+	script := New(newCommandExecutor("executor"))
+	script.Logger = fakelogger.New()
+	script.Exec = prepareFakeExec(prepareFakeCmd("", "", exitErr))
+
+	_, err := script.Run(nil)
+
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "exit status 2")
+	}
+}
+
+func Test_command_fails_on_unknown_messages(t *testing.T) {
+	script := New(newCommandExecutor("executor"))
+	script.Logger = fakelogger.New()
+	script.Exec = prepareFakeExec(prepareFakeCmd(`{"type": "unknown"}`, "", nil))
+
+	_, err := script.Run(nil)
+
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), `unknown type "unknown" of the message`)
+	}
+}
+
+func Test_command_must_be_allowed_by_policy(t *testing.T) {
+	script := New(newCommandExecutor("executor"))
+	script.Logger = fakelogger.New()
+	script.Policy = &object.ExecutorPolicy{RequireCommands: true}
+	script.Exec = prepareFakeExec()
+
+	_, err := script.Run(nil)
+
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), `command "executor" is not allowed for builder "test"`)
+	}
+}
+
+func Test_command_is_stopped_when_time_limit_is_exceeded(t *testing.T) {
+	stopped := make(chan struct{})
+	cmd := &testingexec.FakeCmd{
+		RunScript: []testingexec.FakeAction{
+			func() ([]byte, []byte, error) {
+				<-stopped
+				return nil, nil, errors.New("signal: terminated")
+			},
+		},
+	}
+	script := New(newCommandExecutor("executor"))
+	script.Logger = fakelogger.New()
+	script.Exec = prepareFakeExec(cmd)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	go func() {
+		<-ctx.Done()
+		close(stopped)
+	}()
+	_, err := script.RunContext(ctx, nil)
+
+	if assert.Error(t, err) {
+		assert.Equal(t, `Time limit exceeded while running builder "test"`, err.Error())
+	}
+}
+
+func newCommandExecutor(command ...string) object.Executor {
+	var node yaml.Node
+	data, _ := json.Marshal(command)
+	err := yaml.Unmarshal([]byte(fmt.Sprintf(`{
+		kind: Builder,
+		name: test,
+		schema: {},
+		command: %s,
+	}`, data)), &node)
+	if err != nil {
+		panic(err)
+	}
+
+	obj, err := object.NewExecutor("dir/file.yaml", &node)
+	if err != nil {
+		panic(err)
+	}
+
+	return obj
+}
+
+func prepareFakeExec(commands ...*testingexec.FakeCmd) *testingexec.FakeExec {
+	actions := make([]testingexec.FakeCommandAction, len(commands))
+	for i := range actions {
+		i := i
+		actions[i] = func(cmd string, args ...string) exec.Cmd {
+			return testingexec.InitFakeCmd(commands[i], cmd, args...)
+		}
+	}
+	return &testingexec.FakeExec{CommandScript: actions}
+}
+
+func prepareFakeCmd(stdout string, stderr string, err error) *testingexec.FakeCmd {
+	return &testingexec.FakeCmd{
+		RunScript: []testingexec.FakeAction{
+			func() ([]byte, []byte, error) {
+				return []byte(stdout), []byte(stderr), err
+			},
+		},
+	}
+}
+
+func Test_rollback_of_command_executor_runs_rollback_script(t *testing.T) {
+	var node yaml.Node
+	_ = yaml.Unmarshal([]byte(`{
+		kind: Deployer,
+		name: test,
+		schema: {},
+		command: [executor],
+		rollback: "addResult(\"rollback\")",
+	}`), &node)
+	executor, _ := object.NewExecutor("file.yaml", &node)
+	script := NewRollback(executor)
+	script.Logger = fakelogger.New()
+	script.Exec = prepareFakeExec()
+
+	result, err := script.Run(nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"rollback"}, IDs(result))
+}
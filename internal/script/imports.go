@@ -0,0 +1,36 @@
+package script
+
+import (
+	"strconv"
+
+	"github.com/d5/tengo/v2/parser"
+	"github.com/d5/tengo/v2/token"
+)
+
+// Imports returns names of modules imported by the source code, without
+// duplicates. Source code doesn't need to be valid, imports are found by
+// scanning tokens (so imports within comments or strings are omitted).
+func Imports(source string) []string {
+	src := []byte(source)
+	file := parser.NewFileSet().AddFile("", -1, len(src))
+	scanner := parser.NewScanner(file, src, nil, parser.DontInsertSemis)
+
+	names := []string{}
+	seen := map[string]bool{}
+	var prev, prevPrev token.Token
+	for {
+		tok, literal, _ := scanner.Scan()
+		if tok == token.EOF {
+			break
+		}
+		if tok == token.String && prev == token.LParen && prevPrev == token.Import {
+			name, err := strconv.Unquote(literal)
+			if err == nil && !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+		prevPrev, prev = prev, tok
+	}
+	return names
+}
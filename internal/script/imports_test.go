@@ -0,0 +1,25 @@
+package script
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_imports_returns_names_of_imported_modules(t *testing.T) {
+	result := Imports("a := import(\"exec\")\nb := import(`helpers`)\nc := import(\"exec\")")
+
+	assert.Equal(t, []string{"exec", "helpers"}, result)
+}
+
+func Test_imports_omits_comments_and_strings(t *testing.T) {
+	result := Imports("// import(\"a\")\n/* import(\"b\") */\nc := \"import(\\\"c\\\")\"")
+
+	assert.Empty(t, result)
+}
+
+func Test_imports_ignores_syntax_errors(t *testing.T) {
+	result := Imports(`if { import("a")`)
+
+	assert.Equal(t, []string{"a"}, result)
+}
@@ -0,0 +1,72 @@
+package script
+
+import (
+	"errors"
+)
+
+// Result is a value added by the executor (e.g. using addResult function).
+// Besides the identifier, it may carry arbitrary metadata, like a digest or a
+// size of the built artifact.
+type Result struct {
+	ID       string
+	Metadata map[string]interface{}
+}
+
+// Map returns the result in the form passed to other executors, which is
+// metadata together with the "id" field.
+func (r Result) Map() map[string]interface{} {
+	m := make(map[string]interface{}, len(r.Metadata)+1)
+	for key, value := range r.Metadata {
+		m[key] = value
+	}
+	m["id"] = r.ID
+	return m
+}
+
+// IDs returns identifiers of the results, for results which are used only
+// as strings (e.g. tags).
+func IDs(results []Result) []string {
+	ids := make([]string, len(results))
+	for i, r := range results {
+		ids[i] = r.ID
+	}
+	return ids
+}
+
+// Maps returns results in the form passed to other executors (see Map).
+func Maps(results []Result) []map[string]interface{} {
+	maps := make([]map[string]interface{}, len(results))
+	for i, r := range results {
+		maps[i] = r.Map()
+	}
+	return maps
+}
+
+var errInvalidResult = errors.New(`result must be a string or a map with a string "id" field`)
+
+// newResult converts a value added by the executor to the result. Values are
+// either strings, or maps with the "id" field and metadata.
+func newResult(value interface{}) (Result, error) {
+	switch v := value.(type) {
+	case string:
+		return Result{ID: v}, nil
+	case map[string]interface{}:
+		id, ok := v["id"].(string)
+		if !ok || id == "" {
+			return Result{}, errInvalidResult
+		}
+		var metadata map[string]interface{}
+		for key, value := range v {
+			if key == "id" {
+				continue
+			}
+			if metadata == nil {
+				metadata = map[string]interface{}{}
+			}
+			metadata[key] = value
+		}
+		return Result{ID: id, Metadata: metadata}, nil
+	default:
+		return Result{}, errInvalidResult
+	}
+}
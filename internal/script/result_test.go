@@ -0,0 +1,58 @@
+package script
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_result_map_contains_id_and_metadata(t *testing.T) {
+	r := Result{ID: "a", Metadata: map[string]interface{}{"digest": "sha256:1"}}
+
+	assert.Equal(t, map[string]interface{}{"id": "a", "digest": "sha256:1"}, r.Map())
+}
+
+func Test_result_without_metadata_is_map_with_id(t *testing.T) {
+	r := Result{ID: "a"}
+
+	assert.Equal(t, map[string]interface{}{"id": "a"}, r.Map())
+}
+
+func Test_ids_returns_identifiers_of_results(t *testing.T) {
+	results := []Result{{ID: "a"}, {ID: "b", Metadata: map[string]interface{}{"size": 1}}}
+
+	assert.Equal(t, []string{"a", "b"}, IDs(results))
+}
+
+func Test_maps_returns_results_with_metadata(t *testing.T) {
+	results := []Result{{ID: "a"}, {ID: "b", Metadata: map[string]interface{}{"size": 1}}}
+
+	assert.Equal(t, []map[string]interface{}{{"id": "a"}, {"id": "b", "size": 1}}, Maps(results))
+}
+
+func Test_results_are_created_from_strings_and_maps(t *testing.T) {
+	testCases := []struct {
+		value    interface{}
+		expected Result
+		err      bool
+	}{
+		{value: "a", expected: Result{ID: "a"}},
+		{value: map[string]interface{}{"id": "a"}, expected: Result{ID: "a"}},
+		{value: map[string]interface{}{"id": "a", "size": 1}, expected: Result{ID: "a", Metadata: map[string]interface{}{"size": 1}}},
+		{value: map[string]interface{}{"size": 1}, err: true},
+		{value: map[string]interface{}{"id": ""}, err: true},
+		{value: map[string]interface{}{"id": 7}, err: true},
+		{value: 7, err: true},
+	}
+
+	for _, tc := range testCases {
+		result, err := newResult(tc.value)
+
+		if tc.err {
+			assert.Error(t, err, "%v", tc.value)
+		} else {
+			assert.NoError(t, err, "%v", tc.value)
+			assert.Equal(t, tc.expected, result, "%v", tc.value)
+		}
+	}
+}
@@ -1,45 +1,116 @@
 package script
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/d5/tengo/v2"
 	"github.com/g2a-com/cicd/internal/object"
 	"github.com/g2a-com/cicd/internal/script/stdlib"
+	execModule "github.com/g2a-com/cicd/internal/script/stdlib/exec"
+	fsModule "github.com/g2a-com/cicd/internal/script/stdlib/fs"
+	"github.com/g2a-com/cicd/internal/secrets"
+	"github.com/g2a-com/cicd/internal/tengoutil"
 	logger "github.com/g2a-com/klio-logger-go/v2"
+	"k8s.io/utils/exec"
 )
 
 type Script struct {
-	executor object.Executor
-	Logger   logger.Logger
+	executor    object.Executor
+	source      string
+	command     []string
+	displayName string
+	Logger      logger.Logger
+	// Masker masks values of secrets in logs and errors of the script.
+	Masker *secrets.Masker
+	// Policy restricts commands which can be run by the script, in addition
+	// to commands declared by the executor.
+	Policy *object.ExecutorPolicy
+	// Libraries can be imported by the script using their names.
+	Libraries []object.Library
+	// Exec runs commands started by the executor, it allows to fake them in
+	// tests. By default, commands are run as processes.
+	Exec exec.Interface
 }
 
 func New(executor object.Executor) *Script {
 	script := &Script{}
 	script.executor = executor
+	script.source = executor.Script()
+	script.command = executor.Command()
+	script.Exec = execModule.NewProcessExec()
+	script.displayName = executor.DisplayName()
 	script.Logger = logger.StandardLogger()
 	return script
 }
 
-func (s *Script) Run(input interface{}) (results []string, err error) {
-	displayName := s.executor.DisplayName()
+// NewRollback creates script running rollback implementation of the
+// executor.
+func NewRollback(executor object.Executor) *Script {
+	script := New(executor)
+	script.source = executor.RollbackScript()
+	script.command = nil
+	script.displayName = "rollback of " + executor.DisplayName()
+	return script
+}
+
+func (s *Script) Run(input interface{}) (results []Result, err error) {
+	return s.RunContext(context.Background(), input)
+}
+
+// RunContext runs the script, which is interrupted (together with commands it
+// started) once the context is done.
+func (s *Script) RunContext(ctx context.Context, input interface{}) (results []Result, err error) {
+	displayName := s.displayName
+	log := s.Masker.Logger(s.Logger)
+	defer func() {
+		err = s.Masker.MaskError(err)
+	}()
 
-	s.Logger.WithLevel(logger.SpamLevel).Printf("Running %s", displayName)
+	log.WithLevel(logger.SpamLevel).Printf("Running %s", displayName)
+
+	// Executors implemented by external programs don't use Tengo at all
+	if len(s.command) > 0 {
+		return s.runCommand(ctx, log, input)
+	}
 
 	// Create a new tengo script instance
-	script := tengo.NewScript([]byte(s.executor.Script()))
+	script := tengo.NewScript([]byte(s.source))
 
-	// Prepare function for updating results
-	addResult := func(rs ...string) {
-		results = append(results, rs...)
+	// Prepare function for updating results, results are either strings or
+	// maps with the "id" field
+	addResult := func(values ...tengo.Object) {
+		for _, value := range values {
+			r, err := newResult(tengo.ToInterface(value))
+			if err != nil {
+				panic(fmt.Errorf("addResult: %w, found %s", err, value.TypeName()))
+			}
+			results = append(results, r)
+		}
 	}
 
 	// Set imports & builtins
-	std := stdlib.New(s.Logger)
+	std := stdlib.New(log)
+	std.SetContext(ctx)
+	defer func() {
+		if cleanupErr := std.Cleanup(); cleanupErr != nil && err == nil {
+			err = fmt.Errorf("Cannot clean up after %s:\n\t%s", displayName, cleanupErr)
+		}
+	}()
 	err = std.AddBuiltin("input", input)
 	if err != nil {
 		return results, fmt.Errorf("Cannot initialize standard library for %s:\n\t%s", displayName, err)
 	}
+	fsOptions := fsModule.Options{AllowOutsideProject: s.executor.AllowOutsideProject()}
+	fsOptions.ProjectDir, fsOptions.WorkingDir = inputDirs(input)
+	std.SetFsOptions(fsOptions)
+	std.SetExecOptions(s.execOptions())
+	for _, library := range s.Libraries {
+		err = std.AddLibrary(library.Name(), library.Script())
+		if err != nil {
+			return results, fmt.Errorf("Cannot initialize standard library for %s:\n\t%s", displayName, err)
+		}
+	}
 	err = std.AddBuiltin("addResult", addResult)
 	if err != nil {
 		return results, fmt.Errorf("Cannot initialize standard library for %s:\n\t%s", displayName, err)
@@ -50,10 +121,50 @@ func (s *Script) Run(input interface{}) (results []string, err error) {
 	}
 
 	// Run the script
-	_, err = script.Run()
+	_, err = script.RunContext(ctx)
+	if err != nil && ctx.Err() == context.DeadlineExceeded {
+		return results, fmt.Errorf("Time limit exceeded while running %s", displayName)
+	}
+	if err != nil && ctx.Err() != nil {
+		return results, fmt.Errorf("Interrupted while running %s", displayName)
+	}
 	if err != nil {
 		return results, fmt.Errorf("Error occurred while running %s:\n\t%s", displayName, err)
 	}
 
 	return results, nil
 }
+
+// execOptions returns commands which can be run by the executor.
+func (s *Script) execOptions() execModule.Options {
+	opts := execModule.Options{
+		Owner: fmt.Sprintf("%s defined in %s", s.executor.DisplayName(), s.executor.Metadata()),
+		Exec:  s.Exec,
+	}
+	opts.AllowedCommands, opts.RestrictCommands = s.Policy.CommandsFor(s.executor)
+	return opts
+}
+
+// inputDirs returns project directory and directory of the service from the
+// "dirs" field of the input.
+func inputDirs(input interface{}) (project string, service string) {
+	obj, err := tengoutil.ToObject(input)
+	if err != nil {
+		return "", ""
+	}
+	m, ok := obj.(*tengo.Map)
+	if !ok {
+		return "", ""
+	}
+	dirs, ok := m.Value["dirs"].(*tengo.Map)
+	if !ok {
+		return "", ""
+	}
+	if str, ok := dirs.Value["project"].(*tengo.String); ok {
+		project = str.Value
+	}
+	if str, ok := dirs.Value["service"].(*tengo.String); ok {
+		service = str.Value
+	}
+	return project, service
+}
@@ -1,10 +1,15 @@
 package script
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/g2a-com/cicd/internal/object"
+	"github.com/g2a-com/cicd/internal/secrets"
 	fakelogger "github.com/g2a-com/cicd/internal/utils/fake_logger"
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/yaml.v3"
@@ -32,7 +37,33 @@ func Test_returns_results_added_by_script(t *testing.T) {
 	result, err := script.Run(nil)
 
 	assert.NoError(t, err)
-	assert.Equal(t, []string{"a", "b", "c"}, result)
+	assert.Equal(t, []string{"a", "b", "c"}, IDs(result))
+}
+
+func Test_returns_results_with_metadata_added_by_script(t *testing.T) {
+	executor := newExecutor(`addResult("a", {id: "b", digest: "sha256:1", size: 7})`)
+	script := New(executor)
+	script.Logger = fakelogger.New()
+
+	result, err := script.Run(nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []Result{
+		{ID: "a"},
+		{ID: "b", Metadata: map[string]interface{}{"digest": "sha256:1", "size": int64(7)}},
+	}, result)
+}
+
+func Test_returns_error_when_result_has_no_id(t *testing.T) {
+	executor := newExecutor(`addResult({digest: "sha256:1"})`)
+	script := New(executor)
+	script.Logger = fakelogger.New()
+
+	_, err := script.Run(nil)
+
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), `addResult: result must be a string or a map with a string "id" field, found map`)
+	}
 }
 
 func Test_returns_error_when_script_has_invalid_syntax(t *testing.T) {
@@ -55,7 +86,75 @@ func Test_returns_error_when_script_is_aborted(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func Test_rollback_script_runs_rollback_implementation_of_executor(t *testing.T) {
+	var node yaml.Node
+	yaml.Unmarshal([]byte(`{
+		kind: Deployer,
+		name: test,
+		schema: {},
+		script: "addResult(\"deploy\")",
+		rollback: "addResult(\"rollback\", input.releases...)",
+	}`), &node)
+	executor, _ := object.NewExecutor("file.yaml", &node)
+	script := NewRollback(executor)
+	script.Logger = fakelogger.New()
+
+	result, err := script.Run(map[string]interface{}{
+		"releases": []string{"a", "b"},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"rollback", "a", "b"}, IDs(result))
+}
+
 // TODO: use object.fakeObject instead (needs to be exported first)
+func Test_rejects_commands_which_are_not_declared_by_executor(t *testing.T) {
+	var node yaml.Node
+	_ = yaml.Unmarshal([]byte(`{
+		kind: Builder,
+		name: test,
+		schema: {},
+		script: "import(\"exec\").run(\"curl\")",
+		commands: [docker],
+	}`), &node)
+	executor, _ := object.NewExecutor("file.yaml", &node)
+	script := New(executor)
+	script.Logger = fakelogger.New()
+
+	_, err := script.Run(nil)
+
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), `command "curl" is not allowed for builder "test" defined in file.yaml:1, allowed commands: docker`)
+	}
+}
+
+func Test_rejects_commands_which_are_not_allowed_by_policy(t *testing.T) {
+	executor := newExecutor(`import("exec").run("curl")`)
+	script := New(executor)
+	script.Logger = fakelogger.New()
+	script.Policy = &object.ExecutorPolicy{RequireCommands: true}
+
+	_, err := script.Run(nil)
+
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), `command "curl" is not allowed for builder "test" defined in file.yaml:1, allowed commands: none`)
+	}
+}
+
+func Test_libraries_can_be_imported_by_script(t *testing.T) {
+	executor := newExecutor(`addResult(import("helpers").image(input.name))`)
+	script := New(executor)
+	script.Logger = fakelogger.New()
+	script.Libraries = []object.Library{
+		newLibrary("helpers", `export { image: func(name) { return "registry/" + name } }`),
+	}
+
+	result, err := script.Run(map[string]interface{}{"name": "app"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"registry/app"}, IDs(result))
+}
+
 func newExecutor(script string) object.Executor {
 	var node yaml.Node
 	err := yaml.Unmarshal([]byte(fmt.Sprintf(`{
@@ -75,3 +174,75 @@ func newExecutor(script string) object.Executor {
 
 	return obj
 }
+
+func newLibrary(name string, script string) object.Library {
+	var node yaml.Node
+	err := yaml.Unmarshal([]byte(fmt.Sprintf(`{ kind: Library, name: %q, script: %q }`, name, script)), &node)
+	if err != nil {
+		panic(err)
+	}
+
+	obj, err := object.NewLibrary("library.yaml", &node)
+	if err != nil {
+		panic(err)
+	}
+
+	return obj
+}
+
+func Test_fs_module_resolves_paths_relative_to_service_dir(t *testing.T) {
+	projectDir := t.TempDir()
+	serviceDir := filepath.Join(projectDir, "service")
+	_ = os.Mkdir(serviceDir, 0755)
+	_ = os.WriteFile(filepath.Join(serviceDir, "VERSION"), []byte("1.2.3"), 0644)
+	executor := newExecutor(`addResult(import("fs").read_file("VERSION"))`)
+	script := New(executor)
+	script.Logger = fakelogger.New()
+
+	result, err := script.Run(map[string]interface{}{
+		"dirs": map[string]interface{}{"project": projectDir, "service": serviceDir},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1.2.3"}, IDs(result))
+}
+
+func Test_fs_module_rejects_paths_outside_of_project_dir(t *testing.T) {
+	projectDir := t.TempDir()
+	executor := newExecutor(`import("fs").read_file("../file")`)
+	script := New(executor)
+	script.Logger = fakelogger.New()
+
+	_, err := script.Run(map[string]interface{}{
+		"dirs": map[string]interface{}{"project": projectDir},
+	})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "outside of the project directory")
+}
+
+func Test_secrets_are_masked_in_logs_and_errors(t *testing.T) {
+	log := fakelogger.New()
+	executor := newExecutor(`import("log").print("token: " + input.token); abort("invalid token " + input.token)`)
+	script := New(executor)
+	script.Logger = log
+	script.Masker = secrets.NewMasker("secret")
+
+	_, err := script.Run(map[string]interface{}{"token": "secret"})
+
+	assert.Error(t, err)
+	assert.NotContains(t, err.Error(), "secret")
+	assert.Contains(t, log.Messages, fakelogger.Message{Level: "info", Method: "Print", Args: []interface{}{"token: ***"}})
+}
+
+func Test_returns_error_when_time_limit_is_exceeded(t *testing.T) {
+	executor := newExecutor(`for {}`)
+	script := New(executor)
+	script.Logger = fakelogger.New()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := script.RunContext(ctx, nil)
+
+	assert.EqualError(t, err, `Time limit exceeded while running builder "test"`)
+}
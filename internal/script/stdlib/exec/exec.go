@@ -2,8 +2,13 @@ package exec
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"io"
+	"os"
+	"strings"
+	"sync"
 
 	"github.com/d5/tengo/v2"
 	"github.com/g2a-com/cicd/internal/tengoutil"
@@ -11,24 +16,82 @@ import (
 	"k8s.io/utils/exec"
 )
 
+// NativeModuleName is a name under which the module must be registered, in
+// addition to the regular name. It gives access to functions implemented in Go,
+// which are wrapped by the Tengo part of the module (see source.go).
+const NativeModuleName = "exec:native"
+
+// Options restricts commands which can be run using the module.
+type Options struct {
+	// RestrictCommands enables checking whether commands are present in
+	// AllowedCommands.
+	RestrictCommands bool
+	AllowedCommands  []string
+	// Owner describes the executor using the module (e.g. its name and
+	// definition file), it's used in error messages.
+	Owner string
+	// Exec runs commands, by default they are run as processes (see
+	// NewProcessExec). It allows to fake commands in tests.
+	Exec exec.Interface
+}
+
 type module struct {
-	exec   exec.Interface
-	logger logger.Logger
+	ctx         context.Context
+	cancel      context.CancelFunc
+	exec        exec.Interface
+	logger      logger.Logger
+	resolvePath func(string) (string, error)
+	opts        Options
+
+	mutex     sync.Mutex
+	processes []*process
 }
 
-func New(logger logger.Logger) *module {
+// New creates exec module. Commands run by the module are interrupted once the
+// context is done, resolvePath is used to resolve paths of files passed to
+// commands as stdin.
+func New(ctx context.Context, logger logger.Logger, resolvePath func(string) (string, error), opts Options) *module {
+	ctx, cancel := context.WithCancel(ctx)
+	execImpl := opts.Exec
+	if execImpl == nil {
+		execImpl = processExec{}
+	}
 	return &module{
-		exec:   exec.New(),
-		logger: logger,
+		ctx:         ctx,
+		cancel:      cancel,
+		exec:        execImpl,
+		logger:      logger,
+		resolvePath: resolvePath,
+		opts:        opts,
 	}
 }
 
+// Cleanup stops commands which are still running, e.g. because the script was
+// aborted while reading their output.
+func (m *module) Cleanup() error {
+	m.cancel()
+
+	m.mutex.Lock()
+	processes := m.processes
+	m.processes = nil
+	m.mutex.Unlock()
+
+	for _, p := range processes {
+		p.finish()
+	}
+	return nil
+}
+
 func (m *module) Import(name string) (interface{}, error) {
+	if name != NativeModuleName {
+		return []byte(source), nil
+	}
 	return tengoutil.ToImmutableObject(map[string]interface{}{
 		"__module_name__": name,
 		"command":         m.command,
 		"run":             m.run,
 		"run_silently":    m.runSilently,
+		"run_all":         m.runAll,
 	})
 }
 
@@ -40,7 +103,7 @@ func (m *module) command(opts cmdOpts) *cmd {
 		opts.StderrLevel = logger.ErrorLevel
 	}
 
-	return &cmd{&opts, m.logger, m.exec}
+	return m.newCmd(&opts)
 }
 
 func (m *module) run(cmdName string, args ...string) cmdResult {
@@ -50,7 +113,7 @@ func (m *module) run(cmdName string, args ...string) cmdResult {
 		StdoutLevel: logger.InfoLevel,
 		StderrLevel: logger.ErrorLevel,
 	}
-	return (&cmd{opts, m.logger, m.exec}).run()
+	return m.newCmd(opts).run()
 }
 
 func (m *module) runSilently(cmdName string, args ...string) cmdResult {
@@ -60,62 +123,393 @@ func (m *module) runSilently(cmdName string, args ...string) cmdResult {
 		StdoutLevel: logger.DebugLevel,
 		StderrLevel: logger.ErrorLevel,
 	}
-	return (&cmd{opts, m.logger, m.exec}).run()
+	return m.newCmd(opts).run()
+}
+
+// runAll runs commands concurrently, at most opts.Concurrency at once (by
+// default all of them). Output of each command is tagged with its tag option,
+// or its name and position on the list. Failures are reported once all commands
+// complete.
+func (m *module) runAll(commands []tengo.Object, opts ...runAllOpts) []cmdResult {
+	cmds := make([]*cmd, len(commands))
+	tags := make([]string, len(commands))
+	for i, obj := range commands {
+		o, ok := obj.(*cmdObject)
+		if !ok {
+			panic(fmt.Errorf("expected command created using command function without on_line option at index %d, found %s", i, obj.TypeName()))
+		}
+		cmds[i] = o.cmd
+		tags[i] = o.cmd.opts.Tag
+		if tags[i] == "" {
+			tags[i] = fmt.Sprintf("%s#%d", o.cmd.opts.Name, i+1)
+		}
+	}
+
+	concurrency := len(cmds)
+	if len(opts) > 0 && opts[0].Concurrency > 0 {
+		concurrency = opts[0].Concurrency
+	}
+
+	// Commands are started one by one, but they're awaited concurrently
+	results := make([]cmdResult, len(cmds))
+	semaphore := make(chan struct{}, concurrency)
+	wg := sync.WaitGroup{}
+	for i := range cmds {
+		semaphore <- struct{}{}
+		p, err := cmds[i].startProcess(tags[i])
+		if err != nil {
+			results[i] = newResult(err)
+			<-semaphore
+			continue
+		}
+		wg.Add(1)
+		go func(i int, p *process) {
+			defer wg.Done()
+			results[i] = p.result()
+			<-semaphore
+		}(i, p)
+	}
+	wg.Wait()
+
+	var failures []string
+	for i, result := range results {
+		if result.Error != nil && !cmds[i].opts.IgnoreErrors {
+			failures = append(failures, fmt.Sprintf("%s: %s", tags[i], result.Error))
+		}
+	}
+	if len(failures) > 0 {
+		panic(fmt.Errorf("%d of %d commands failed: %s", len(failures), len(cmds), strings.Join(failures, "; ")))
+	}
+
+	return results
+}
+
+// CheckAllowed returns an error when the command cannot be run.
+func (o Options) CheckAllowed(name string) error {
+	if !o.RestrictCommands {
+		return nil
+	}
+	for _, allowed := range o.AllowedCommands {
+		if name == allowed {
+			return nil
+		}
+	}
+
+	allowed := "none"
+	if len(o.AllowedCommands) > 0 {
+		allowed = strings.Join(o.AllowedCommands, ", ")
+	}
+	return fmt.Errorf(
+		"command %q is not allowed for %s, allowed commands: %s (declare it in \"commands\" of the executor, or allow it in \"executorPolicy\" of the project)",
+		name, o.Owner, allowed,
+	)
+}
+
+func (m *module) newCmd(opts *cmdOpts) *cmd {
+	return &cmd{m, opts}
+}
+
+// track keeps started process, so it can be stopped during cleanup.
+func (m *module) track(p *process) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	running := m.processes[:0]
+	for _, p := range m.processes {
+		select {
+		case <-p.done:
+		default:
+			running = append(running, p)
+		}
+	}
+	m.processes = append(running, p)
 }
 
 type cmd struct {
+	mod  *module
 	opts *cmdOpts
-	log  logger.Logger
-	exec exec.Interface
 }
 
 func (c *cmd) EncodeTengoObject() (tengo.Object, error) {
-	return tengoutil.ToImmutableObject(map[string]interface{}{
-		"run": c.run,
+	methods, err := tengoutil.ToImmutableObject(map[string]interface{}{
+		"run":   c.run,
+		"start": c.start,
 	})
+	if err != nil {
+		return nil, err
+	}
+	return &cmdObject{methods.(*tengo.ImmutableMap), c}, nil
 }
 
 func (c *cmd) run() cmdResult {
+	return c.start().wait()
+}
+
+// start starts the command without waiting for it to complete. If on_line
+// option is set, lines printed by the command are available using next_line
+// method of the returned process.
+func (c *cmd) start() *process {
+	p, err := c.startProcess(c.opts.Tag)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// startProcess starts the command, its output is logged with the tag (unless
+// it's empty) appended to the tags of the module's logger.
+func (c *cmd) startProcess(tag string) (*process, error) {
+	// Don't start commands after script was interrupted
+	if err := c.mod.ctx.Err(); err != nil {
+		return nil, interruptedError(c.opts.Name, err)
+	}
+	if err := c.mod.opts.CheckAllowed(c.opts.Name); err != nil {
+		return nil, err
+	}
+
+	log := c.mod.logger
+	if tag != "" {
+		tags := append([]string{}, log.Tags()...)
+		log = log.WithTags(append(tags, tag)...)
+	}
+
+	p := &process{opts: c.opts, done: make(chan struct{})}
+
+	// Prepare output
+	stdout := []io.Writer{&p.stdout}
+	stderr := []io.Writer{&p.stderr}
+	var loggers []logger.Logger
+	if c.opts.StdoutLevel != "disable" {
+		level, err := parseLevel(string(c.opts.StdoutLevel))
+		if err != nil {
+			return nil, err
+		}
+		loggers = append(loggers, log.WithLevel(level))
+		stdout = append(stdout, loggers[len(loggers)-1])
+	}
+	if c.opts.StderrLevel != "disable" {
+		level, err := parseLevel(string(c.opts.StderrLevel))
+		if err != nil {
+			return nil, err
+		}
+		loggers = append(loggers, log.WithLevel(level))
+		stderr = append(stderr, loggers[len(loggers)-1])
+	}
+	var lineWriters []*lineWriter
+	if c.opts.OnLine != nil {
+		p.lines = make(chan outputLine, 100)
+		lineWriters = []*lineWriter{
+			{stream: "stdout", lines: p.lines},
+			{stream: "stderr", lines: p.lines},
+		}
+		stdout = append(stdout, lineWriters[0])
+		stderr = append(stderr, lineWriters[1])
+	}
+
 	// Prepare command to run
-	execCmd := c.exec.Command(c.opts.Name, c.opts.Args...)
+	stdin, err := c.stdin()
+	if err != nil {
+		return nil, err
+	}
+	execCmd := c.mod.exec.Command(c.opts.Name, c.opts.Args...)
 	execCmd.SetDir(c.opts.Dir)
-	execCmd.SetEnv(c.opts.Env)
+	execCmd.SetEnv(c.env())
+	if stdin != nil {
+		execCmd.SetStdin(stdin)
+	}
+	execCmd.SetStdout(io.MultiWriter(stdout...))
+	execCmd.SetStderr(io.MultiWriter(stderr...))
 
-	var stdoutBuffer, stderrBuffer bytes.Buffer
+	// Run command in the background
+	go func() {
+		p.err = c.wait(execCmd, log)
+		if stdin, ok := stdin.(io.Closer); ok {
+			stdin.Close()
+		}
+		for _, w := range lineWriters {
+			w.flush()
+		}
+		for _, l := range loggers {
+			flushLogger(l)
+		}
+		if p.lines != nil {
+			close(p.lines)
+		}
+		close(p.done)
+	}()
+	c.mod.track(p)
+
+	return p, nil
+}
 
-	if c.opts.StdoutLevel == "disable" {
-		execCmd.SetStdout(&stdoutBuffer)
-	} else {
-		level := parseLevel(string(c.opts.StdoutLevel))
-		execCmd.SetStdout(io.MultiWriter(&stdoutBuffer, c.log.WithLevel(level)))
+// flushLogger writes output held back by the logger (masking logger holds back
+// text which may be the beginning of a secret).
+func flushLogger(l logger.Logger) {
+	if f, ok := l.(interface{ Flush() error }); ok {
+		_ = f.Flush()
 	}
-	if c.opts.StderrLevel == "disable" {
-		execCmd.SetStderr(&stderrBuffer)
-	} else {
-		level := parseLevel(string(c.opts.StderrLevel))
-		execCmd.SetStderr(io.MultiWriter(&stderrBuffer, c.log.WithLevel(level)))
+}
+
+// env returns environment variables for the command. When env option is empty,
+// the command inherits environment of the current process.
+func (c *cmd) env() []string {
+	if c.opts.InheritEnv && len(c.opts.Env) > 0 {
+		// In case of duplicates, the last value takes precedence
+		return append(os.Environ(), c.opts.Env...)
 	}
+	return c.opts.Env
+}
 
-	// Run and prepare result
-	result := cmdResult{}
-	result.Error = execCmd.Run()
-	if result.Error != nil {
-		if !c.opts.IgnoreErrors {
-			panic(result.Error)
-		}
-		if e, ok := result.Error.(exec.ExitError); ok {
-			result.ExitCode = e.ExitStatus()
-		} else {
-			result.ExitCode = -1
+func (c *cmd) stdin() (io.Reader, error) {
+	if c.opts.StdinText != "" && c.opts.StdinFile != "" {
+		return nil, errors.New("options stdin_text and stdin_file cannot be used together")
+	}
+	if c.opts.StdinText != "" {
+		return strings.NewReader(c.opts.StdinText), nil
+	}
+	if c.opts.StdinFile != "" {
+		path, err := c.mod.resolvePath(c.opts.StdinFile)
+		if err != nil {
+			return nil, err
 		}
+		return os.Open(path)
+	}
+	return nil, nil
+}
+
+// wait runs the command and waits for it to finish. Once the context is done,
+// the command is stopped (see exec.Cmd's Stop method). Interrupted commands
+// always return an error.
+func (c *cmd) wait(execCmd exec.Cmd, log logger.Logger) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- execCmd.Run()
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-c.mod.ctx.Done():
+		log.WithLevel(logger.WarnLevel).Printf("Stopping command %q...", c.opts.Name)
+		execCmd.Stop()
+		<-done
+		return interruptedError(c.opts.Name, c.mod.ctx.Err())
+	}
+}
+
+// cmdObject is a Tengo representation of the command. Unlike maps returned by
+// tengoutil, it allows to get the command back once it's passed to one of the
+// module's functions (see run_all).
+type cmdObject struct {
+	*tengo.ImmutableMap
+	cmd *cmd
+}
+
+// process represents a started command.
+type process struct {
+	opts   *cmdOpts
+	lines  chan outputLine
+	done   chan struct{}
+	err    error
+	stdout bytes.Buffer
+	stderr bytes.Buffer
+}
+
+func (p *process) EncodeTengoObject() (tengo.Object, error) {
+	return tengoutil.ToImmutableObject(map[string]interface{}{
+		"next_line": p.nextLine,
+		"wait":      p.wait,
+	})
+}
+
+// nextLine waits for the next line printed by the command. It returns nil once
+// the command completes and all lines were read.
+func (p *process) nextLine() *outputLine {
+	if p.lines == nil {
+		return nil
+	}
+	line, ok := <-p.lines
+	if !ok {
+		return nil
+	}
+	return &line
+}
+
+// wait waits for the command to complete. Lines which weren't read using
+// nextLine are discarded. Unless ignore_errors option is enabled, it aborts the
+// script if the command failed.
+func (p *process) wait() cmdResult {
+	result := p.result()
+	if result.Error != nil && !p.opts.IgnoreErrors {
+		panic(result.Error)
 	}
+	return result
+}
 
-	result.StdoutText = stdoutBuffer.String()
-	result.StderrText = stderrBuffer.String()
+// result waits for the command to complete and returns its result, regardless
+// of ignore_errors option.
+func (p *process) result() cmdResult {
+	p.finish()
 
+	result := newResult(p.err)
+	result.StdoutText = p.stdout.String()
+	result.StderrText = p.stderr.String()
 	return result
 }
 
+// finish discards unread lines and waits for the command to complete.
+func (p *process) finish() {
+	if p.lines != nil {
+		for range p.lines {
+		}
+	}
+	<-p.done
+}
+
+type outputLine struct {
+	Text   string `tengo:"text"`
+	Stream string `tengo:"stream"`
+}
+
+// lineWriter splits written data into lines and sends them to the channel.
+// Incomplete lines are kept until more data is written or flush is called.
+type lineWriter struct {
+	stream string
+	lines  chan<- outputLine
+	buffer []byte
+}
+
+func (w *lineWriter) Write(data []byte) (int, error) {
+	w.buffer = append(w.buffer, data...)
+	for {
+		i := bytes.IndexByte(w.buffer, '\n')
+		if i < 0 {
+			break
+		}
+		w.send(w.buffer[:i])
+		w.buffer = w.buffer[i+1:]
+	}
+	return len(data), nil
+}
+
+func (w *lineWriter) flush() {
+	if len(w.buffer) > 0 {
+		w.send(w.buffer)
+		w.buffer = nil
+	}
+}
+
+func (w *lineWriter) send(line []byte) {
+	w.lines <- outputLine{Text: strings.TrimSuffix(string(line), "\r"), Stream: w.stream}
+}
+
+func interruptedError(name string, reason error) error {
+	if reason == context.DeadlineExceeded {
+		return fmt.Errorf("command %q was interrupted, because time limit was exceeded", name)
+	}
+	return fmt.Errorf("command %q was interrupted: %s", name, reason)
+}
+
 type cmdOpts struct {
 	Name         string       `tengo:"name"`
 	Args         []string     `tengo:"args"`
@@ -124,6 +518,17 @@ type cmdOpts struct {
 	StdoutLevel  logger.Level `tengo:"stdout_level"`
 	StderrLevel  logger.Level `tengo:"stderr_level"`
 	IgnoreErrors bool         `tengo:"ignore_errors"`
+	Tag          string       `tengo:"tag"`
+	InheritEnv   bool         `tengo:"inherit_env"`
+	StdinText    string       `tengo:"stdin_text"`
+	StdinFile    string       `tengo:"stdin_file"`
+	// OnLine is called by the Tengo part of the module, native functions
+	// only collect lines for it.
+	OnLine tengo.Object `tengo:"on_line"`
+}
+
+type runAllOpts struct {
+	Concurrency int `tengo:"concurrency"`
 }
 
 type cmdResult struct {
@@ -133,13 +538,25 @@ type cmdResult struct {
 	StderrText string `tengo:"stderr_text"`
 }
 
-func parseLevel(levelName string) logger.Level {
+func newResult(err error) cmdResult {
+	result := cmdResult{Error: err}
+	if err != nil {
+		if e, ok := err.(exec.ExitError); ok {
+			result.ExitCode = e.ExitStatus()
+		} else {
+			result.ExitCode = -1
+		}
+	}
+	return result
+}
+
+func parseLevel(levelName string) (logger.Level, error) {
 	parsed, ok := logger.ParseLevel(levelName)
 	if !ok {
-		panic(fmt.Errorf(
+		return "", fmt.Errorf(
 			"Unknown level %q, use one of: %s, %s, %s, %s, %s, %s, %s, disable",
 			levelName, logger.SpamLevel, logger.DebugLevel, logger.VerboseLevel, logger.InfoLevel, logger.WarnLevel, logger.ErrorLevel, logger.FatalLevel,
-		))
+		)
 	}
-	return parsed
+	return parsed, nil
 }
@@ -1,8 +1,14 @@
 package exec
 
 import (
+	"context"
 	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/d5/tengo/v2"
 	"github.com/g2a-com/cicd/internal/tengoutil"
@@ -14,7 +20,7 @@ import (
 
 func Test_run_runs_command(t *testing.T) {
 	cmd := prepareFakeCmd("stdout", "stderr", 0)
-	mod := New(fakelogger.New())
+	mod := New(context.Background(), fakelogger.New(), resolvePath, Options{})
 	mod.exec = prepareFakeExec(cmd)
 
 	result, err := run(mod, `exec.run("echo", "abc", "123")`)
@@ -32,7 +38,7 @@ func Test_run_runs_command(t *testing.T) {
 func Test_run_prints_logs_at_info_and_error_levels(t *testing.T) {
 	log := fakelogger.New()
 	cmd := prepareFakeCmd("stdout", "stderr", 0)
-	mod := New(log)
+	mod := New(context.Background(), log, resolvePath, Options{})
 	mod.exec = prepareFakeExec(cmd)
 
 	_, _ = run(mod, `exec.run("cmd")`)
@@ -45,7 +51,7 @@ func Test_run_prints_logs_at_info_and_error_levels(t *testing.T) {
 
 func Test_run_aborts_script_on_error(t *testing.T) {
 	cmd := prepareFakeCmd("stdout", "stderr", 1)
-	mod := New(fakelogger.New())
+	mod := New(context.Background(), fakelogger.New(), resolvePath, Options{})
 	mod.exec = prepareFakeExec(cmd)
 
 	_, err := run(mod, `exec.run("cmd")`)
@@ -55,7 +61,7 @@ func Test_run_aborts_script_on_error(t *testing.T) {
 
 func Test_run_silently_runs_command(t *testing.T) {
 	cmd := prepareFakeCmd("stdout", "stderr", 0)
-	mod := New(fakelogger.New())
+	mod := New(context.Background(), fakelogger.New(), resolvePath, Options{})
 	mod.exec = prepareFakeExec(cmd)
 
 	result, err := run(mod, `exec.run_silently("echo", "abc", "123")`)
@@ -73,7 +79,7 @@ func Test_run_silently_runs_command(t *testing.T) {
 func Test_run_silently_prints_logs_at_debug_and_error_levels(t *testing.T) {
 	log := fakelogger.New()
 	cmd := prepareFakeCmd("stdout", "stderr", 0)
-	mod := New(log)
+	mod := New(context.Background(), log, resolvePath, Options{})
 	mod.exec = prepareFakeExec(cmd)
 
 	_, _ = run(mod, `exec.run_silently("cmd")`)
@@ -86,7 +92,7 @@ func Test_run_silently_prints_logs_at_debug_and_error_levels(t *testing.T) {
 
 func Test_run_silently_aborts_script_on_error(t *testing.T) {
 	cmd := prepareFakeCmd("stdout", "stderr", 1)
-	mod := New(fakelogger.New())
+	mod := New(context.Background(), fakelogger.New(), resolvePath, Options{})
 	mod.exec = prepareFakeExec(cmd)
 
 	_, err := run(mod, `exec.run_silently("cmd")`)
@@ -96,7 +102,7 @@ func Test_run_silently_aborts_script_on_error(t *testing.T) {
 
 func Test_command_run_runs_command(t *testing.T) {
 	cmd := prepareFakeCmd("stdout", "stderr", 0)
-	mod := New(fakelogger.New())
+	mod := New(context.Background(), fakelogger.New(), resolvePath, Options{})
 	mod.exec = prepareFakeExec(cmd)
 
 	result, err := run(mod, `exec.command({ name: "echo", args: [ "abc", "123" ] }).run()`)
@@ -114,7 +120,7 @@ func Test_command_run_runs_command(t *testing.T) {
 func Test_command_run_by_default_prints_logs_at_info_and_error_levels(t *testing.T) {
 	log := fakelogger.New()
 	cmd := prepareFakeCmd("stdout", "stderr", 0)
-	mod := New(log)
+	mod := New(context.Background(), log, resolvePath, Options{})
 	mod.exec = prepareFakeExec(cmd)
 
 	_, _ = run(mod, `exec.command({ name: "cmd" }).run()`)
@@ -128,7 +134,7 @@ func Test_command_run_by_default_prints_logs_at_info_and_error_levels(t *testing
 func Test_command_run_prints_logs_at_specified_levels(t *testing.T) {
 	log := fakelogger.New()
 	cmd := prepareFakeCmd("stdout", "stderr", 0)
-	mod := New(log)
+	mod := New(context.Background(), log, resolvePath, Options{})
 	mod.exec = prepareFakeExec(cmd)
 
 	_, _ = run(mod, `exec.command({ name: "cmd", stdout_level: "verbose", stderr_level: "warn" }).run()`)
@@ -141,7 +147,7 @@ func Test_command_run_prints_logs_at_specified_levels(t *testing.T) {
 
 func Test_command_run_by_default_aborts_script_on_error(t *testing.T) {
 	cmd := prepareFakeCmd("stdout", "stderr", 1)
-	mod := New(fakelogger.New())
+	mod := New(context.Background(), fakelogger.New(), resolvePath, Options{})
 	mod.exec = prepareFakeExec(cmd)
 
 	_, err := run(mod, `exec.command({ name: "cmd" }).run()`)
@@ -151,7 +157,7 @@ func Test_command_run_by_default_aborts_script_on_error(t *testing.T) {
 
 func Test_command_run_returns_result_with_error_when_ignore_errors_option_is_enabled(t *testing.T) {
 	cmd := prepareFakeCmd("stdout", "stderr", 1)
-	mod := New(fakelogger.New())
+	mod := New(context.Background(), fakelogger.New(), resolvePath, Options{})
 	mod.exec = prepareFakeExec(cmd)
 
 	result, err := run(mod, `exec.command({ name: "cmd", ignore_errors: true }).run()`)
@@ -171,7 +177,7 @@ func Test_command_run_returns_result_with_minus_one_exit_code_when_command_canno
 			return []byte{}, []byte{}, errors.New("command not found")
 		},
 	}}
-	mod := New(fakelogger.New())
+	mod := New(context.Background(), fakelogger.New(), resolvePath, Options{})
 	mod.exec = prepareFakeExec(cmd)
 
 	result, err := run(mod, `exec.command({ name: "cmd", ignore_errors: true }).run()`)
@@ -187,7 +193,7 @@ func Test_command_run_returns_result_with_minus_one_exit_code_when_command_canno
 
 func Test_command_run_runs_command_in_specified_directory(t *testing.T) {
 	cmd := prepareFakeCmd("stdout", "stderr", 0)
-	mod := New(fakelogger.New())
+	mod := New(context.Background(), fakelogger.New(), resolvePath, Options{})
 	mod.exec = prepareFakeExec(cmd)
 
 	_, err := run(mod, `exec.command({ name: "cmd", dir: "/some/dir" }).run()`)
@@ -198,7 +204,7 @@ func Test_command_run_runs_command_in_specified_directory(t *testing.T) {
 
 func Test_command_run_runs_command_with_specified_env_variables(t *testing.T) {
 	cmd := prepareFakeCmd("stdout", "stderr", 0)
-	mod := New(fakelogger.New())
+	mod := New(context.Background(), fakelogger.New(), resolvePath, Options{})
 	mod.exec = prepareFakeExec(cmd)
 
 	_, err := run(mod, `exec.command({ name: "cmd", env: ["FOO=bar", "egg=spam"] }).run()`)
@@ -207,9 +213,416 @@ func Test_command_run_runs_command_with_specified_env_variables(t *testing.T) {
 	assert.Equal(t, []string{"FOO=bar", "egg=spam"}, cmd.Env)
 }
 
+func Test_command_run_merges_env_variables_with_parent_environment_when_inherit_env_option_is_enabled(t *testing.T) {
+	t.Setenv("FOO", "parent")
+	t.Setenv("EGG", "parent")
+	cmd := prepareFakeCmd("stdout", "stderr", 0)
+	mod := New(context.Background(), fakelogger.New(), resolvePath, Options{})
+	mod.exec = prepareFakeExec(cmd)
+
+	_, err := run(mod, `exec.command({ name: "cmd", env: ["FOO=bar"], inherit_env: true }).run()`)
+
+	assert.NoError(t, err)
+	assert.Contains(t, cmd.Env, "EGG=parent")
+	assert.Equal(t, "FOO=bar", cmd.Env[len(cmd.Env)-1])
+}
+
+func Test_command_run_passes_stdin_text_to_command(t *testing.T) {
+	var stdin []byte
+	cmd := &testingexec.FakeCmd{}
+	cmd.RunScript = []testingexec.FakeAction{
+		func() ([]byte, []byte, error) {
+			stdin, _ = ioutil.ReadAll(cmd.Stdin)
+			return nil, nil, nil
+		},
+	}
+	mod := New(context.Background(), fakelogger.New(), resolvePath, Options{})
+	mod.exec = prepareFakeExec(cmd)
+
+	_, err := run(mod, `exec.command({ name: "cmd", stdin_text: "some input" }).run()`)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "some input", string(stdin))
+}
+
+func Test_command_run_passes_stdin_file_to_command(t *testing.T) {
+	dir := t.TempDir()
+	_ = ioutil.WriteFile(filepath.Join(dir, "input.txt"), []byte("file input"), 0o644)
+	var stdin []byte
+	cmd := &testingexec.FakeCmd{}
+	cmd.RunScript = []testingexec.FakeAction{
+		func() ([]byte, []byte, error) {
+			stdin, _ = ioutil.ReadAll(cmd.Stdin)
+			return nil, nil, nil
+		},
+	}
+	mod := New(context.Background(), fakelogger.New(), func(path string) (string, error) {
+		return filepath.Join(dir, path), nil
+	}, Options{})
+	mod.exec = prepareFakeExec(cmd)
+
+	_, err := run(mod, `exec.command({ name: "cmd", stdin_file: "input.txt" }).run()`)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "file input", string(stdin))
+}
+
+func Test_command_run_fails_when_stdin_file_cannot_be_resolved(t *testing.T) {
+	cmd := prepareFakeCmd("stdout", "stderr", 0)
+	mod := New(context.Background(), fakelogger.New(), func(path string) (string, error) {
+		return "", errors.New("path is outside of the project directory")
+	}, Options{})
+	mod.exec = prepareFakeExec(cmd)
+
+	_, err := run(mod, `exec.command({ name: "cmd", stdin_file: "../input.txt" }).run()`)
+
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "path is outside of the project directory")
+	}
+	assert.Equal(t, 0, cmd.RunCalls)
+}
+
+func Test_command_run_fails_when_both_stdin_text_and_stdin_file_are_specified(t *testing.T) {
+	cmd := prepareFakeCmd("stdout", "stderr", 0)
+	mod := New(context.Background(), fakelogger.New(), resolvePath, Options{})
+	mod.exec = prepareFakeExec(cmd)
+
+	_, err := run(mod, `exec.command({ name: "cmd", stdin_text: "abc", stdin_file: "input.txt" }).run()`)
+
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "options stdin_text and stdin_file cannot be used together")
+	}
+}
+
+func Test_command_run_calls_on_line_for_each_printed_line(t *testing.T) {
+	cmd := prepareFakeCmd("first\r\nsecond\nthird", "warning\n", 0)
+	mod := New(context.Background(), fakelogger.New(), resolvePath, Options{})
+	mod.exec = prepareFakeExec(cmd)
+
+	result, err := run(mod, `func() {
+		lines := []
+		result := exec.command({
+			name: "cmd",
+			on_line: func(text, stream) { lines = append(lines, stream + ": " + text) }
+		}).run()
+		return { lines: lines, stdout_text: result.stdout_text }
+	}()`)
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		// Incomplete lines are passed once the command completes
+		"lines":       []interface{}{"stdout: first", "stdout: second", "stderr: warning", "stdout: third"},
+		"stdout_text": "first\r\nsecond\nthird",
+	}, result)
+}
+
+func Test_command_run_with_on_line_aborts_script_on_error(t *testing.T) {
+	cmd := prepareFakeCmd("line\n", "", 1)
+	mod := New(context.Background(), fakelogger.New(), resolvePath, Options{})
+	mod.exec = prepareFakeExec(cmd)
+
+	_, err := run(mod, `exec.command({ name: "cmd", on_line: func(text, stream) {} }).run()`)
+
+	assert.Error(t, err)
+}
+
+func Test_shell_runs_script_using_sh_with_errexit_option(t *testing.T) {
+	cmd := prepareFakeCmd("stdout", "stderr", 0)
+	mod := New(context.Background(), fakelogger.New(), resolvePath, Options{})
+	mod.exec = prepareFakeExec(cmd)
+
+	result, err := run(mod, `exec.shell("echo abc | tr a-z A-Z", { dir: "/some/dir", name: "ignored" })`)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"sh", "-e", "-c", "echo abc | tr a-z A-Z"}, cmd.Argv)
+	assert.Equal(t, []string{"/some/dir"}, cmd.Dirs)
+	assert.Equal(t, map[string]interface{}{
+		"stdout_text": "stdout",
+		"stderr_text": "stderr",
+		"error":       nil,
+		"exit_code":   0,
+	}, result)
+}
+
+func Test_shell_aborts_script_on_error(t *testing.T) {
+	cmd := prepareFakeCmd("stdout", "stderr", 1)
+	mod := New(context.Background(), fakelogger.New(), resolvePath, Options{})
+	mod.exec = prepareFakeExec(cmd)
+
+	_, err := run(mod, `exec.shell("false")`)
+
+	assert.Error(t, err)
+}
+
+func Test_cleanup_stops_commands_which_output_was_not_read(t *testing.T) {
+	log := fakelogger.New()
+	cmd := &testingexec.FakeCmd{RunScript: []testingexec.FakeAction{
+		func() ([]byte, []byte, error) {
+			time.Sleep(100 * time.Millisecond)
+			return []byte(strings.Repeat("line\n", 1000)), nil, nil
+		},
+	}}
+	mod := New(context.Background(), log, resolvePath, Options{})
+	mod.exec = prepareFakeExec(cmd)
+
+	_, err := run(mod, `exec.command({ name: "cmd", on_line: func(text, stream) { text() } }).run()`)
+
+	assert.Error(t, err)
+	assert.NoError(t, mod.Cleanup())
+	assert.Contains(t, log.Messages, fakelogger.Message{
+		Level: "warn", Method: "Printf", Args: []interface{}{"Stopping command %q...", "cmd"},
+	})
+}
+
+func Test_command_start_returns_process_which_can_be_awaited(t *testing.T) {
+	cmd := prepareFakeCmd("stdout", "stderr", 0)
+	mod := New(context.Background(), fakelogger.New(), resolvePath, Options{})
+	mod.exec = prepareFakeExec(cmd)
+
+	result, err := run(mod, `func() {
+		process := exec.command({ name: "echo", args: ["abc"] }).start()
+		return process.wait()
+	}()`)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"echo", "abc"}, cmd.Argv)
+	assert.Equal(t, map[string]interface{}{
+		"stdout_text": "stdout",
+		"stderr_text": "stderr",
+		"error":       nil,
+		"exit_code":   0,
+	}, result)
+}
+
+func Test_command_start_with_on_line_calls_it_while_waiting_for_process(t *testing.T) {
+	cmd := prepareFakeCmd("first\nsecond\n", "", 0)
+	mod := New(context.Background(), fakelogger.New(), resolvePath, Options{})
+	mod.exec = prepareFakeExec(cmd)
+
+	result, err := run(mod, `func() {
+		lines := []
+		process := exec.command({ name: "cmd", on_line: func(text, stream) { lines = append(lines, text) } }).start()
+		process.wait()
+		return lines
+	}()`)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{"first", "second"}, result)
+}
+
+func Test_command_start_aborts_script_on_error_when_process_is_awaited(t *testing.T) {
+	cmd := prepareFakeCmd("stdout", "stderr", 1)
+	mod := New(context.Background(), fakelogger.New(), resolvePath, Options{})
+	mod.exec = prepareFakeExec(cmd)
+
+	_, err := run(mod, `exec.command({ name: "cmd" }).start().wait()`)
+
+	assert.Error(t, err)
+}
+
+func Test_run_all_runs_commands_and_returns_results_in_order(t *testing.T) {
+	first := prepareFakeCmd("first", "", 0)
+	second := prepareFakeCmd("second", "", 0)
+	mod := New(context.Background(), fakelogger.New(), resolvePath, Options{})
+	mod.exec = prepareFakeExec(first, second)
+
+	result, err := run(mod, `exec.run_all([
+		exec.command({ name: "cmd", args: ["1"] }),
+		exec.command({ name: "cmd", args: ["2"] })
+	])`)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"cmd", "1"}, first.Argv)
+	assert.Equal(t, []string{"cmd", "2"}, second.Argv)
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"stdout_text": "first", "stderr_text": "", "error": nil, "exit_code": 0},
+		map[string]interface{}{"stdout_text": "second", "stderr_text": "", "error": nil, "exit_code": 0},
+	}, result)
+}
+
+func Test_run_all_tags_output_of_each_command(t *testing.T) {
+	log := fakelogger.New()
+	first := prepareFakeCmd("first", "first error", 0)
+	second := prepareFakeCmd("second", "second error", 0)
+	mod := New(context.Background(), log.WithTags("service"), resolvePath, Options{})
+	mod.exec = prepareFakeExec(first, second)
+
+	_, err := run(mod, `exec.run_all([
+		exec.command({ name: "cmd" }),
+		exec.command({ name: "cmd", tag: "custom" })
+	])`)
+
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []fakelogger.Message{
+		{Level: "info", Tags: []string{"service", "cmd#1"}, Method: "Write", Args: []interface{}{[]byte("first")}},
+		{Level: "error", Tags: []string{"service", "cmd#1"}, Method: "Write", Args: []interface{}{[]byte("first error")}},
+		{Level: "info", Tags: []string{"service", "custom"}, Method: "Write", Args: []interface{}{[]byte("second")}},
+		{Level: "error", Tags: []string{"service", "custom"}, Method: "Write", Args: []interface{}{[]byte("second error")}},
+	}, log.Messages)
+}
+
+func Test_run_all_runs_at_most_specified_number_of_commands_at_once(t *testing.T) {
+	var running, maxRunning int32
+	commands := make([]*testingexec.FakeCmd, 5)
+	for i := range commands {
+		commands[i] = &testingexec.FakeCmd{RunScript: []testingexec.FakeAction{
+			func() ([]byte, []byte, error) {
+				n := atomic.AddInt32(&running, 1)
+				for {
+					max := atomic.LoadInt32(&maxRunning)
+					if n <= max || atomic.CompareAndSwapInt32(&maxRunning, max, n) {
+						break
+					}
+				}
+				time.Sleep(10 * time.Millisecond)
+				atomic.AddInt32(&running, -1)
+				return nil, nil, nil
+			},
+		}}
+	}
+	mod := New(context.Background(), fakelogger.New(), resolvePath, Options{})
+	mod.exec = prepareFakeExec(commands...)
+
+	_, err := run(mod, `func() {
+		cmds := []
+		for i := 0; i < 5; i++ {
+			cmds = append(cmds, exec.command({ name: "cmd" }))
+		}
+		return exec.run_all(cmds, { concurrency: 2 })
+	}()`)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), maxRunning)
+	for _, cmd := range commands {
+		assert.Equal(t, 1, cmd.RunCalls)
+	}
+}
+
+func Test_run_all_runs_all_commands_and_aborts_script_with_aggregated_failures(t *testing.T) {
+	first := prepareFakeCmd("", "", 1)
+	second := prepareFakeCmd("", "", 0)
+	third := prepareFakeCmd("", "", 2)
+	fourth := prepareFakeCmd("", "", 3)
+	mod := New(context.Background(), fakelogger.New(), resolvePath, Options{})
+	mod.exec = prepareFakeExec(first, second, third, fourth)
+
+	_, err := run(mod, `exec.run_all([
+		exec.command({ name: "build" }),
+		exec.command({ name: "build" }),
+		exec.command({ name: "push", tag: "pusher" }),
+		exec.command({ name: "push", ignore_errors: true })
+	], { concurrency: 1 })`)
+
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "2 of 4 commands failed: build#1: exit code != 0; pusher: exit code != 0")
+	}
+	assert.Equal(t, 1, fourth.RunCalls)
+}
+
+func Test_run_all_rejects_commands_with_on_line_option(t *testing.T) {
+	mod := New(context.Background(), fakelogger.New(), resolvePath, Options{})
+	mod.exec = prepareFakeExec()
+
+	_, err := run(mod, `exec.run_all([exec.command({ name: "cmd", on_line: func(text, stream) {} })])`)
+
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "expected command created using command function without on_line option at index 0, found map")
+	}
+}
+
+func Test_command_run_rejects_commands_which_are_not_allowed(t *testing.T) {
+	cmd := prepareFakeCmd("stdout", "stderr", 0)
+	mod := New(context.Background(), fakelogger.New(), resolvePath, Options{
+		RestrictCommands: true,
+		AllowedCommands:  []string{"docker", "git"},
+		Owner:            `builder "test" defined in file.yaml:1`,
+	})
+	mod.exec = prepareFakeExec(cmd)
+
+	_, err := run(mod, `exec.command({ name: "curl", ignore_errors: true }).run()`)
+
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), `command "curl" is not allowed for builder "test" defined in file.yaml:1, allowed commands: docker, git`)
+	}
+	assert.Equal(t, 0, cmd.RunCalls)
+}
+
+func Test_command_run_runs_allowed_commands(t *testing.T) {
+	cmd := prepareFakeCmd("stdout", "stderr", 0)
+	mod := New(context.Background(), fakelogger.New(), resolvePath, Options{
+		RestrictCommands: true,
+		AllowedCommands:  []string{"docker"},
+	})
+	mod.exec = prepareFakeExec(cmd)
+
+	_, err := run(mod, `exec.run("docker", "build", ".")`)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, cmd.RunCalls)
+}
+
+func Test_run_all_reports_commands_which_are_not_allowed_as_failures(t *testing.T) {
+	cmd := prepareFakeCmd("stdout", "stderr", 0)
+	mod := New(context.Background(), fakelogger.New(), resolvePath, Options{
+		RestrictCommands: true,
+		AllowedCommands:  []string{"docker"},
+		Owner:            `builder "test"`,
+	})
+	mod.exec = prepareFakeExec(cmd)
+
+	_, err := run(mod, `exec.run_all([exec.command({ name: "docker" }), exec.command({ name: "sh" })])`)
+
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), `1 of 2 commands failed: sh#2: command "sh" is not allowed for builder "test", allowed commands: docker`)
+	}
+	assert.Equal(t, 1, cmd.RunCalls)
+}
+
+func Test_run_does_not_start_command_after_context_is_done(t *testing.T) {
+	cmd := prepareFakeCmd("stdout", "stderr", 0)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	mod := New(ctx, fakelogger.New(), resolvePath, Options{})
+	mod.exec = prepareFakeExec(cmd)
+
+	_, err := run(mod, `exec.run("cmd")`)
+
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), `command "cmd" was interrupted: context canceled`)
+	}
+	assert.Equal(t, 0, cmd.RunCalls)
+}
+
+func Test_run_interrupts_command_when_time_limit_is_exceeded(t *testing.T) {
+	log := fakelogger.New()
+	cmd := &testingexec.FakeCmd{RunScript: []testingexec.FakeAction{
+		func() ([]byte, []byte, error) {
+			time.Sleep(100 * time.Millisecond)
+			return nil, nil, nil
+		},
+	}}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	mod := New(ctx, log, resolvePath, Options{})
+	mod.exec = prepareFakeExec(cmd)
+
+	result, err := run(mod, `exec.command({ name: "cmd", ignore_errors: true }).run()`)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "command \"cmd\" was interrupted, because time limit was exceeded", result.(map[string]interface{})["error"])
+	assert.Equal(t, []fakelogger.Message{
+		{Level: "warn", Method: "Printf", Args: []interface{}{"Stopping command %q...", "cmd"}},
+	}, log.Messages)
+}
+
+func resolvePath(path string) (string, error) {
+	return path, nil
+}
+
 func run(m *module, code string) (result interface{}, err error) {
 	modules := tengo.NewModuleMap()
 	modules.Add("exec", m)
+	modules.Add(NativeModuleName, m)
 	script := tengo.NewScript([]byte(`exec := import("exec"); result := ` + code))
 	script.SetImports(modules)
 	compiled, err := script.Run()
@@ -222,6 +635,7 @@ func run(m *module, code string) (result interface{}, err error) {
 func prepareFakeExec(commands ...*testingexec.FakeCmd) *testingexec.FakeExec {
 	actions := make([]testingexec.FakeCommandAction, len(commands))
 	for i := range actions {
+		i := i
 		actions[i] = func(cmd string, args ...string) exec.Cmd {
 			return testingexec.InitFakeCmd(commands[i], cmd, args...)
 		}
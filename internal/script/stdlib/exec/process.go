@@ -0,0 +1,177 @@
+package exec
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	osexec "os/exec"
+	"sync"
+	"time"
+
+	"k8s.io/utils/exec"
+)
+
+// gracePeriod is a time given to stopped commands to exit, after which they
+// are killed.
+const gracePeriod = 5 * time.Second
+
+// processExec implements exec.Interface. Unlike exec.New(), it starts commands
+// in their own process groups, so stopping a command stops processes started
+// by it as well (e.g. commands run by a shell script).
+type processExec struct{}
+
+var _ exec.Interface = processExec{}
+
+// NewProcessExec returns exec.Interface which starts commands in their own
+// process groups, like the one used by the module.
+func NewProcessExec() exec.Interface {
+	return processExec{}
+}
+
+func (processExec) Command(cmd string, args ...string) exec.Cmd {
+	return newProcessCmd(osexec.Command(cmd, args...))
+}
+
+func (processExec) CommandContext(ctx context.Context, cmd string, args ...string) exec.Cmd {
+	return newProcessCmd(osexec.CommandContext(ctx, cmd, args...))
+}
+
+func (processExec) LookPath(file string) (string, error) {
+	return osexec.LookPath(file)
+}
+
+// running contains started commands, so they can be killed when the program
+// has to exit immediately.
+var running = struct {
+	sync.Mutex
+	cmds map[*processCmd]bool
+}{cmds: map[*processCmd]bool{}}
+
+// KillAll kills process groups of all running commands started using the exec
+// returned by NewProcessExec. It's meant to be called right before the program
+// exits without waiting for the commands.
+func KillAll() {
+	running.Lock()
+	defer running.Unlock()
+
+	for c := range running.cmds {
+		killProcessGroup(c.cmd.Process)
+	}
+}
+
+type processCmd struct {
+	cmd *osexec.Cmd
+
+	mutex   sync.Mutex
+	stopped bool
+	done    chan struct{}
+}
+
+var _ exec.Cmd = &processCmd{}
+
+func newProcessCmd(cmd *osexec.Cmd) *processCmd {
+	setProcessGroup(cmd)
+	return &processCmd{cmd: cmd, done: make(chan struct{})}
+}
+
+func (c *processCmd) SetDir(dir string)       { c.cmd.Dir = dir }
+func (c *processCmd) SetEnv(env []string)     { c.cmd.Env = env }
+func (c *processCmd) SetStdin(in io.Reader)   { c.cmd.Stdin = in }
+func (c *processCmd) SetStdout(out io.Writer) { c.cmd.Stdout = out }
+func (c *processCmd) SetStderr(out io.Writer) { c.cmd.Stderr = out }
+
+func (c *processCmd) StdoutPipe() (io.ReadCloser, error) {
+	r, err := c.cmd.StdoutPipe()
+	return r, handleError(err)
+}
+
+func (c *processCmd) StderrPipe() (io.ReadCloser, error) {
+	r, err := c.cmd.StderrPipe()
+	return r, handleError(err)
+}
+
+func (c *processCmd) Start() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.stopped {
+		return errors.New("command was stopped before it started")
+	}
+	err := c.cmd.Start()
+	if err == nil {
+		running.Lock()
+		running.cmds[c] = true
+		running.Unlock()
+	}
+	return handleError(err)
+}
+
+func (c *processCmd) Wait() error {
+	defer close(c.done)
+	err := c.cmd.Wait()
+
+	running.Lock()
+	delete(running.cmds, c)
+	running.Unlock()
+
+	return handleError(err)
+}
+
+func (c *processCmd) Run() error {
+	err := c.Start()
+	if err != nil {
+		return err
+	}
+	return c.Wait()
+}
+
+func (c *processCmd) Output() ([]byte, error) {
+	var stdout bytes.Buffer
+	c.cmd.Stdout = &stdout
+	err := c.Run()
+	return stdout.Bytes(), err
+}
+
+func (c *processCmd) CombinedOutput() ([]byte, error) {
+	var output bytes.Buffer
+	c.cmd.Stdout = &output
+	c.cmd.Stderr = &output
+	err := c.Run()
+	return output.Bytes(), err
+}
+
+// Stop asks the process group of the command to terminate, and kills it if the
+// command doesn't exit within the grace period. Commands which weren't started
+// yet are never started.
+func (c *processCmd) Stop() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.stopped = true
+	if c.cmd.Process == nil {
+		return
+	}
+
+	terminateProcessGroup(c.cmd.Process)
+	go func() {
+		select {
+		case <-c.done:
+		case <-time.After(gracePeriod):
+			killProcessGroup(c.cmd.Process)
+		}
+	}()
+}
+
+// handleError converts errors the same way as exec.New() does, so exit codes
+// can be read using exec.ExitError interface.
+func handleError(err error) error {
+	var exitErr *osexec.ExitError
+	if errors.As(err, &exitErr) {
+		return &exec.ExitErrorWrapper{ExitError: exitErr}
+	}
+	if errors.Is(err, osexec.ErrNotFound) {
+		return exec.ErrExecutableNotFound
+	}
+	return err
+}
@@ -0,0 +1,72 @@
+//go:build !windows
+// +build !windows
+
+package exec
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/exec"
+)
+
+func Test_stopping_command_stops_processes_started_by_it(t *testing.T) {
+	var stdout bytes.Buffer
+	cmd := processExec{}.Command("sh", "-c", "sleep 30; echo done")
+	cmd.SetStdout(&stdout)
+	done := make(chan error, 1)
+
+	go func() {
+		done <- cmd.Run()
+	}()
+	time.Sleep(100 * time.Millisecond)
+	cmd.Stop()
+
+	select {
+	case err := <-done:
+		assert.Error(t, err)
+		assert.Empty(t, stdout.String())
+	case <-time.After(gracePeriod):
+		t.Fatal("command didn't stop")
+	}
+}
+
+func Test_kill_all_kills_processes_of_running_commands(t *testing.T) {
+	var stdout bytes.Buffer
+	cmd := processExec{}.Command("sh", "-c", "trap '' TERM; sleep 30; echo done")
+	cmd.SetStdout(&stdout)
+	done := make(chan error, 1)
+
+	go func() {
+		done <- cmd.Run()
+	}()
+	time.Sleep(100 * time.Millisecond)
+	KillAll()
+
+	select {
+	case err := <-done:
+		assert.Error(t, err)
+		assert.Empty(t, stdout.String())
+	case <-time.After(gracePeriod):
+		t.Fatal("command wasn't killed")
+	}
+}
+
+func Test_stopped_command_is_never_started(t *testing.T) {
+	cmd := processExec{}.Command("true")
+
+	cmd.Stop()
+	err := cmd.Run()
+
+	assert.Error(t, err)
+}
+
+func Test_exit_code_of_command_is_returned(t *testing.T) {
+	err := processExec{}.Command("sh", "-c", "exit 3").Run()
+
+	if assert.Implements(t, (*exec.ExitError)(nil), err) {
+		assert.Equal(t, 3, err.(exec.ExitError).ExitStatus())
+	}
+}
@@ -0,0 +1,22 @@
+//go:build !windows
+// +build !windows
+
+package exec
+
+import (
+	"os"
+	osexec "os/exec"
+	"syscall"
+)
+
+func setProcessGroup(cmd *osexec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+}
+
+func terminateProcessGroup(process *os.Process) {
+	_ = syscall.Kill(-process.Pid, syscall.SIGTERM)
+}
+
+func killProcessGroup(process *os.Process) {
+	_ = syscall.Kill(-process.Pid, syscall.SIGKILL)
+}
@@ -0,0 +1,21 @@
+//go:build windows
+// +build windows
+
+package exec
+
+import (
+	"os"
+	osexec "os/exec"
+)
+
+// Windows doesn't support signals, so stopped commands are killed immediately.
+
+func setProcessGroup(cmd *osexec.Cmd) {}
+
+func terminateProcessGroup(process *os.Process) {
+	_ = process.Kill()
+}
+
+func killProcessGroup(process *os.Process) {
+	_ = process.Kill()
+}
@@ -0,0 +1,55 @@
+package exec
+
+// source is the Tengo part of the module. Functions implemented in Go cannot
+// call Tengo functions, so commands with on_line callback are run here, while
+// everything else is delegated to the native module.
+const source = `
+native := import("exec:native")
+
+wait_with_callback := func(process, on_line) {
+	for line := process.next_line(); !is_undefined(line); line = process.next_line() {
+		on_line(line.text, line.stream)
+	}
+	return process.wait()
+}
+
+command := func(opts) {
+	cmd := native.command(opts)
+	if is_undefined(opts.on_line) {
+		return cmd
+	}
+	return {
+		run: func() {
+			return wait_with_callback(cmd.start(), opts.on_line)
+		},
+		start: func() {
+			process := cmd.start()
+			return {
+				wait: func() {
+					return wait_with_callback(process, opts.on_line)
+				}
+			}
+		}
+	}
+}
+
+shell := func(script, ...opts) {
+	cmd_opts := {}
+	if len(opts) > 0 {
+		for key, value in opts[0] {
+			cmd_opts[key] = value
+		}
+	}
+	cmd_opts.name = "sh"
+	cmd_opts.args = ["-e", "-c", script]
+	return command(cmd_opts).run()
+}
+
+export {
+	run: native.run,
+	run_silently: native.run_silently,
+	run_all: native.run_all,
+	command: command,
+	shell: shell
+}
+`
@@ -0,0 +1,229 @@
+package fs
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/g2a-com/cicd/internal/tengoutil"
+	logger "github.com/g2a-com/klio-logger-go/v2"
+)
+
+// Options configures directories used by the module.
+type Options struct {
+	// ProjectDir is a directory which cannot be escaped, unless
+	// AllowOutsideProject is set.
+	ProjectDir string
+	// WorkingDir is a directory against which relative paths are resolved.
+	// Defaults to ProjectDir.
+	WorkingDir string
+	// AllowOutsideProject disables checking whether paths are within the
+	// ProjectDir.
+	AllowOutsideProject bool
+}
+
+type module struct {
+	opts     Options
+	logger   logger.Logger
+	tempDirs []string
+}
+
+func New(logger logger.Logger, opts Options) *module {
+	if opts.WorkingDir == "" {
+		opts.WorkingDir = opts.ProjectDir
+	}
+	return &module{
+		opts:   opts,
+		logger: logger,
+	}
+}
+
+func (m *module) Import(name string) (interface{}, error) {
+	return tengoutil.ToImmutableObject(map[string]interface{}{
+		"__module_name__": name,
+		"read_file":       m.readFile,
+		"write_file":      m.writeFile,
+		"glob":            m.glob,
+		"exists":          m.exists,
+		"mkdir":           m.mkdir,
+		"temp_dir":        m.tempDir,
+		"remove":          m.remove,
+	})
+}
+
+// Cleanup removes temporary directories created by the script.
+func (m *module) Cleanup() error {
+	for _, dir := range m.tempDirs {
+		err := os.RemoveAll(dir)
+		if err != nil {
+			return err
+		}
+	}
+	m.tempDirs = nil
+	return nil
+}
+
+func (m *module) readFile(path string) string {
+	path = m.resolve(path)
+	m.logger.WithLevel(logger.DebugLevel).Printf("Reading file %s", path)
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		panic(err)
+	}
+	return string(data)
+}
+
+func (m *module) writeFile(path string, content interface{}) {
+	path = m.resolve(path)
+	m.logger.WithLevel(logger.DebugLevel).Printf("Writing file %s", path)
+
+	var data []byte
+	switch c := content.(type) {
+	case string:
+		data = []byte(c)
+	case []byte:
+		data = c
+	default:
+		panic(fmt.Errorf("content of the file %s must be a string or bytes, got %T", path, content))
+	}
+
+	err := ioutil.WriteFile(path, data, 0644)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func (m *module) glob(pattern string) []string {
+	absPattern := m.resolve(pattern)
+
+	matches, err := filepath.Glob(absPattern)
+	if err != nil {
+		panic(err)
+	}
+
+	results := []string{}
+	for _, match := range matches {
+		if !m.isAllowed(match) {
+			continue
+		}
+		// Return paths in the same form as the pattern
+		if !filepath.IsAbs(pattern) {
+			match, err = filepath.Rel(m.opts.WorkingDir, match)
+			if err != nil {
+				panic(err)
+			}
+		}
+		results = append(results, match)
+	}
+	return results
+}
+
+func (m *module) exists(path string) bool {
+	_, err := os.Stat(m.resolve(path))
+	if err == nil {
+		return true
+	}
+	if os.IsNotExist(err) {
+		return false
+	}
+	panic(err)
+}
+
+func (m *module) mkdir(path string) {
+	path = m.resolve(path)
+	m.logger.WithLevel(logger.DebugLevel).Printf("Creating directory %s", path)
+
+	err := os.MkdirAll(path, 0755)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func (m *module) tempDir() string {
+	dir, err := ioutil.TempDir("", "cicd-")
+	if err != nil {
+		panic(err)
+	}
+	m.logger.WithLevel(logger.DebugLevel).Printf("Created temporary directory %s", dir)
+
+	m.tempDirs = append(m.tempDirs, dir)
+	return dir
+}
+
+func (m *module) remove(path string) {
+	path = m.resolve(path)
+	if path == m.opts.ProjectDir {
+		panic(fmt.Errorf("cannot remove project directory %s", path))
+	}
+	m.logger.WithLevel(logger.DebugLevel).Printf("Removing %s", path)
+
+	err := os.RemoveAll(path)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// Resolve returns absolute path for the specified path. It returns error if
+// the path is outside of the project directory (and temporary directories
+// created by the script).
+func (m *module) Resolve(path string) (string, error) {
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(m.opts.WorkingDir, path)
+	}
+	path = filepath.Clean(path)
+
+	if !m.isAllowed(path) {
+		return "", fmt.Errorf("path %s is outside of the project directory %s", path, m.opts.ProjectDir)
+	}
+	return path, nil
+}
+
+func (m *module) resolve(path string) string {
+	path, err := m.Resolve(path)
+	if err != nil {
+		panic(err)
+	}
+	return path
+}
+
+func (m *module) isAllowed(path string) bool {
+	if m.opts.AllowOutsideProject {
+		return true
+	}
+
+	path = evalSymlinks(path)
+	for _, dir := range append([]string{m.opts.ProjectDir}, m.tempDirs...) {
+		if isWithin(evalSymlinks(dir), path) {
+			return true
+		}
+	}
+	return false
+}
+
+// evalSymlinks resolves symlinks in the longest existing part of the path.
+func evalSymlinks(path string) string {
+	rest := ""
+	for {
+		resolved, err := filepath.EvalSymlinks(path)
+		if err == nil {
+			return filepath.Join(resolved, rest)
+		}
+		parent := filepath.Dir(path)
+		if parent == path {
+			return filepath.Join(path, rest)
+		}
+		rest = filepath.Join(filepath.Base(path), rest)
+		path = parent
+	}
+}
+
+func isWithin(dir string, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	if err != nil {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
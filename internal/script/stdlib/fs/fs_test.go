@@ -0,0 +1,173 @@
+package fs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/d5/tengo/v2"
+	"github.com/g2a-com/cicd/internal/tengoutil"
+	fakelogger "github.com/g2a-com/cicd/internal/utils/fake_logger"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_read_file_reads_file_relative_to_working_dir(t *testing.T) {
+	dir := t.TempDir()
+	_ = os.WriteFile(filepath.Join(dir, "file.txt"), []byte("content"), 0644)
+	mod := New(fakelogger.New(), Options{ProjectDir: dir})
+
+	result, err := run(mod, `fs.read_file("file.txt")`)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "content", result)
+}
+
+func Test_read_file_aborts_script_when_file_does_not_exist(t *testing.T) {
+	mod := New(fakelogger.New(), Options{ProjectDir: t.TempDir()})
+
+	_, err := run(mod, `fs.read_file("missing.txt")`)
+
+	assert.Error(t, err)
+}
+
+func Test_write_file_writes_strings_and_bytes(t *testing.T) {
+	dir := t.TempDir()
+	mod := New(fakelogger.New(), Options{ProjectDir: dir})
+
+	_, err := run(mod, `fs.write_file("a.txt", "string") || fs.write_file("b.txt", bytes("bytes"))`)
+
+	assert.NoError(t, err)
+	a, _ := os.ReadFile(filepath.Join(dir, "a.txt"))
+	b, _ := os.ReadFile(filepath.Join(dir, "b.txt"))
+	assert.Equal(t, "string", string(a))
+	assert.Equal(t, "bytes", string(b))
+}
+
+func Test_glob_returns_paths_relative_to_working_dir(t *testing.T) {
+	projectDir := t.TempDir()
+	workingDir := filepath.Join(projectDir, "service")
+	_ = os.MkdirAll(filepath.Join(workingDir, "dist"), 0755)
+	_ = os.WriteFile(filepath.Join(workingDir, "dist", "a.tgz"), nil, 0644)
+	_ = os.WriteFile(filepath.Join(workingDir, "dist", "b.tgz"), nil, 0644)
+	_ = os.WriteFile(filepath.Join(workingDir, "dist", "c.txt"), nil, 0644)
+	mod := New(fakelogger.New(), Options{ProjectDir: projectDir, WorkingDir: workingDir})
+
+	result, err := run(mod, `fs.glob("dist/*.tgz")`)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{"dist/a.tgz", "dist/b.tgz"}, result)
+}
+
+func Test_exists_checks_whether_file_exists(t *testing.T) {
+	dir := t.TempDir()
+	_ = os.WriteFile(filepath.Join(dir, "file.txt"), nil, 0644)
+	mod := New(fakelogger.New(), Options{ProjectDir: dir})
+
+	result, err := run(mod, `[fs.exists("file.txt"), fs.exists("missing.txt")]`)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{true, false}, result)
+}
+
+func Test_mkdir_creates_nested_directories(t *testing.T) {
+	dir := t.TempDir()
+	mod := New(fakelogger.New(), Options{ProjectDir: dir})
+
+	_, err := run(mod, `fs.mkdir("a/b/c")`)
+
+	assert.NoError(t, err)
+	assert.DirExists(t, filepath.Join(dir, "a", "b", "c"))
+}
+
+func Test_remove_removes_directories_recursively(t *testing.T) {
+	dir := t.TempDir()
+	_ = os.MkdirAll(filepath.Join(dir, "a", "b"), 0755)
+	mod := New(fakelogger.New(), Options{ProjectDir: dir})
+
+	_, err := run(mod, `fs.remove("a")`)
+
+	assert.NoError(t, err)
+	assert.NoDirExists(t, filepath.Join(dir, "a"))
+}
+
+func Test_remove_refuses_to_remove_project_dir(t *testing.T) {
+	dir := t.TempDir()
+	mod := New(fakelogger.New(), Options{ProjectDir: dir})
+
+	_, err := run(mod, `fs.remove(".")`)
+
+	assert.Error(t, err)
+	assert.DirExists(t, dir)
+}
+
+func Test_temp_dir_is_accessible_and_removed_on_cleanup(t *testing.T) {
+	mod := New(fakelogger.New(), Options{ProjectDir: t.TempDir()})
+
+	result, err := run(mod, `func() { d := fs.temp_dir(); fs.write_file(d + "/file", "x"); return d }()`)
+	tempDir, _ := result.(string)
+
+	assert.NoError(t, err)
+	assert.FileExists(t, filepath.Join(tempDir, "file"))
+	assert.NoError(t, mod.Cleanup())
+	assert.NoDirExists(t, tempDir)
+}
+
+func Test_paths_outside_of_project_dir_are_rejected(t *testing.T) {
+	projectDir := t.TempDir()
+	outsideDir := t.TempDir()
+	_ = os.WriteFile(filepath.Join(outsideDir, "file.txt"), nil, 0644)
+	mod := New(fakelogger.New(), Options{ProjectDir: projectDir})
+
+	for _, code := range []string{
+		`fs.read_file("../file.txt")`,
+		`fs.read_file("` + filepath.Join(outsideDir, "file.txt") + `")`,
+		`fs.write_file("../file.txt", "")`,
+		`fs.exists("../file.txt")`,
+		`fs.mkdir("../dir")`,
+		`fs.remove("..")`,
+		`fs.glob("../*")`,
+	} {
+		t.Run(code, func(t *testing.T) {
+			_, err := run(mod, code)
+
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), "outside of the project directory")
+		})
+	}
+}
+
+func Test_symlinks_pointing_outside_of_project_dir_are_rejected(t *testing.T) {
+	projectDir := t.TempDir()
+	outsideDir := t.TempDir()
+	_ = os.WriteFile(filepath.Join(outsideDir, "file.txt"), []byte("secret"), 0644)
+	_ = os.Symlink(outsideDir, filepath.Join(projectDir, "link"))
+	mod := New(fakelogger.New(), Options{ProjectDir: projectDir})
+
+	_, err := run(mod, `fs.read_file("link/file.txt")`)
+
+	assert.Error(t, err)
+}
+
+func Test_paths_outside_of_project_dir_are_accessible_when_allowed(t *testing.T) {
+	projectDir := t.TempDir()
+	outsideDir := t.TempDir()
+	_ = os.WriteFile(filepath.Join(outsideDir, "file.txt"), []byte("content"), 0644)
+	mod := New(fakelogger.New(), Options{ProjectDir: projectDir, AllowOutsideProject: true})
+
+	result, err := run(mod, `fs.read_file("`+filepath.Join(outsideDir, "file.txt")+`")`)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "content", result)
+}
+
+func run(m *module, code string) (result interface{}, err error) {
+	modules := tengo.NewModuleMap()
+	modules.Add("fs", m)
+	script := tengo.NewScript([]byte(`fs := import("fs"); result := ` + code))
+	script.SetImports(modules)
+	compiled, err := script.Run()
+	if err == nil {
+		err = tengoutil.DecodeObject(compiled.Get("result").Object(), &result)
+	}
+	return
+}
@@ -0,0 +1,340 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"time"
+
+	"github.com/d5/tengo/v2"
+	"github.com/g2a-com/cicd/internal/tengoutil"
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+type module struct {
+	dir string
+}
+
+// New creates git module operating on the repository containing the directory.
+// Repository is read in-process, so the git command doesn't have to be
+// installed or allowed for executors.
+func New(dir string) *module {
+	return &module{dir: dir}
+}
+
+func (m *module) Import(name string) (interface{}, error) {
+	return tengoutil.ToImmutableObject(map[string]interface{}{
+		"__module_name__":     name,
+		"is_repository":       m.isRepository,
+		"sha":                 m.sha,
+		"short_sha":           m.shortSha,
+		"branch":              m.branch,
+		"tags_at_head":        m.tagsAtHead,
+		"describe":            m.describe,
+		"is_dirty":            m.isDirty,
+		"commit_time":         m.commitTime,
+		"changed_files_since": m.changedFilesSince,
+	})
+}
+
+// isRepository checks whether the directory is in a git repository.
+func (m *module) isRepository() bool {
+	_, err := m.open()
+	return err == nil
+}
+
+func (m *module) sha() string {
+	return m.head(m.repository()).Hash.String()
+}
+
+// shortSha returns abbreviated SHA of HEAD, length defaults to 7 characters.
+func (m *module) shortSha(length ...int) string {
+	n := 7
+	if len(length) > 0 {
+		n = length[0]
+	}
+	if n < 1 || n > 40 {
+		panic(fmt.Errorf("length must be between 1 and 40, got %d", n))
+	}
+	return m.sha()[:n]
+}
+
+// branch returns name of the current branch, or an empty string when HEAD is
+// detached (which is common in CI).
+func (m *module) branch() string {
+	ref, err := m.repository().Head()
+	if err != nil {
+		panic(fmt.Errorf("cannot read HEAD: %w", err))
+	}
+	if !ref.Name().IsBranch() {
+		return ""
+	}
+	return ref.Name().Short()
+}
+
+func (m *module) tagsAtHead() []string {
+	repo := m.repository()
+	head := m.head(repo)
+
+	result := []string{}
+	for _, t := range m.tags(repo) {
+		if t.commit == head.Hash {
+			result = append(result, t.name)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+type describeOpts struct {
+	Match      string `tengo:"match"`
+	Dirty      bool   `tengo:"dirty"`
+	ExactMatch bool   `tengo:"exact_match"`
+	Annotated  bool   `tengo:"annotated"`
+}
+
+// describe returns the most recent tag followed by the number of commits on
+// top of it and abbreviated SHA of HEAD (e.g. v1.0.0-3-gabcdef0), like
+// "git describe --tags --always". When there are no tags (e.g. in shallow
+// clones) only abbreviated SHA is returned. With exact_match only a tag
+// pointing at HEAD is returned, or an empty string if there is none (like
+// "git describe --exact-match", but without failing).
+func (m *module) describe(opts ...describeOpts) string {
+	var o describeOpts
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+
+	repo := m.repository()
+	if _, err := repo.Head(); o.ExactMatch && errors.Is(err, plumbing.ErrReferenceNotFound) {
+		// Nothing is tagged in repositories without commits
+		return ""
+	}
+	head := m.head(repo)
+
+	names := map[plumbing.Hash]tag{}
+	for _, t := range m.tags(repo) {
+		if o.Annotated && !t.annotated {
+			continue
+		}
+		if o.Match != "" {
+			if ok, _ := path.Match(o.Match, t.name); !ok {
+				continue
+			}
+		}
+		if current, ok := names[t.commit]; !ok || t.betterThan(current) {
+			names[t.commit] = t
+		}
+	}
+
+	suffix := ""
+	if o.Dirty && m.isDirty() {
+		suffix = "-dirty"
+	}
+
+	if o.ExactMatch {
+		if t, ok := names[head.Hash]; ok {
+			return t.name + suffix
+		}
+		return ""
+	}
+
+	// Like git, the tag with the smallest number of commits on top of it is
+	// used, which are commits reachable from HEAD, but not from the tag.
+	ancestors := m.ancestors(repo, head.Hash)
+	best, depth := tag{}, -1
+	for hash, t := range names {
+		if !ancestors[hash] {
+			continue
+		}
+		d := len(ancestors) - len(m.ancestors(repo, hash))
+		if depth == -1 || d < depth || (d == depth && t.betterThan(best)) {
+			best, depth = t, d
+		}
+	}
+
+	short := head.Hash.String()[:7]
+	switch depth {
+	case -1:
+		return short + suffix
+	case 0:
+		return best.name + suffix
+	default:
+		return fmt.Sprintf("%s-%d-g%s%s", best.name, depth, short, suffix)
+	}
+}
+
+// isDirty checks whether tracked files contain uncommitted changes, untracked
+// files are ignored.
+func (m *module) isDirty() bool {
+	worktree, err := m.repository().Worktree()
+	if err != nil {
+		panic(fmt.Errorf("cannot read worktree: %w", err))
+	}
+	status, err := worktree.Status()
+	if err != nil {
+		panic(fmt.Errorf("cannot read status of worktree: %w", err))
+	}
+	for _, s := range status {
+		if s.Worktree == gogit.Untracked && s.Staging == gogit.Untracked {
+			continue
+		}
+		if s.Worktree != gogit.Unmodified || s.Staging != gogit.Unmodified {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *module) commitTime() tengo.Object {
+	commit := m.head(m.repository())
+	return &tengo.Time{Value: time.Unix(commit.Committer.When.Unix(), 0)}
+}
+
+// changedFilesSince returns files changed between the revision and HEAD,
+// relative to the root of the repository.
+func (m *module) changedFilesSince(revision string) []string {
+	repo := m.repository()
+	hash, err := repo.ResolveRevision(plumbing.Revision(revision))
+	if err != nil {
+		panic(fmt.Errorf("cannot resolve revision %q: %w", revision, err))
+	}
+	since, err := repo.CommitObject(*hash)
+	if err != nil {
+		panic(fmt.Errorf("cannot read commit %s: %w", hash, err))
+	}
+
+	changes, err := object.DiffTree(m.tree(since), m.tree(m.head(repo)))
+	if err != nil {
+		panic(fmt.Errorf("cannot compare %q with HEAD: %w", revision, err))
+	}
+
+	unique := map[string]bool{}
+	for _, change := range changes {
+		for _, name := range []string{change.From.Name, change.To.Name} {
+			if name != "" {
+				unique[name] = true
+			}
+		}
+	}
+	files := make([]string, 0, len(unique))
+	for name := range unique {
+		files = append(files, name)
+	}
+	sort.Strings(files)
+	return files
+}
+
+func (m *module) open() (*gogit.Repository, error) {
+	return gogit.PlainOpenWithOptions(m.dir, &gogit.PlainOpenOptions{
+		DetectDotGit:          true,
+		EnableDotGitCommonDir: true,
+	})
+}
+
+// repository opens the repository, errors abort the script.
+func (m *module) repository() *gogit.Repository {
+	repo, err := m.open()
+	if errors.Is(err, gogit.ErrRepositoryNotExists) {
+		panic(fmt.Errorf("directory %s is not in a git repository", m.dir))
+	}
+	if err != nil {
+		panic(fmt.Errorf("cannot open git repository in %s: %w", m.dir, err))
+	}
+	return repo
+}
+
+func (m *module) head(repo *gogit.Repository) *object.Commit {
+	ref, err := repo.Head()
+	if err != nil {
+		panic(fmt.Errorf("cannot read HEAD: %w", err))
+	}
+	commit, err := repo.CommitObject(ref.Hash())
+	if err != nil {
+		panic(fmt.Errorf("cannot read commit %s: %w", ref.Hash(), err))
+	}
+	return commit
+}
+
+func (m *module) tree(commit *object.Commit) *object.Tree {
+	tree, err := commit.Tree()
+	if err != nil {
+		panic(fmt.Errorf("cannot read tree of commit %s: %w", commit.Hash, err))
+	}
+	return tree
+}
+
+type tag struct {
+	name      string
+	commit    plumbing.Hash
+	annotated bool
+	date      time.Time
+}
+
+// betterThan compares tags pointing at the same commit the way git describe
+// does: annotated tags win over lightweight ones, newer over older ones.
+func (t tag) betterThan(other tag) bool {
+	if t.annotated != other.annotated {
+		return t.annotated
+	}
+	if !t.date.Equal(other.date) {
+		return t.date.After(other.date)
+	}
+	return t.name < other.name
+}
+
+// tags returns all tags of the repository together with commits they point
+// at. Tags pointing at other objects than commits are skipped.
+func (m *module) tags(repo *gogit.Repository) (tags []tag) {
+	refs, err := repo.Tags()
+	if err != nil {
+		panic(fmt.Errorf("cannot read tags: %w", err))
+	}
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		t := tag{name: ref.Name().Short(), commit: ref.Hash()}
+		if annotated, err := repo.TagObject(ref.Hash()); err == nil {
+			commit, err := annotated.Commit()
+			if err != nil {
+				return nil
+			}
+			t.commit = commit.Hash
+			t.annotated = true
+			t.date = annotated.Tagger.When
+		} else if _, err := repo.CommitObject(ref.Hash()); err != nil {
+			return nil
+		}
+		tags = append(tags, t)
+		return nil
+	})
+	if err != nil {
+		panic(fmt.Errorf("cannot read tags: %w", err))
+	}
+	return tags
+}
+
+// ancestors returns the commit and all commits reachable from it. Missing
+// commits (e.g. beyond the history of a shallow clone) are skipped.
+func (m *module) ancestors(repo *gogit.Repository, hash plumbing.Hash) map[plumbing.Hash]bool {
+	visited := map[plumbing.Hash]bool{}
+	queue := []plumbing.Hash{hash}
+	for len(queue) > 0 {
+		h := queue[0]
+		queue = queue[1:]
+		if visited[h] {
+			continue
+		}
+		commit, err := repo.CommitObject(h)
+		if errors.Is(err, plumbing.ErrObjectNotFound) {
+			continue
+		}
+		if err != nil {
+			panic(fmt.Errorf("cannot read commit %s: %w", h, err))
+		}
+		visited[h] = true
+		queue = append(queue, commit.ParentHashes...)
+	}
+	return visited
+}
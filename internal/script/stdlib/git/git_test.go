@@ -0,0 +1,276 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/d5/tengo/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_sha_returns_hash_of_head(t *testing.T) {
+	dir := prepareRepo(t)
+	commit(t, dir, "a.txt")
+
+	sha, err := run(dir, `git.sha()`)
+	shortSha, _ := run(dir, `git.short_sha()`)
+	shortSha10, _ := run(dir, `git.short_sha(10)`)
+
+	assert.NoError(t, err)
+	assert.Equal(t, gitOutput(t, dir, "rev-parse", "HEAD"), sha)
+	assert.Equal(t, sha.(string)[:7], shortSha)
+	assert.Equal(t, sha.(string)[:10], shortSha10)
+}
+
+func Test_short_sha_fails_on_invalid_length(t *testing.T) {
+	dir := prepareRepo(t)
+	commit(t, dir, "a.txt")
+
+	_, err := run(dir, `git.short_sha(41)`)
+
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "length must be between 1 and 40, got 41")
+	}
+}
+
+func Test_branch_returns_current_branch(t *testing.T) {
+	dir := prepareRepo(t)
+	commit(t, dir, "a.txt")
+	git(t, dir, "checkout", "-q", "-b", "feature")
+
+	branch, err := run(dir, `git.branch()`)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "feature", branch)
+}
+
+func Test_branch_is_empty_when_head_is_detached(t *testing.T) {
+	dir := prepareRepo(t)
+	commit(t, dir, "a.txt")
+	git(t, dir, "checkout", "-q", "--detach")
+
+	branch, err := run(dir, `git.branch()`)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "", branch)
+}
+
+func Test_tags_at_head_returns_sorted_tags(t *testing.T) {
+	dir := prepareRepo(t)
+	commit(t, dir, "a.txt")
+	git(t, dir, "tag", "old")
+	commit(t, dir, "b.txt")
+	git(t, dir, "tag", "v2")
+	git(t, dir, "tag", "-a", "-m", "release", "v1")
+
+	tags, err := run(dir, `git.tags_at_head()`)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{"v1", "v2"}, tags)
+}
+
+func Test_describe_returns_distance_from_tag(t *testing.T) {
+	dir := prepareRepo(t)
+	commit(t, dir, "a.txt")
+	git(t, dir, "tag", "v1.0.0")
+	commit(t, dir, "b.txt")
+	sha := gitOutput(t, dir, "rev-parse", "--short=7", "HEAD")
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "a.txt"), []byte("changed"), 0644))
+
+	description, err := run(dir, `git.describe()`)
+	dirty, _ := run(dir, `git.describe({dirty: true, match: "v*"})`)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "v1.0.0-1-g"+sha, description)
+	assert.Equal(t, "v1.0.0-1-g"+sha+"-dirty", dirty)
+}
+
+func Test_describe_prefers_annotated_tags(t *testing.T) {
+	dir := prepareRepo(t)
+	commit(t, dir, "a.txt")
+	git(t, dir, "tag", "-a", "-m", "release", "v1")
+	commit(t, dir, "b.txt")
+	git(t, dir, "tag", "-a", "-m", "release", "v2")
+	git(t, dir, "tag", "a-lightweight")
+
+	description, err := run(dir, `git.describe()`)
+
+	assert.NoError(t, err)
+	assert.Equal(t, gitOutput(t, dir, "describe", "--tags"), description)
+	assert.Equal(t, "v2", description)
+}
+
+func Test_describe_with_exact_match_returns_tag_pointing_at_head(t *testing.T) {
+	dir := prepareRepo(t)
+	commit(t, dir, "a.txt")
+	git(t, dir, "tag", "-a", "-m", "release", "v1")
+	git(t, dir, "tag", "lightweight")
+
+	annotated, err := run(dir, `git.describe({exact_match: true, annotated: true})`)
+	commit(t, dir, "b.txt")
+	git(t, dir, "tag", "next")
+	lightweight, _ := run(dir, `git.describe({exact_match: true, annotated: true})`)
+	any, _ := run(dir, `git.describe({exact_match: true})`)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "v1", annotated)
+	assert.Equal(t, "", lightweight)
+	assert.Equal(t, "next", any)
+}
+
+func Test_describe_returns_sha_when_there_are_no_tags(t *testing.T) {
+	dir := prepareRepo(t)
+	commit(t, dir, "a.txt")
+
+	description, err := run(dir, `git.describe()`)
+
+	assert.NoError(t, err)
+	assert.Equal(t, gitOutput(t, dir, "rev-parse", "--short=7", "HEAD"), description)
+}
+
+func Test_is_dirty_ignores_untracked_files(t *testing.T) {
+	dir := prepareRepo(t)
+	commit(t, dir, "a.txt")
+
+	clean, err := run(dir, `git.is_dirty()`)
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "untracked.txt"), nil, 0644))
+	untracked, _ := run(dir, `git.is_dirty()`)
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "a.txt"), []byte("changed"), 0644))
+	dirty, _ := run(dir, `git.is_dirty()`)
+
+	assert.NoError(t, err)
+	assert.Equal(t, false, clean)
+	assert.Equal(t, false, untracked)
+	assert.Equal(t, true, dirty)
+}
+
+func Test_commit_time_returns_time_of_head(t *testing.T) {
+	dir := prepareRepo(t)
+	commit(t, dir, "a.txt")
+
+	commitTime, err := run(dir, `git.commit_time()`)
+
+	assert.NoError(t, err)
+	assert.Equal(t, time.Unix(1600000000, 0), commitTime)
+}
+
+func Test_changed_files_since_returns_files_changed_after_revision(t *testing.T) {
+	dir := prepareRepo(t)
+	commit(t, dir, "a.txt")
+	git(t, dir, "tag", "v1")
+	commit(t, dir, "b.txt")
+	commit(t, dir, "sub/c.txt")
+
+	files, err := run(dir, `git.changed_files_since("v1")`)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{"b.txt", "sub/c.txt"}, files)
+}
+
+func Test_changed_files_since_fails_on_unknown_revision(t *testing.T) {
+	dir := prepareRepo(t)
+	commit(t, dir, "a.txt")
+
+	_, err := run(dir, `git.changed_files_since("unknown")`)
+
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), `cannot resolve revision "unknown"`)
+	}
+}
+
+func Test_describe_works_in_shallow_clones(t *testing.T) {
+	origin := prepareRepo(t)
+	commit(t, origin, "a.txt")
+	git(t, origin, "tag", "v1")
+	commit(t, origin, "b.txt")
+	commit(t, origin, "c.txt")
+	dir := filepath.Join(t.TempDir(), "clone")
+	git(t, origin, "clone", "-q", "--depth", "1", "file://"+origin, dir)
+
+	description, err := run(dir, `git.describe()`)
+
+	assert.NoError(t, err)
+	assert.Equal(t, gitOutput(t, dir, "rev-parse", "--short=7", "HEAD"), description)
+}
+
+func Test_functions_work_in_subdirectories_of_repository(t *testing.T) {
+	dir := prepareRepo(t)
+	commit(t, dir, "sub/a.txt")
+
+	sha, err := run(filepath.Join(dir, "sub"), `git.sha()`)
+
+	assert.NoError(t, err)
+	assert.Equal(t, gitOutput(t, dir, "rev-parse", "HEAD"), sha)
+}
+
+func Test_is_repository_checks_whether_directory_is_in_repository(t *testing.T) {
+	repo, err := run(prepareRepo(t), `git.is_repository()`)
+	notRepo, _ := run(t.TempDir(), `git.is_repository()`)
+
+	assert.NoError(t, err)
+	assert.Equal(t, true, repo)
+	assert.Equal(t, false, notRepo)
+}
+
+func Test_functions_fail_outside_of_repository(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := run(dir, `git.sha()`)
+
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "directory "+dir+" is not in a git repository")
+	}
+}
+
+func prepareRepo(t *testing.T) string {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	dir := t.TempDir()
+	git(t, dir, "init", "-q")
+	return dir
+}
+
+func commit(t *testing.T, dir string, file string) {
+	path := filepath.Join(dir, file)
+	assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	assert.NoError(t, os.WriteFile(path, []byte(file), 0644))
+	git(t, dir, "add", file)
+	git(t, dir, "commit", "-q", "-m", "Add "+file)
+}
+
+func git(t *testing.T, dir string, args ...string) {
+	gitOutput(t, dir, args...)
+}
+
+func gitOutput(t *testing.T, dir string, args ...string) string {
+	args = append([]string{"-c", "user.name=Test", "-c", "user.email=test@example.com", "-c", "commit.gpgsign=false", "-c", "tag.gpgsign=false"}, args...)
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GIT_COMMITTER_DATE=@1600000000 +0000", "GIT_AUTHOR_DATE=@1600000000 +0000")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v failed: %s", args, output)
+	}
+	return strings.TrimSpace(string(output))
+}
+
+func run(dir string, code string) (interface{}, error) {
+	return runModule(New(dir), code)
+}
+
+func runModule(m *module, code string) (result interface{}, err error) {
+	modules := tengo.NewModuleMap()
+	modules.Add("git", m)
+	script := tengo.NewScript([]byte(`git := import("git"); result := ` + code))
+	script.SetImports(modules)
+	compiled, err := script.Run()
+	if err == nil {
+		result = compiled.Get("result").Value()
+	}
+	return
+}
@@ -0,0 +1,220 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/g2a-com/cicd/internal/tengoutil"
+	logger "github.com/g2a-com/klio-logger-go/v2"
+)
+
+const defaultTimeout = time.Minute
+
+// redactedHeaders lists headers which values are never logged.
+var redactedHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+type module struct {
+	ctx         context.Context
+	client      *http.Client
+	logger      logger.Logger
+	resolvePath func(string) (string, error)
+}
+
+// New creates http module, resolvePath is used to resolve paths of uploaded
+// files. Requests are cancelled once the context is done.
+func New(ctx context.Context, logger logger.Logger, resolvePath func(string) (string, error)) *module {
+	return &module{
+		ctx:         ctx,
+		client:      &http.Client{},
+		logger:      logger,
+		resolvePath: resolvePath,
+	}
+}
+
+func (m *module) Import(name string) (interface{}, error) {
+	return tengoutil.ToImmutableObject(map[string]interface{}{
+		"__module_name__": name,
+		"request":         m.request,
+		"get":             m.get,
+	})
+}
+
+func (m *module) get(url string) response {
+	return m.request(requestOpts{URL: url})
+}
+
+func (m *module) request(opts requestOpts) response {
+	res, err := m.do(opts)
+	if err != nil {
+		if !opts.IgnoreErrors {
+			panic(err)
+		}
+		res.Error = err
+	}
+	return res
+}
+
+func (m *module) do(opts requestOpts) (res response, err error) {
+	if opts.Method == "" {
+		opts.Method = http.MethodGet
+	}
+	timeout := defaultTimeout
+	if opts.Timeout != "" {
+		timeout, err = time.ParseDuration(opts.Timeout)
+		if err != nil {
+			return res, fmt.Errorf("invalid timeout %q: %w", opts.Timeout, err)
+		}
+	}
+
+	// Prepare body
+	var body io.Reader
+	if opts.Body != nil && opts.File != "" {
+		return res, errors.New("options \"body\" and \"file\" cannot be used together")
+	}
+	switch b := opts.Body.(type) {
+	case nil:
+	case string:
+		body = strings.NewReader(b)
+	case []byte:
+		body = bytes.NewReader(b)
+	default:
+		return res, fmt.Errorf("body must be a string or bytes, got %T", opts.Body)
+	}
+	if opts.File != "" {
+		path, err := m.resolvePath(opts.File)
+		if err != nil {
+			return res, err
+		}
+		file, err := os.Open(path)
+		if err != nil {
+			return res, err
+		}
+		defer file.Close()
+		body = file
+	}
+
+	// Prepare request
+	req, err := http.NewRequestWithContext(m.ctx, strings.ToUpper(opts.Method), opts.URL, body)
+	if err != nil {
+		return res, err
+	}
+	if f, ok := body.(*os.File); ok {
+		stat, err := f.Stat()
+		if err != nil {
+			return res, err
+		}
+		req.ContentLength = stat.Size()
+	}
+	for name, value := range opts.Headers {
+		req.Header.Set(name, value)
+	}
+	if opts.BasicAuth != (basicAuth{}) {
+		req.SetBasicAuth(opts.BasicAuth.Username, opts.BasicAuth.Password)
+	}
+	if opts.BearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+opts.BearerToken)
+	}
+
+	m.logger.WithLevel(logger.DebugLevel).Printf("HTTP request: %s %s%s", req.Method, redactURL(req.URL), formatHeaders(req.Header))
+
+	// Send request
+	client := *m.client
+	client.Timeout = timeout
+	resp, err := client.Do(req)
+	if err != nil {
+		// Errors returned by the client contain URL, make sure that it doesn't
+		// contain credentials.
+		if urlErr, ok := err.(*url.Error); ok {
+			urlErr.URL = redactURL(req.URL)
+		}
+		return res, err
+	}
+	defer resp.Body.Close()
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return res, err
+	}
+
+	m.logger.WithLevel(logger.DebugLevel).Printf("HTTP response: %s%s", resp.Status, formatHeaders(resp.Header))
+
+	res.StatusCode = resp.StatusCode
+	res.Status = resp.Status
+	res.Headers = map[string]string{}
+	for name, values := range resp.Header {
+		res.Headers[name] = strings.Join(values, ", ")
+	}
+	res.Body = data
+	res.BodyText = string(data)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return res, fmt.Errorf("%s %s returned %s", req.Method, redactURL(req.URL), resp.Status)
+	}
+
+	return res, nil
+}
+
+type requestOpts struct {
+	URL          string            `tengo:"url"`
+	Method       string            `tengo:"method"`
+	Headers      map[string]string `tengo:"headers"`
+	Body         interface{}       `tengo:"body"`
+	File         string            `tengo:"file"`
+	Timeout      string            `tengo:"timeout"`
+	BasicAuth    basicAuth         `tengo:"basic_auth"`
+	BearerToken  string            `tengo:"bearer_token"`
+	IgnoreErrors bool              `tengo:"ignore_errors"`
+}
+
+type basicAuth struct {
+	Username string `tengo:"username"`
+	Password string `tengo:"password"`
+}
+
+type response struct {
+	StatusCode int               `tengo:"status_code"`
+	Status     string            `tengo:"status"`
+	Headers    map[string]string `tengo:"headers"`
+	Body       []byte            `tengo:"body"`
+	BodyText   string            `tengo:"body_text"`
+	Error      error             `tengo:"error"`
+}
+
+func redactURL(u *url.URL) string {
+	if u.User == nil {
+		return u.String()
+	}
+	redacted := *u
+	redacted.User = url.User("REDACTED")
+	return redacted.String()
+}
+
+func formatHeaders(header http.Header) string {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var sb strings.Builder
+	for _, name := range names {
+		value := strings.Join(header[name], ", ")
+		for _, h := range redactedHeaders {
+			if strings.EqualFold(name, h) {
+				value = "REDACTED"
+			}
+		}
+		fmt.Fprintf(&sb, "\n\t%s: %s", name, value)
+	}
+	return sb.String()
+}
@@ -0,0 +1,204 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/d5/tengo/v2"
+	"github.com/g2a-com/cicd/internal/tengoutil"
+	fakelogger "github.com/g2a-com/cicd/internal/utils/fake_logger"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_get_returns_response(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Test", "value")
+		fmt.Fprint(w, "body")
+	}))
+	defer server.Close()
+	mod := New(context.Background(), fakelogger.New(), resolvePath)
+
+	result, err := run(mod, `http.get("`+server.URL+`")`)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 200, result["status_code"])
+	assert.Equal(t, "200 OK", result["status"])
+	assert.Equal(t, "value", result["headers"].(map[string]interface{})["X-Test"])
+	assert.Equal(t, []byte("body"), result["body"])
+	assert.Equal(t, "body", result["body_text"])
+}
+
+func Test_request_sends_method_headers_and_body(t *testing.T) {
+	var method, header, body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := ioutil.ReadAll(r.Body)
+		method, header, body = r.Method, r.Header.Get("X-Test"), string(data)
+	}))
+	defer server.Close()
+	mod := New(context.Background(), fakelogger.New(), resolvePath)
+
+	_, err := run(mod, `http.request({
+		url: "`+server.URL+`",
+		method: "post",
+		headers: {"X-Test": "value"},
+		body: "content"
+	})`)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "POST", method)
+	assert.Equal(t, "value", header)
+	assert.Equal(t, "content", body)
+}
+
+func Test_request_uploads_file(t *testing.T) {
+	dir := t.TempDir()
+	_ = os.WriteFile(filepath.Join(dir, "file.txt"), []byte("file content"), 0644)
+	var body string
+	var length int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := ioutil.ReadAll(r.Body)
+		body, length = string(data), r.ContentLength
+	}))
+	defer server.Close()
+	mod := New(context.Background(), fakelogger.New(), func(path string) (string, error) {
+		return filepath.Join(dir, path), nil
+	})
+
+	_, err := run(mod, `http.request({url: "`+server.URL+`", method: "PUT", file: "file.txt"})`)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "file content", body)
+	assert.Equal(t, int64(12), length)
+}
+
+func Test_request_fails_when_file_cannot_be_resolved(t *testing.T) {
+	mod := New(context.Background(), fakelogger.New(), func(path string) (string, error) {
+		return "", fmt.Errorf("path %s is outside of the project directory", path)
+	})
+
+	_, err := run(mod, `http.request({url: "http://localhost", method: "PUT", file: "../file.txt"})`)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "outside of the project directory")
+}
+
+func Test_request_uses_basic_auth(t *testing.T) {
+	var username, password string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, password, _ = r.BasicAuth()
+	}))
+	defer server.Close()
+	mod := New(context.Background(), fakelogger.New(), resolvePath)
+
+	_, err := run(mod, `http.request({url: "`+server.URL+`", basic_auth: {username: "user", password: "pass"}})`)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "user", username)
+	assert.Equal(t, "pass", password)
+}
+
+func Test_request_uses_bearer_token(t *testing.T) {
+	var auth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+	}))
+	defer server.Close()
+	mod := New(context.Background(), fakelogger.New(), resolvePath)
+
+	_, err := run(mod, `http.request({url: "`+server.URL+`", bearer_token: "token"})`)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Bearer token", auth)
+}
+
+func Test_request_logs_at_debug_level_with_credentials_redacted(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	log := fakelogger.New()
+	mod := New(context.Background(), log, resolvePath)
+	url := strings.Replace(server.URL, "http://", "http://user:secret-password@", 1)
+
+	_, err := run(mod, `http.request({url: "`+url+`", headers: {"X-Test": "value"}, bearer_token: "secret-token"})`)
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, log.Messages)
+	for _, msg := range log.Messages {
+		text := fmt.Sprintf(msg.Args[0].(string), msg.Args[1:]...)
+		assert.Equal(t, "debug", string(msg.Level))
+		assert.NotContains(t, text, "secret")
+	}
+	assert.Contains(t, fmt.Sprintf(log.Messages[0].Args[0].(string), log.Messages[0].Args[1:]...), "X-Test: value")
+}
+
+func Test_request_aborts_script_on_error_status(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+	mod := New(context.Background(), fakelogger.New(), resolvePath)
+
+	_, err := run(mod, `http.get("`+server.URL+`")`)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "404 Not Found")
+}
+
+func Test_request_returns_error_when_errors_are_ignored(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+	mod := New(context.Background(), fakelogger.New(), resolvePath)
+
+	result, err := run(mod, `http.request({url: "`+server.URL+`", ignore_errors: true})`)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 404, result["status_code"])
+	assert.Contains(t, result["error"], "404 Not Found")
+}
+
+func Test_request_aborts_script_on_timeout(t *testing.T) {
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-done
+	}))
+	defer server.Close()
+	defer close(done)
+	mod := New(context.Background(), fakelogger.New(), resolvePath)
+
+	_, err := run(mod, `http.request({url: "`+server.URL+`", timeout: "10ms"})`)
+
+	assert.Error(t, err)
+}
+
+func Test_request_aborts_script_on_invalid_timeout(t *testing.T) {
+	mod := New(context.Background(), fakelogger.New(), resolvePath)
+
+	_, err := run(mod, `http.request({url: "http://localhost", timeout: "abc"})`)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid timeout")
+}
+
+func resolvePath(path string) (string, error) {
+	return path, nil
+}
+
+func run(m *module, code string) (result map[string]interface{}, err error) {
+	modules := tengo.NewModuleMap()
+	modules.Add("http", m)
+	script := tengo.NewScript([]byte(`http := import("http"); result := ` + code))
+	script.SetImports(modules)
+	compiled, err := script.Run()
+	if err == nil {
+		err = tengoutil.DecodeObject(compiled.Get("result").Object(), &result)
+	}
+	return
+}
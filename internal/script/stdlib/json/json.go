@@ -0,0 +1,108 @@
+package json
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/d5/tengo/v2"
+	"github.com/g2a-com/cicd/internal/tengoutil"
+)
+
+type module struct{}
+
+func New() *module {
+	return &module{}
+}
+
+func (m *module) Import(name string) (interface{}, error) {
+	return tengoutil.ToImmutableObject(map[string]interface{}{
+		"__module_name__": name,
+		"decode":          m.decode,
+		"encode":          m.encode,
+		"encode_indent":   m.encodeIndent,
+	})
+}
+
+func (m *module) decode(data interface{}) tengo.Object {
+	var text string
+	switch d := data.(type) {
+	case string:
+		text = d
+	case []byte:
+		text = string(d)
+	default:
+		panic(fmt.Errorf("cannot decode JSON from %T, expected string or bytes", data))
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(text))
+	decoder.UseNumber()
+
+	var value interface{}
+	err := decoder.Decode(&value)
+	if err != nil {
+		panic(fmt.Errorf("cannot decode JSON: %w", err))
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		panic(fmt.Errorf("cannot decode JSON: unexpected data after top-level value"))
+	}
+
+	obj, err := tengoutil.ToObject(convertNumbers(value))
+	if err != nil {
+		panic(fmt.Errorf("cannot decode JSON: %w", err))
+	}
+	return obj
+}
+
+func (m *module) encode(obj tengo.Object) string {
+	return encode(obj, "")
+}
+
+func (m *module) encodeIndent(obj tengo.Object) string {
+	return encode(obj, "  ")
+}
+
+func encode(obj tengo.Object, indent string) string {
+	var value interface{}
+	err := tengoutil.DecodeObject(obj, &value)
+	if err != nil {
+		panic(fmt.Errorf("cannot encode %s to JSON: %w", obj.TypeName(), err))
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", indent)
+	err = encoder.Encode(value)
+	if err != nil {
+		panic(fmt.Errorf("cannot encode %s to JSON: %w", obj.TypeName(), err))
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// convertNumbers replaces json.Number values with ints (if number has no
+// fractional part and fits in int64) or floats.
+func convertNumbers(value interface{}) interface{} {
+	switch v := value.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, err := v.Float64()
+		if err != nil {
+			panic(err)
+		}
+		return f
+	case []interface{}:
+		for i := range v {
+			v[i] = convertNumbers(v[i])
+		}
+	case map[string]interface{}:
+		for k := range v {
+			v[k] = convertNumbers(v[k])
+		}
+	}
+	return value
+}
@@ -0,0 +1,78 @@
+package json
+
+import (
+	"testing"
+
+	"github.com/d5/tengo/v2"
+	"github.com/g2a-com/cicd/internal/tengoutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_decode_converts_json_to_objects(t *testing.T) {
+	result, err := run(`json.decode("{\"a\": [1, 1.5, \"str\", true, null]}")`)
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"a": []interface{}{1, 1.5, "str", true, nil},
+	}, result)
+}
+
+func Test_decode_accepts_bytes(t *testing.T) {
+	result, err := run(`json.decode(bytes("[1]"))`)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{1}, result)
+}
+
+func Test_decode_aborts_script_on_invalid_json(t *testing.T) {
+	for _, code := range []string{`json.decode("{")`, `json.decode("{} []")`, `json.decode(1)`} {
+		t.Run(code, func(t *testing.T) {
+			_, err := run(code)
+
+			assert.Error(t, err)
+		})
+	}
+}
+
+func Test_encode_converts_objects_to_json(t *testing.T) {
+	result, err := run(`json.encode({b: [1, 1.5, "<str>", true, undefined], a: {}})`)
+
+	assert.NoError(t, err)
+	assert.Equal(t, `{"a":{},"b":[1,1.5,"<str>",true,null]}`, result)
+}
+
+func Test_encode_indent_returns_indented_json(t *testing.T) {
+	result, err := run(`json.encode_indent({a: [1]})`)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "{\n  \"a\": [\n    1\n  ]\n}", result)
+}
+
+func Test_encode_aborts_script_on_unsupported_types(t *testing.T) {
+	_, err := run(`json.encode({a: func() {}})`)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot encode map to JSON")
+}
+
+func Test_objects_round_trip(t *testing.T) {
+	result, err := run(`json.decode(json.encode({a: [1, 2.5, {b: "c"}], d: false}))`)
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"a": []interface{}{1, 2.5, map[string]interface{}{"b": "c"}},
+		"d": false,
+	}, result)
+}
+
+func run(code string) (result interface{}, err error) {
+	modules := tengo.NewModuleMap()
+	modules.Add("json", New())
+	script := tengo.NewScript([]byte(`json := import("json"); result := ` + code))
+	script.SetImports(modules)
+	compiled, err := script.Run()
+	if err == nil {
+		err = tengoutil.DecodeObject(compiled.Get("result").Object(), &result)
+	}
+	return
+}
@@ -1,18 +1,45 @@
 package stdlib
 
 import (
+	"context"
 	"fmt"
+	"os"
 
 	"github.com/d5/tengo/v2"
+	tengoStdlib "github.com/d5/tengo/v2/stdlib"
 	execModule "github.com/g2a-com/cicd/internal/script/stdlib/exec"
+	fsModule "github.com/g2a-com/cicd/internal/script/stdlib/fs"
+	gitModule "github.com/g2a-com/cicd/internal/script/stdlib/git"
+	httpModule "github.com/g2a-com/cicd/internal/script/stdlib/http"
+	jsonModule "github.com/g2a-com/cicd/internal/script/stdlib/json"
 	logModule "github.com/g2a-com/cicd/internal/script/stdlib/log"
+	yamlModule "github.com/g2a-com/cicd/internal/script/stdlib/yaml"
 	"github.com/g2a-com/cicd/internal/tengoutil"
 	logger "github.com/g2a-com/klio-logger-go/v2"
 )
 
+// tengoModules lists modules of the Tengo's standard library available in
+// scripts. The "os" module is excluded on purpose, since it gives unrestricted
+// access to the filesystem and processes.
+var tengoModules = []string{"base64", "enum", "hex", "json", "math", "rand", "text", "times"}
+
+// ModuleNames returns names of all modules which can be imported by scripts,
+// excluding libraries.
+func ModuleNames() []string {
+	names := append([]string{}, tengoModules...)
+	// "json" module from Tengo's standard library is replaced, so it's not
+	// listed again
+	return append(names, "fmt", "log", "yaml", "fs", "http", "exec", execModule.NativeModuleName, "git")
+}
+
 type stdlib struct {
-	logger   logger.Logger
-	builtins map[string]interface{}
+	ctx         context.Context
+	logger      logger.Logger
+	builtins    map[string]interface{}
+	fsOptions   fsModule.Options
+	execOptions execModule.Options
+	libraries   map[string]string
+	cleanups    []func() error
 }
 
 func New(l logger.Logger) *stdlib {
@@ -21,10 +48,12 @@ func New(l logger.Logger) *stdlib {
 	})
 
 	return &stdlib{
+		ctx:    context.Background(),
 		logger: l,
 		builtins: map[string]interface{}{
 			"abort": abort,
 		},
+		libraries: map[string]string{},
 	}
 }
 
@@ -36,11 +65,71 @@ func (s *stdlib) AddBuiltin(name string, value interface{}) (err error) {
 	return err
 }
 
+// AddLibrary registers source code which can be imported by scripts under the
+// given name. Libraries are compiled as Tengo source modules, so they can use
+// other modules and libraries, but not builtins added using AddBuiltin.
+func (s *stdlib) AddLibrary(name string, source string) error {
+	for _, n := range ModuleNames() {
+		if n == name {
+			return fmt.Errorf("library %q conflicts with a module of standard library", name)
+		}
+	}
+	if _, ok := s.libraries[name]; ok {
+		return fmt.Errorf("library %q is already registered in standard library", name)
+	}
+	s.libraries[name] = source
+	return nil
+}
+
+// SetFsOptions configures directories accessible using "fs" module. By
+// default, only the current working directory is accessible.
+func (s *stdlib) SetFsOptions(opts fsModule.Options) {
+	s.fsOptions = opts
+}
+
+// SetExecOptions configures commands which can be run using "exec" module. By
+// default, all commands are allowed.
+func (s *stdlib) SetExecOptions(opts execModule.Options) {
+	s.execOptions = opts
+}
+
+// SetContext sets context used to interrupt commands and requests started by
+// the script. By default, they are never interrupted.
+func (s *stdlib) SetContext(ctx context.Context) {
+	s.ctx = ctx
+}
+
 func (s *stdlib) InitializeScript(script *tengo.Script) error {
 	// Set imports
-	mm := tengo.NewModuleMap()
-	mm.Add("exec", execModule.New(s.logger))
+	mm := tengoStdlib.GetModuleMap(tengoModules...)
+	mm.AddBuiltinModule("fmt", map[string]tengo.Object{
+		// Printing functions are omitted, since they write directly to the
+		// standard output. Use "log" module instead.
+		"sprintf": tengoStdlib.BuiltinModules["fmt"]["sprintf"],
+	})
 	mm.Add("log", logModule.New(s.logger))
+	mm.Add("json", jsonModule.New())
+	mm.Add("yaml", yamlModule.New())
+	fsOptions := s.fsOptions
+	if fsOptions.ProjectDir == "" {
+		wd, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+		fsOptions.ProjectDir = wd
+	}
+	fs := fsModule.New(s.logger, fsOptions)
+	mm.Add("fs", fs)
+	mm.Add("http", httpModule.New(s.ctx, s.logger, fs.Resolve))
+	exec := execModule.New(s.ctx, s.logger, fs.Resolve, s.execOptions)
+	mm.Add("exec", exec)
+	mm.Add(execModule.NativeModuleName, exec)
+	mm.Add("git", gitModule.New(fsOptions.ProjectDir))
+	// Commands are stopped before removing temporary directories they may use
+	s.cleanups = append(s.cleanups, exec.Cleanup, fs.Cleanup)
+	for name, source := range s.libraries {
+		mm.AddSourceModule(name, []byte(source))
+	}
 	script.SetImports(mm)
 
 	// Set builtins
@@ -54,6 +143,19 @@ func (s *stdlib) InitializeScript(script *tengo.Script) error {
 	return nil
 }
 
+// Cleanup removes resources created by the script (e.g. temporary
+// directories). It should be called after script finishes.
+func (s *stdlib) Cleanup() error {
+	for _, cleanup := range s.cleanups {
+		err := cleanup()
+		if err != nil {
+			return err
+		}
+	}
+	s.cleanups = nil
+	return nil
+}
+
 type AbortError struct {
 	value interface{}
 }
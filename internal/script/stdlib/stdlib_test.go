@@ -30,8 +30,7 @@ func Test_builtin_abort_function_accepts_tengo_errors(t *testing.T) {
 }
 
 func Test_all_modules_can_be_imported(t *testing.T) {
-	modules := []string{"log", "exec"}
-	for _, module := range modules {
+	for _, module := range ModuleNames() {
 		t.Run(module, func(t *testing.T) {
 			stdlib := New(fakelogger.New())
 
@@ -68,3 +67,75 @@ func Test_logger_is_passed_down_to_modules(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Len(t, log.Messages, 1)
 }
+
+func Test_os_module_cannot_be_imported(t *testing.T) {
+	stdlib := New(fakelogger.New())
+
+	script := tengo.NewScript([]byte(`import("os")`))
+	_ = stdlib.InitializeScript(script)
+	_, err := script.Run()
+
+	assert.Error(t, err)
+}
+
+func Test_fmt_module_provides_only_sprintf(t *testing.T) {
+	stdlib := New(fakelogger.New())
+
+	script := tengo.NewScript([]byte(`
+		fmt := import("fmt")
+		result := fmt.sprintf("%d-%s", 7, "x")
+		hasPrint := is_undefined(fmt.println) ? false : true
+	`))
+	_ = stdlib.InitializeScript(script)
+	compiled, err := script.Run()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "7-x", compiled.Get("result").String())
+	assert.False(t, compiled.Get("hasPrint").Bool())
+}
+
+func Test_libraries_can_be_imported(t *testing.T) {
+	stdlib := New(fakelogger.New())
+
+	errAdd := stdlib.AddLibrary("helpers", `
+		text := import("text")
+		export { shout: func(s) { return text.to_upper(s) + "!" } }
+	`)
+	script := tengo.NewScript([]byte(`result := import("helpers").shout("test")`))
+	_ = stdlib.InitializeScript(script)
+	compiled, errRun := script.Run()
+
+	assert.NoError(t, errAdd)
+	assert.NoError(t, errRun)
+	assert.Equal(t, "TEST!", compiled.Get("result").String())
+}
+
+func Test_libraries_can_import_other_libraries(t *testing.T) {
+	stdlib := New(fakelogger.New())
+
+	_ = stdlib.AddLibrary("a", `export import("b") + 1`)
+	_ = stdlib.AddLibrary("b", `export 7`)
+	script := tengo.NewScript([]byte(`result := import("a")`))
+	_ = stdlib.InitializeScript(script)
+	compiled, err := script.Run()
+
+	assert.NoError(t, err)
+	assert.Equal(t, 8, compiled.Get("result").Int())
+}
+
+func Test_libraries_cannot_replace_modules(t *testing.T) {
+	stdlib := New(fakelogger.New())
+
+	err := stdlib.AddLibrary("exec", `export {}`)
+
+	assert.Error(t, err)
+}
+
+func Test_libraries_cannot_be_registered_twice(t *testing.T) {
+	stdlib := New(fakelogger.New())
+
+	_ = stdlib.AddLibrary("helpers", `export {}`)
+	err := stdlib.AddLibrary("helpers", `export {}`)
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,191 @@
+package yaml
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/d5/tengo/v2"
+	"github.com/g2a-com/cicd/internal/tengoutil"
+	"gopkg.in/yaml.v3"
+)
+
+type module struct{}
+
+func New() *module {
+	return &module{}
+}
+
+func (m *module) Import(name string) (interface{}, error) {
+	return tengoutil.ToImmutableObject(map[string]interface{}{
+		"__module_name__": name,
+		"decode":          m.decode,
+		"decode_all":      m.decodeAll,
+		"encode":          m.encode,
+	})
+}
+
+func (m *module) decode(data interface{}) tengo.Object {
+	docs := decodeAll(data)
+	if len(docs) == 0 {
+		return tengo.UndefinedValue
+	}
+	if len(docs) > 1 {
+		panic(errors.New("cannot decode YAML: found multiple documents, use decode_all instead"))
+	}
+	return docs[0]
+}
+
+func (m *module) decodeAll(data interface{}) []tengo.Object {
+	return decodeAll(data)
+}
+
+func (m *module) encode(obj tengo.Object) string {
+	var value interface{}
+	err := tengoutil.DecodeObject(obj, &value)
+	if err != nil {
+		panic(fmt.Errorf("cannot encode %s to YAML: %w", obj.TypeName(), err))
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	err = encoder.Encode(value)
+	if err == nil {
+		err = encoder.Close()
+	}
+	if err != nil {
+		panic(fmt.Errorf("cannot encode %s to YAML: %w", obj.TypeName(), err))
+	}
+	return buf.String()
+}
+
+func decodeAll(data interface{}) []tengo.Object {
+	var text string
+	switch d := data.(type) {
+	case string:
+		text = d
+	case []byte:
+		text = string(d)
+	default:
+		panic(fmt.Errorf("cannot decode YAML from %T, expected string or bytes", data))
+	}
+
+	results := []tengo.Object{}
+	decoder := yaml.NewDecoder(strings.NewReader(text))
+	for {
+		var node yaml.Node
+		err := decoder.Decode(&node)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			panic(fmt.Errorf("cannot decode YAML: %w", err))
+		}
+
+		value, err := convertNode(&node)
+		if err != nil {
+			panic(fmt.Errorf("cannot decode YAML: %w", err))
+		}
+		obj, err := tengoutil.ToObject(value)
+		if err != nil {
+			panic(fmt.Errorf("cannot decode YAML: %w", err))
+		}
+		results = append(results, obj)
+	}
+	return results
+}
+
+// convertNode converts YAML node to a value which can be converted to Tengo
+// object. Timestamps are left as strings, since Tengo has no dedicated type
+// for them.
+func convertNode(node *yaml.Node) (interface{}, error) {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		if len(node.Content) == 0 {
+			return nil, nil
+		}
+		return convertNode(node.Content[0])
+
+	case yaml.AliasNode:
+		return convertNode(node.Alias)
+
+	case yaml.SequenceNode:
+		result := make([]interface{}, len(node.Content))
+		for i, n := range node.Content {
+			value, err := convertNode(n)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = value
+		}
+		return result, nil
+
+	case yaml.MappingNode:
+		result := map[string]interface{}{}
+		for i := 0; i < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if key.Kind != yaml.ScalarNode {
+				return nil, fmt.Errorf("line %d: only scalar keys are supported", key.Line)
+			}
+			if key.ShortTag() == "!!merge" {
+				err := mergeInto(result, value)
+				if err != nil {
+					return nil, err
+				}
+				continue
+			}
+			converted, err := convertNode(value)
+			if err != nil {
+				return nil, err
+			}
+			result[key.Value] = converted
+		}
+		return result, nil
+
+	case yaml.ScalarNode:
+		switch node.ShortTag() {
+		case "!!null":
+			return nil, nil
+		case "!!bool", "!!int", "!!float":
+			var value interface{}
+			err := node.Decode(&value)
+			return value, err
+		case "!!binary":
+			var value []byte
+			err := node.Decode(&value)
+			return value, err
+		default:
+			return node.Value, nil
+		}
+	}
+
+	return nil, fmt.Errorf("line %d: unsupported node", node.Line)
+}
+
+// mergeInto handles merge keys ("<<"), values already present in the result
+// take precedence.
+func mergeInto(result map[string]interface{}, node *yaml.Node) error {
+	sources := []*yaml.Node{node}
+	if node.Kind == yaml.SequenceNode {
+		sources = node.Content
+	}
+	for _, source := range sources {
+		value, err := convertNode(source)
+		if err != nil {
+			return err
+		}
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("line %d: merged value must be a map", source.Line)
+		}
+		for k, v := range m {
+			if _, ok := result[k]; !ok {
+				result[k] = v
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package yaml
+
+import (
+	"testing"
+
+	"github.com/d5/tengo/v2"
+	"github.com/g2a-com/cicd/internal/tengoutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_decode_converts_yaml_to_objects(t *testing.T) {
+	result, err := run(`yaml.decode("a: [1, 1.5, str, true, null, 2021-01-01]")`)
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"a": []interface{}{1, 1.5, "str", true, nil, "2021-01-01"},
+	}, result)
+}
+
+func Test_decode_resolves_aliases_and_merge_keys(t *testing.T) {
+	result, err := run(`yaml.decode("base: &base {a: 1, b: 2}\nalias: *base\nmerged: {<<: *base, b: 3}")`)
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"base":   map[string]interface{}{"a": 1, "b": 2},
+		"alias":  map[string]interface{}{"a": 1, "b": 2},
+		"merged": map[string]interface{}{"a": 1, "b": 3},
+	}, result)
+}
+
+func Test_decode_aborts_script_on_multiple_documents(t *testing.T) {
+	_, err := run(`yaml.decode("a: 1\n---\nb: 2")`)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "decode_all")
+}
+
+func Test_decode_aborts_script_on_unsupported_keys(t *testing.T) {
+	_, err := run(`yaml.decode("? [a]\n: 1")`)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "only scalar keys are supported")
+}
+
+func Test_decode_aborts_script_on_invalid_yaml(t *testing.T) {
+	_, err := run(`yaml.decode("a: [")`)
+
+	assert.Error(t, err)
+}
+
+func Test_decode_all_returns_all_documents(t *testing.T) {
+	result, err := run(`yaml.decode_all("a: 1\n---\nb: 2")`)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"a": 1},
+		map[string]interface{}{"b": 2},
+	}, result)
+}
+
+func Test_encode_converts_objects_to_yaml(t *testing.T) {
+	result, err := run(`yaml.encode({b: [1, 1.5, "str", true, undefined], a: {c: "d"}})`)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "a:\n  c: d\nb:\n- 1\n- 1.5\n- str\n- true\n- null\n", result)
+}
+
+func Test_encode_aborts_script_on_unsupported_types(t *testing.T) {
+	_, err := run(`yaml.encode({a: func() {}})`)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot encode map to YAML")
+}
+
+func Test_objects_round_trip(t *testing.T) {
+	result, err := run(`yaml.decode(yaml.encode({a: [1, 2.5, {b: "true"}], d: false}))`)
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"a": []interface{}{1, 2.5, map[string]interface{}{"b": "true"}},
+		"d": false,
+	}, result)
+}
+
+func run(code string) (result interface{}, err error) {
+	modules := tengo.NewModuleMap()
+	modules.Add("yaml", New())
+	script := tengo.NewScript([]byte(`yaml := import("yaml"); result := ` + code))
+	script.SetImports(modules)
+	compiled, err := script.Run()
+	if err == nil {
+		err = tengoutil.DecodeObject(compiled.Get("result").Object(), &result)
+	}
+	return
+}
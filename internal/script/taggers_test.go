@@ -0,0 +1,160 @@
+package script
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/g2a-com/cicd/internal/object"
+	fakelogger "github.com/g2a-com/cicd/internal/utils/fake_logger"
+	gogit "github.com/go-git/go-git/v5"
+	gitobject "github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func Test_git_sha_tagger_returns_sha_of_head(t *testing.T) {
+	dir, repo := prepareRepo(t)
+	sha := commit(t, repo, "a.txt")
+
+	testCases := []struct {
+		name     string
+		spec     interface{}
+		expected string
+	}{
+		{name: "full", spec: map[string]interface{}{}, expected: sha},
+		{name: "undefined spec", spec: nil, expected: sha},
+		{name: "short", spec: map[string]interface{}{"length": 8}, expected: sha[:8]},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, _, err := runTagger(t, "gitSha", dir, tc.spec)
+
+			assert.NoError(t, err)
+			assert.Equal(t, []string{tc.expected}, IDs(result))
+		})
+	}
+}
+
+func Test_git_tag_tagger_returns_annotated_tag_pointing_at_head(t *testing.T) {
+	dir, repo := prepareRepo(t)
+	commit(t, repo, "a.txt")
+	tag(t, repo, "v1.0.0", true)
+	tag(t, repo, "latest", false)
+
+	result, _, err := runTagger(t, "gitTag", dir, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"v1.0.0"}, IDs(result))
+}
+
+func Test_git_tag_tagger_returns_no_tags_when_head_isnt_tagged(t *testing.T) {
+	testCases := []struct {
+		name    string
+		prepare func(t *testing.T) string
+	}{
+		{name: "not a repository", prepare: func(t *testing.T) string {
+			return t.TempDir()
+		}},
+		{name: "no commits", prepare: func(t *testing.T) string {
+			dir, _ := prepareRepo(t)
+			return dir
+		}},
+		{name: "not tagged", prepare: func(t *testing.T) string {
+			dir, repo := prepareRepo(t)
+			commit(t, repo, "a.txt")
+			tag(t, repo, "v1.0.0", true)
+			commit(t, repo, "b.txt")
+			return dir
+		}},
+		{name: "only lightweight tags", prepare: func(t *testing.T) string {
+			dir, repo := prepareRepo(t)
+			commit(t, repo, "a.txt")
+			tag(t, repo, "latest", false)
+			return dir
+		}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, log, err := runTagger(t, "gitTag", tc.prepare(t), nil)
+
+			assert.NoError(t, err)
+			assert.Empty(t, result)
+			assert.Contains(t, log.Messages, fakelogger.Message{Level: "verbose", Method: "Print", Args: []interface{}{"cannot find any git tags"}})
+		})
+	}
+}
+
+func runTagger(t *testing.T, name string, dir string, spec interface{}) ([]Result, *fakelogger.FakeLogger, error) {
+	filename := filepath.Join("..", "..", "assets", "executors", "taggers", name+".yaml")
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var node yaml.Node
+	if err := yaml.Unmarshal(content, &node); err != nil {
+		t.Fatal(err)
+	}
+	executor, err := object.NewExecutor(filename, &node)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	log := fakelogger.New()
+	script := New(executor)
+	script.Logger = log
+	input := map[string]interface{}{"dirs": map[string]interface{}{"project": dir}}
+	if spec != nil {
+		input["spec"] = spec
+	}
+	result, err := script.Run(input)
+	return result, log, err
+}
+
+func prepareRepo(t *testing.T) (string, *gogit.Repository) {
+	dir := t.TempDir()
+	repo, err := gogit.PlainInit(dir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir, repo
+}
+
+func commit(t *testing.T, repo *gogit.Repository, file string) string {
+	worktree, err := repo.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(worktree.Filesystem.Root(), file), []byte(file), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := worktree.Add(file); err != nil {
+		t.Fatal(err)
+	}
+	hash, err := worktree.Commit("Add "+file, &gogit.CommitOptions{Author: signature()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return hash.String()
+}
+
+func tag(t *testing.T, repo *gogit.Repository, name string, annotated bool) {
+	head, err := repo.Head()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var opts *gogit.CreateTagOptions
+	if annotated {
+		opts = &gogit.CreateTagOptions{Tagger: signature(), Message: "Release " + name}
+	}
+	if _, err := repo.CreateTag(name, head.Hash(), opts); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func signature() *gitobject.Signature {
+	return &gitobject.Signature{Name: "Test", Email: "test@example.com", When: time.Unix(1600000000, 0)}
+}
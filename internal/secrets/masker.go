@@ -0,0 +1,186 @@
+package secrets
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	logger "github.com/g2a-com/klio-logger-go/v2"
+)
+
+// Mask replaces secret values in masked texts.
+const Mask = "***"
+
+// Masker replaces secret values with a mask. Nil Masker doesn't mask
+// anything.
+type Masker struct {
+	replacer *strings.Replacer
+	values   []string
+}
+
+// NewMasker creates masker for specified values. Besides values themselves,
+// it masks also their JSON-escaped forms, so it can be used for JSON files.
+func NewMasker(values ...string) *Masker {
+	unique := map[string]bool{}
+	for _, value := range values {
+		if value == "" {
+			continue
+		}
+		unique[value] = true
+		escaped, _ := json.Marshal(value)
+		unique[string(escaped[1:len(escaped)-1])] = true
+	}
+	if len(unique) == 0 {
+		return nil
+	}
+
+	// Longer values go first, so values containing other values are masked
+	// entirely.
+	sorted := make([]string, 0, len(unique))
+	for value := range unique {
+		sorted = append(sorted, value)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if len(sorted[i]) != len(sorted[j]) {
+			return len(sorted[i]) > len(sorted[j])
+		}
+		return sorted[i] < sorted[j]
+	})
+
+	oldnew := make([]string, 0, len(sorted)*2)
+	for _, value := range sorted {
+		oldnew = append(oldnew, value, Mask)
+	}
+	return &Masker{strings.NewReplacer(oldnew...), sorted}
+}
+
+// Mask returns text with secret values masked.
+func (m *Masker) Mask(text string) string {
+	if m == nil {
+		return text
+	}
+	return m.replacer.Replace(text)
+}
+
+// MaskError returns error with secret values masked in its message.
+func (m *Masker) MaskError(err error) error {
+	if m == nil || err == nil {
+		return err
+	}
+	msg := err.Error()
+	if masked := m.Mask(msg); masked != msg {
+		return errors.New(masked)
+	}
+	return err
+}
+
+// incompleteSuffix returns position from which the text has to be held back,
+// because it may contain a secret value completed by the text written later.
+func (m *Masker) incompleteSuffix(text string) int {
+	pos := len(text)
+	for _, value := range m.values {
+		for i := len(text) - len(value) + 1; i < len(text); i++ {
+			if i < pos && i >= 0 && strings.HasPrefix(value, text[i:]) {
+				pos = i
+				break
+			}
+		}
+	}
+
+	// Values must not be split at the position, otherwise their beginning
+	// wouldn't be masked
+	for split := true; split; {
+		split = false
+		for _, value := range m.values {
+			start := pos - len(value) + 1
+			if start < 0 {
+				start = 0
+			}
+			if i := strings.Index(text[start:], value); i >= 0 && start+i < pos {
+				pos = start + i
+				split = true
+			}
+		}
+	}
+	return pos
+}
+
+// Logger wraps logger, so it masks secret values in all logged messages.
+func (m *Masker) Logger(l logger.Logger) logger.Logger {
+	if m == nil {
+		return l
+	}
+	if ml, ok := l.(*maskingLogger); ok && ml.masker == m {
+		return l
+	}
+	return &maskingLogger{Logger: l, masker: m}
+}
+
+// maskingLogger masks secret values in messages. Data passed to Write may be
+// split at any point (e.g. output of commands), so the end of data which may
+// be the beginning of a secret value is held back until more data is written
+// or Flush is called.
+type maskingLogger struct {
+	logger.Logger
+	masker  *Masker
+	mutex   sync.Mutex
+	pending string
+}
+
+func (l *maskingLogger) Print(v ...interface{}) logger.Logger {
+	_ = l.Flush()
+	l.Logger.Print(l.masker.Mask(fmt.Sprint(v...)))
+	return l
+}
+
+func (l *maskingLogger) Printf(format string, v ...interface{}) logger.Logger {
+	_ = l.Flush()
+	l.Logger.Print(l.masker.Mask(fmt.Sprintf(format, v...)))
+	return l
+}
+
+func (l *maskingLogger) Write(data []byte) (int, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	text := l.pending + string(data)
+	pos := l.masker.incompleteSuffix(text)
+	l.pending = text[pos:]
+	if pos > 0 {
+		_, err := l.Logger.Write([]byte(l.masker.Mask(text[:pos])))
+		if err != nil {
+			return 0, err
+		}
+	}
+	return len(data), nil
+}
+
+// Flush writes data held back by Write.
+func (l *maskingLogger) Flush() error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.pending == "" {
+		return nil
+	}
+	text := l.pending
+	l.pending = ""
+	_, err := l.Logger.Write([]byte(l.masker.Mask(text)))
+	return err
+}
+
+func (l *maskingLogger) WithLevel(level logger.Level) logger.Logger {
+	return &maskingLogger{Logger: l.Logger.WithLevel(level), masker: l.masker}
+}
+
+func (l *maskingLogger) WithTags(tags ...string) logger.Logger {
+	return &maskingLogger{Logger: l.Logger.WithTags(tags...), masker: l.masker}
+}
+
+func (l *maskingLogger) WithOutput(output io.Writer) logger.Logger {
+	return &maskingLogger{Logger: l.Logger.WithOutput(output), masker: l.masker}
+}
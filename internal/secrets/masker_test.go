@@ -0,0 +1,116 @@
+package secrets
+
+import (
+	"errors"
+	"testing"
+
+	fakelogger "github.com/g2a-com/cicd/internal/utils/fake_logger"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_masker_masks_all_occurrences_of_values(t *testing.T) {
+	masker := NewMasker("secret", "token")
+
+	result := masker.Mask("secret, token and secret again")
+
+	assert.Equal(t, "***, *** and *** again", result)
+}
+
+func Test_masker_masks_longer_values_first(t *testing.T) {
+	masker := NewMasker("abc", "abcdef")
+
+	result := masker.Mask("abcdef")
+
+	assert.Equal(t, "***", result)
+}
+
+func Test_masker_masks_json_escaped_values(t *testing.T) {
+	masker := NewMasker(`a"b\c`)
+
+	result := masker.Mask(`{"value": "a\"b\\c"}`)
+
+	assert.Equal(t, `{"value": "***"}`, result)
+}
+
+func Test_masker_ignores_empty_values(t *testing.T) {
+	masker := NewMasker("", "")
+
+	assert.Nil(t, masker)
+	assert.Equal(t, "text", masker.Mask("text"))
+}
+
+func Test_masker_masks_errors(t *testing.T) {
+	masker := NewMasker("secret")
+
+	err := masker.MaskError(errors.New("invalid password: secret"))
+
+	assert.EqualError(t, err, "invalid password: ***")
+}
+
+func Test_masking_logger_masks_all_messages(t *testing.T) {
+	log := fakelogger.New()
+	masker := NewMasker("secret")
+
+	l := masker.Logger(log).WithLevel("debug").WithTags("tag")
+	l.Print("print ", "secret")
+	l.Printf("printf %s", "secret")
+	_, _ = l.Write([]byte("write secret"))
+
+	assert.Equal(t, []fakelogger.Message{
+		{Level: "debug", Tags: []string{"tag"}, Method: "Print", Args: []interface{}{"print ***"}},
+		{Level: "debug", Tags: []string{"tag"}, Method: "Print", Args: []interface{}{"printf ***"}},
+		{Level: "debug", Tags: []string{"tag"}, Method: "Write", Args: []interface{}{[]byte("write ***")}},
+	}, log.Messages)
+}
+
+func Test_masking_logger_masks_secrets_split_between_writes(t *testing.T) {
+	log := fakelogger.New()
+	masker := NewMasker("secret")
+
+	l := masker.Logger(log)
+	_, _ = l.Write([]byte("password: sec"))
+	_, _ = l.Write([]byte("ret\ntoken: se"))
+	_, _ = l.Write([]byte("cret\n"))
+
+	assert.Equal(t, []fakelogger.Message{
+		{Method: "Write", Args: []interface{}{[]byte("password: ")}},
+		{Method: "Write", Args: []interface{}{[]byte("***\ntoken: ")}},
+		{Method: "Write", Args: []interface{}{[]byte("***\n")}},
+	}, log.Messages)
+}
+
+func Test_masking_logger_doesnt_split_secrets_when_holding_back_data(t *testing.T) {
+	log := fakelogger.New()
+	masker := NewMasker("abcd", "cdef")
+
+	l := masker.Logger(log)
+	_, _ = l.Write([]byte("xabcd"))
+	_ = l.(interface{ Flush() error }).Flush()
+
+	assert.Equal(t, []fakelogger.Message{
+		{Method: "Write", Args: []interface{}{[]byte("x")}},
+		{Method: "Write", Args: []interface{}{[]byte("***")}},
+	}, log.Messages)
+}
+
+func Test_masking_logger_writes_held_back_data_before_messages(t *testing.T) {
+	log := fakelogger.New()
+	masker := NewMasker("secret")
+
+	l := masker.Logger(log)
+	_, _ = l.Write([]byte("text s"))
+	l.Print("message")
+
+	assert.Equal(t, []fakelogger.Message{
+		{Method: "Write", Args: []interface{}{[]byte("text ")}},
+		{Method: "Write", Args: []interface{}{[]byte("s")}},
+		{Method: "Print", Args: []interface{}{"message"}},
+	}, log.Messages)
+}
+
+func Test_nil_masker_returns_logger_unchanged(t *testing.T) {
+	var masker *Masker
+	log := fakelogger.New()
+
+	assert.Same(t, log, masker.Logger(log))
+}
@@ -146,6 +146,9 @@ func toObject(value interface{}, immutable bool) (_ tengo.Object, err error) {
 
 	switch t.Kind() {
 	case reflect.Ptr:
+		if v.IsNil() {
+			return tengo.UndefinedValue, nil
+		}
 		return toObject(v.Elem().Interface(), false)
 
 	case reflect.String:
@@ -178,6 +181,9 @@ func toObject(value interface{}, immutable bool) (_ tengo.Object, err error) {
 		}
 
 	case reflect.Slice, reflect.Array:
+		if t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 {
+			return &tengo.Bytes{Value: append([]byte{}, v.Bytes()...)}, nil
+		}
 		objects := make([]tengo.Object, v.Len())
 		for i := range objects {
 			objects[i], err = toObject(v.Index(i).Interface(), false)
@@ -400,6 +406,9 @@ func decodeObject(obj tengo.Object, v reflect.Value) (err error) {
 		default:
 			return &DecodingError{Object: obj, Expected: "map"}
 		}
+		if v.IsNil() {
+			v.Set(reflect.MakeMapWithSize(t, len(entries)))
+		}
 		for key, val := range entries {
 			x := reflect.New(t.Elem()).Elem()
 			err := decodeObject(val, x)
@@ -214,6 +214,14 @@ func TestToObject(t *testing.T) {
 				"String": &tengo.String{},
 			}},
 		},
+		{ // 26
+			input:    []byte("abc"),
+			expected: &tengo.Bytes{Value: []byte("abc")},
+		},
+		{ // 27
+			input:    (*struct{})(nil),
+			expected: tengo.UndefinedValue,
+		},
 	}
 
 	for i, c := range cases {
@@ -965,6 +973,16 @@ func Test_decoding_map_to_map_is_valid(t *testing.T) {
 	assert.Equal(t, map[string]bool{"foo": true, "bar": false}, result)
 }
 
+func Test_decoding_map_to_nil_map_is_valid(t *testing.T) {
+	object := tengo.Map{Value: map[string]tengo.Object{"foo": tengo.TrueValue}}
+	var result map[string]bool
+
+	err := DecodeObject(&object, &result)
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]bool{"foo": true}, result)
+}
+
 func Test_decoding_map_to_map_fails_when_element_types_are_incompatible(t *testing.T) {
 	object := tengo.Map{Value: map[string]tengo.Object{"foo": tengo.TrueValue, "invalid": &tengo.String{}, "bar": tengo.FalseValue}}
 	result := map[string]bool{}
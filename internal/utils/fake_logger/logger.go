@@ -2,6 +2,7 @@ package fakelogger
 
 import (
 	"io"
+	"sync"
 
 	logger "github.com/g2a-com/klio-logger-go/v2"
 )
@@ -18,17 +19,25 @@ type FakeLogger struct {
 	tags     []string
 	output   io.Writer
 	root     *FakeLogger
+	mutex    *sync.Mutex
 	Messages []Message
 }
 
 func New() *FakeLogger {
-	l := &FakeLogger{}
+	l := &FakeLogger{mutex: &sync.Mutex{}}
 	l.root = l
 	return l
 }
 
+// record appends message to the root logger, it's safe for concurrent use.
+func (l *FakeLogger) record(msg Message) {
+	l.root.mutex.Lock()
+	defer l.root.mutex.Unlock()
+	l.root.Messages = append(l.root.Messages, msg)
+}
+
 func (l *FakeLogger) Print(v ...interface{}) logger.Logger {
-	l.root.Messages = append(l.root.Messages, Message{
+	l.record(Message{
 		Level:  l.level,
 		Tags:   l.tags,
 		Method: "Print",
@@ -38,7 +47,7 @@ func (l *FakeLogger) Print(v ...interface{}) logger.Logger {
 }
 
 func (l *FakeLogger) Printf(format string, v ...interface{}) logger.Logger {
-	l.root.Messages = append(l.root.Messages, Message{
+	l.record(Message{
 		Level:  l.level,
 		Tags:   l.tags,
 		Method: "Printf",
@@ -48,7 +57,7 @@ func (l *FakeLogger) Printf(format string, v ...interface{}) logger.Logger {
 }
 
 func (l *FakeLogger) Write(data []byte) (int, error) {
-	l.root.Messages = append(l.root.Messages, Message{
+	l.record(Message{
 		Level:  l.level,
 		Tags:   l.tags,
 		Method: "Write",
@@ -57,20 +66,26 @@ func (l *FakeLogger) Write(data []byte) (int, error) {
 	return len(data), nil
 }
 
+// clone copies the logger without its messages, which are stored by the root
+// logger and may be modified concurrently.
+func (l *FakeLogger) clone() FakeLogger {
+	return FakeLogger{level: l.level, tags: l.tags, output: l.output, root: l.root, mutex: l.mutex}
+}
+
 func (l *FakeLogger) WithLevel(level logger.Level) logger.Logger {
-	n := *l
+	n := l.clone()
 	n.level = level
 	return &n
 }
 
 func (l *FakeLogger) WithTags(tags ...string) logger.Logger {
-	n := *l
+	n := l.clone()
 	n.tags = tags
 	return &n
 }
 
 func (l *FakeLogger) WithOutput(output io.Writer) logger.Logger {
-	n := *l
+	n := l.clone()
 	n.output = output
 	return &n
 }
@@ -0,0 +1,58 @@
+package utils
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	execModule "github.com/g2a-com/cicd/internal/script/stdlib/exec"
+	log "github.com/g2a-com/klio-logger-go/v2"
+)
+
+// InterruptContext returns context which is cancelled when the process
+// receives SIGINT or SIGTERM, or when the timeout is exceeded (0 means no
+// timeout). Signals are handled until the returned function is called, so
+// commands can be stopped gracefully. The next signal kills all running
+// commands and terminates the process immediately.
+func InterruptContext(l log.Logger, timeout time.Duration) (context.Context, context.CancelFunc) {
+	var ctx context.Context
+	var cancel context.CancelFunc
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(context.Background(), timeout)
+	} else {
+		ctx, cancel = context.WithCancel(context.Background())
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	stopped := make(chan struct{})
+
+	go func() {
+		defer signal.Stop(signals)
+		interrupted := false
+		for {
+			select {
+			case sig := <-signals:
+				if interrupted {
+					l.WithLevel(log.ErrorLevel).Printf("Received %s, exiting immediately", sig)
+					execModule.KillAll()
+					os.Exit(128 + int(sig.(syscall.Signal)))
+				}
+				interrupted = true
+				l.WithLevel(log.WarnLevel).Printf("Received %s, stopping (repeat to exit immediately)...", sig)
+				cancel()
+			case <-stopped:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return ctx, func() {
+		cancel()
+		once.Do(func() { close(stopped) })
+	}
+}
@@ -3,10 +3,14 @@ package utils
 import (
 	"encoding/json"
 	"os"
+
+	"github.com/g2a-com/cicd/internal/secrets"
 )
 
-func SaveResult(file string, result interface{}) {
+// SaveResult writes result as JSON to the file, values of secrets are masked.
+func SaveResult(file string, result interface{}, masker *secrets.Masker) {
 	content, _ := json.MarshalIndent(result, "", "  ")
+	content = []byte(masker.Mask(string(content)))
 	err := os.WriteFile(file, append(content, '\n'), 0644)
 	if err != nil {
 		panic(err)
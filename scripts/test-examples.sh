@@ -11,6 +11,21 @@ diff() {
 	git diff -- ./*
 }
 
+# Replaces values which differ between runs (timings of entries) and machines
+# (location of the repository), so outputs can be compared with committed ones
+normalize() {
+	for FILE in "$@"; do
+		sed -E \
+			-e 's/"(startTime|endTime)": "[^"]*"/"\1": "<time>"/' \
+			-e 's/"duration": [0-9.e+-]+/"duration": 0/' \
+			-e "s#${REPO_DIR}#<repo>#g" \
+			"${FILE}" >"${FILE}.tmp"
+		mv "${FILE}.tmp" "${FILE}"
+	done
+}
+
+REPO_DIR="$(pwd)"
+
 cd examples
 
 for DIR in *; do
@@ -18,6 +33,7 @@ for DIR in *; do
 	cd "${DIR}"
 	../../build --param param=value >build-output.txt
 	../../deploy -t latest -e local --param param=value >deploy-output.txt
+	normalize build-output.txt build-result.json deploy-output.txt deploy-result.json
 	if [ -n "$(diff)" ]; then
 		echo
 		diff